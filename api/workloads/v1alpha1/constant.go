@@ -21,6 +21,11 @@ const (
 	// Used as the match label for topology affinity.
 	SetGroupUniqueHashLabelKey = RBGPrefix + "group-unique-key"
 
+	// SetRoleUniqueHashLabelKey carries a hash extending the group unique key with the role name.
+	// It is set on the Pods of the roles when a topology level with Role or RoleInstance scope is declared,
+	// and used as the match label for their topology affinity.
+	SetRoleUniqueHashLabelKey = RBGPrefix + "role-unique-key"
+
 	// ExclusiveKeyAnnotationKey declares the topology domain (e.g. kubernetes.io/hostname)
 	// for 1:1 exclusive scheduling.  When present on RoleBasedGroup or Role template,
 	// all affected Pods are required to land on the same topology domain and block
@@ -41,14 +46,44 @@ const (
 
 	RoleSizeAnnotationKey string = RBGPrefix + "role-size"
 
+	// EvictRoleInstanceAnnotationKey is set to "true" on the pods of a role instance whose eviction, together with
+	// a disrupted pod of the instance, is blocked by the disruption budget of the role. The eviction of the pods
+	// is retried until it succeeds, even once the disrupted pod is gone.
+	EvictRoleInstanceAnnotationKey = RBGPrefix + "evict-role-instance"
+
+	// RoleInstanceResourceClaimsLabelKey is set to "true" on the pods of a LeaderWorkerSet role declaring
+	// RoleInstance resource claims. It selects the pods for the pod webhook of the controller.
+	RoleInstanceResourceClaimsLabelKey = RBGPrefix + "role-instance-resource-claims"
+
+	// RoleInstanceResourceClaimsAnnotationKey maps the names of the RoleInstance resource claims in the
+	// resourceClaims of the pods to the name prefix of their ResourceClaims, as a JSON object. The pod webhook
+	// sets the ResourceClaim of the group of the pod, named after the prefix and the group index.
+	RoleInstanceResourceClaimsAnnotationKey = RBGPrefix + "role-instance-resource-claims"
+
+	// AllowedDependentNamespacesAnnotationKey lists the other namespaces, separated by commas, whose rbgs may depend
+	// on the roles of the rbg. The rbgs of other namespaces are reported a dependency on the rbg as not found.
+	AllowedDependentNamespacesAnnotationKey = RBGPrefix + "allowed-dependent-namespaces"
+
+	// TeardownFinalizer is set on every RoleBasedGroup. It holds the deletion of the rbg until the workloads of
+	// its roles are deleted in reverse dependency order.
+	TeardownFinalizer = RBGPrefix + "ordered-teardown"
+
+	// DefaultDrainTimeoutSeconds is the drain timeout of the roles without drainTimeoutSeconds.
+	DefaultDrainTimeoutSeconds = 300
+
 	// RBGSetPrefix rbgs prefix for all rbgs
 	RBGSetPrefix = "rolebasedgroupset.workloads.x-k8s.io/"
 
-	// SetRBGSetNameLabelKey identifies resources belonging to a specific RoleBasedGroupSet
+	// SetRBGSetNameLabelKey identifies resources belonging to a specific RoleBasedGroupSet.
+	// It is set on the child rbgs and propagated down to their pods.
 	SetRBGSetNameLabelKey = RBGSetPrefix + "name"
 
 	// SetRBGIndexLabelKey SetRBGIndex identifies the index of the rbg within the rbgset
 	SetRBGIndexLabelKey = RBGSetPrefix + "rbg-index"
+
+	// RBGSetSpecifiedDeleteLabelKey is a label used to mark that the rbg should be deleted by the rbgset.
+	// The rbg is deleted regardless of scaling, and replaced if the rbgset still needs the replica.
+	RBGSetSpecifiedDeleteLabelKey = RBGSetPrefix + "specified-delete"
 )
 
 // InstanceSet labels and annotations
@@ -93,6 +128,10 @@ const (
 	// InstancePodReadyConditionType corresponding condition status was set to "False" by multiple writers,
 	// the condition status will be considered as "True" only when all these writers set it to "True".
 	InstancePodReadyConditionType v1.PodConditionType = "InstancePodReady"
+
+	// RoleDependenciesReadyConditionType is the condition of the readiness gate of the pods of roles with
+	// dependencyReadinessGate. It is false while any dependency of the role is not ready.
+	RoleDependenciesReadyConditionType v1.PodConditionType = "RoleDependenciesReady"
 )
 
 type RolloutStrategyType string
@@ -123,10 +162,25 @@ const (
 	RecreateRoleInstanceOnPodRestart RestartPolicyType = "RecreateRoleInstanceOnPodRestart"
 )
 
+type EvictionPolicyType string
+
+const (
+	// NoneEvictionPolicy only evicts the pods the evictions are requested for, e.g. by a node drain.
+	NoneEvictionPolicy EvictionPolicyType = "None"
+
+	// RecreateRoleInstanceOnEviction evicts the other pods of a role instance together with an evicted pod, so that
+	// the whole instance is recreated elsewhere. It applies to the groups of LeaderWorkerSet roles, whose pods
+	// cannot serve without each other.
+	RecreateRoleInstanceOnEviction EvictionPolicyType = "RecreateRoleInstanceOnEviction"
+)
+
 const (
 	DeploymentWorkloadType      string = "apps/v1/Deployment"
 	StatefulSetWorkloadType     string = "apps/v1/StatefulSet"
 	LeaderWorkerSetWorkloadType string = "leaderworkerset.x-k8s.io/v1/LeaderWorkerSet"
+	JobWorkloadType             string = "batch/v1/Job"
+	// AdvancedStatefulSetWorkloadType is the OpenKruise Advanced StatefulSet, updating pods in place when possible
+	AdvancedStatefulSetWorkloadType string = "apps.kruise.io/v1beta1/StatefulSet"
 )
 
 type AdapterPhase string
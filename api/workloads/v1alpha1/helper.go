@@ -6,6 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func (rbg *RoleBasedGroup) GetCommonLabelsFromRole(role *RoleSpec) map[string]string {
@@ -26,12 +31,8 @@ func (rbg *RoleBasedGroup) GetCommonAnnotationsFromRole(role *RoleSpec) map[stri
 
 func (rbg *RoleBasedGroup) GetGroupSize() int {
 	ret := 0
-	for _, role := range rbg.Spec.Roles {
-		if role.Workload.String() == LeaderWorkerSetWorkloadType {
-			ret += int(*role.LeaderWorkerSet.Size) * int(*role.Replicas)
-		} else {
-			ret += int(*role.Replicas)
-		}
+	for i := range rbg.Spec.Roles {
+		ret += rbg.GetRolePodGroupSize(&rbg.Spec.Roles[i])
 	}
 	return ret
 }
@@ -102,6 +103,11 @@ func (rbg *RoleBasedGroup) GenGroupUniqueKey() string {
 	return sha1Hash(fmt.Sprintf("%s/%s", rbg.GetNamespace(), rbg.GetName()))
 }
 
+// GenRoleUniqueKey extends the group unique key with the name of the role.
+func (rbg *RoleBasedGroup) GenRoleUniqueKey(role *RoleSpec) string {
+	return sha1Hash(fmt.Sprintf("%s/%s", rbg.GenGroupUniqueKey(), role.Name))
+}
+
 // sha1Hash accepts an input string and returns the 40 character SHA1 hash digest of the input string.
 func sha1Hash(s string) string {
 	h := sha1.New()
@@ -123,6 +129,10 @@ func (rbg *RoleBasedGroup) IsVolcanoGangScheduling() bool {
 	return false
 }
 
+func (rbg *RoleBasedGroup) IsSchedulingGatesGangScheduling() bool {
+	return rbg.Spec.PodGroupPolicy.IsSchedulingGatesGangScheduling()
+}
+
 func (rbg *RoleBasedGroup) IsKubeGangScheduling() bool {
 	if rbg.Spec.PodGroupPolicy != nil && rbg.Spec.PodGroupPolicy.PodGroupPolicySource.KubeScheduling != nil {
 		return true
@@ -147,6 +157,137 @@ func (p *PodGroupPolicy) IsVolcanoGangScheduling() bool {
 	return p != nil && p.PodGroupPolicySource.VolcanoScheduling != nil
 }
 
+func (p *PodGroupPolicy) IsSchedulingGatesGangScheduling() bool {
+	return p != nil && p.PodGroupPolicySource.SchedulingGates != nil
+}
+
 func (p *PodGroupPolicy) IsKubeGangScheduling() bool {
 	return p != nil && p.PodGroupPolicySource.KubeScheduling != nil
 }
+
+// GetScope returns the PodGroup scope, defaulting to Group.
+func (p *PodGroupPolicy) GetScope() PodGroupScopeType {
+	if p == nil || p.Scope == "" {
+		return GroupPodGroupScope
+	}
+	return p.Scope
+}
+
+// GetOnTimeout returns the action taken on a schedule timeout of the PodGroups, defaulting to Wait.
+func (p *PodGroupPolicy) GetOnTimeout() PodGroupTimeoutAction {
+	if p == nil || p.OnTimeout == "" {
+		return WaitPodGroupTimeoutAction
+	}
+	return p.OnTimeout
+}
+
+// IsGangShrunk returns true if the optional roles are left out of the gang of the current generation.
+func (rbg *RoleBasedGroup) IsGangShrunk() bool {
+	return rbg.isConditionTrueForGeneration(RoleBasedGroupGangShrunk)
+}
+
+// IsSuspendedOnTimeout returns true if the workloads of the roles are deleted after a schedule timeout of the
+// current generation.
+func (rbg *RoleBasedGroup) IsSuspendedOnTimeout() bool {
+	return rbg.isConditionTrueForGeneration(RoleBasedGroupSuspendedOnTimeout)
+}
+
+func (rbg *RoleBasedGroup) isConditionTrueForGeneration(conditionType RoleBasedGroupConditionType) bool {
+	condition := meta.FindStatusCondition(rbg.Status.Conditions, string(conditionType))
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == rbg.Generation
+}
+
+// GetRoleMinAvailable returns the number of pods of a role that must be scheduled together with the group.
+func (rbg *RoleBasedGroup) GetRoleMinAvailable(role *RoleSpec) int {
+	size := rbg.GetRolePodGroupSize(role)
+	if role.MinAvailable != nil && int(*role.MinAvailable) < size {
+		return int(*role.MinAvailable)
+	}
+	return size
+}
+
+// GetRolePodGroupSize returns the number of pods of a role that must be scheduled together.
+func (rbg *RoleBasedGroup) GetRolePodGroupSize(role *RoleSpec) int {
+	if role.Workload.String() == LeaderWorkerSetWorkloadType {
+		return int(*role.LeaderWorkerSet.Size) * int(*role.Replicas)
+	}
+	return int(*role.Replicas)
+}
+
+// GetDependencies returns the dependencies of the role: the roles listed in dependencies, with their condition in
+// dependencyConditions if declared, followed by the other entries of dependencyConditions.
+func (role *RoleSpec) GetDependencies() []RoleDependency {
+	dependencies := make([]RoleDependency, 0, len(role.Dependencies)+len(role.DependencyConditions))
+	listed := map[string]bool{}
+	for _, name := range role.Dependencies {
+		if listed[name] {
+			continue
+		}
+		listed[name] = true
+		dependency := RoleDependency{Role: name}
+		for _, condition := range role.DependencyConditions {
+			if condition.Role == name && !condition.IsExternal() {
+				dependency = condition
+				break
+			}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	for _, condition := range role.DependencyConditions {
+		if condition.IsExternal() || !listed[condition.Role] {
+			dependencies = append(dependencies, condition)
+		}
+	}
+	return dependencies
+}
+
+// GetDependencyNames returns the names of the roles of the same rbg the role depends on.
+func (role *RoleSpec) GetDependencyNames() []string {
+	dependencies := role.GetDependencies()
+	names := make([]string, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		if !dependency.IsExternal() {
+			names = append(names, dependency.Role)
+		}
+	}
+	return names
+}
+
+// IsExternal returns true if the dependency is a role of another rbg.
+func (d *RoleDependency) IsExternal() bool {
+	return d.RBG != ""
+}
+
+// GetDependencyRBG returns the namespaced name of the rbg a dependency of one of its roles belongs to.
+func (rbg *RoleBasedGroup) GetDependencyRBG(d *RoleDependency) types.NamespacedName {
+	if !d.IsExternal() {
+		return types.NamespacedName{Namespace: rbg.Namespace, Name: rbg.Name}
+	}
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = rbg.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: d.RBG}
+}
+
+// AllowsDependentNamespace returns true if the rbgs of a namespace may depend on the roles of the rbg, i.e. if the
+// namespace is the namespace of the rbg or listed in the allowed-dependent-namespaces annotation.
+func (rbg *RoleBasedGroup) AllowsDependentNamespace(namespace string) bool {
+	if namespace == rbg.Namespace {
+		return true
+	}
+	for _, allowed := range strings.Split(rbg.Annotations[AllowedDependentNamespacesAnnotationKey], ",") {
+		if strings.TrimSpace(allowed) == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRoleDrainTimeout returns how long the teardown of the rbg waits for the pods of a role to terminate.
+func (rbg *RoleBasedGroup) GetRoleDrainTimeout(role *RoleSpec) time.Duration {
+	if role.DrainTimeoutSeconds != nil {
+		return time.Duration(*role.DrainTimeoutSeconds) * time.Second
+	}
+	return DefaultDrainTimeoutSeconds * time.Second
+}
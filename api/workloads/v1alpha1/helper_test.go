@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestRoleBasedGroup_GenGroupUniqueKey(t *testing.T) {
@@ -37,3 +38,69 @@ func TestRoleBasedGroup_GetExclusiveKey(t *testing.T) {
 		})
 	}
 }
+
+func TestRoleSpec_GetDependencies(t *testing.T) {
+	minReady := intstr.FromString("90%")
+	decode := RoleDependency{Role: "decode", Readiness: &DependencyReadiness{MinReady: &minReady}}
+	router := RoleDependency{Role: "router", RBG: "kv-cache", Namespace: "shared"}
+	tests := []struct {
+		name         string
+		role         RoleSpec
+		dependencies []RoleDependency
+		names        []string
+	}{
+		{
+			name:         "names",
+			role:         RoleSpec{Dependencies: []string{"prefill", "decode"}},
+			dependencies: []RoleDependency{{Role: "prefill"}, {Role: "decode"}},
+			names:        []string{"prefill", "decode"},
+		},
+		{
+			name: "condition of a listed role",
+			role: RoleSpec{
+				Dependencies:         []string{"prefill", "decode"},
+				DependencyConditions: []RoleDependency{decode},
+			},
+			dependencies: []RoleDependency{{Role: "prefill"}, decode},
+			names:        []string{"prefill", "decode"},
+		},
+		{
+			name:         "condition of a role not listed",
+			role:         RoleSpec{DependencyConditions: []RoleDependency{decode}},
+			dependencies: []RoleDependency{decode},
+			names:        []string{"decode"},
+		},
+		{
+			name: "role of another rbg",
+			role: RoleSpec{
+				Dependencies:         []string{"router"},
+				DependencyConditions: []RoleDependency{router},
+			},
+			dependencies: []RoleDependency{{Role: "router"}, router},
+			names:        []string{"router"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.dependencies, tt.role.GetDependencies())
+			assert.Equal(t, tt.names, tt.role.GetDependencyNames())
+		})
+	}
+}
+
+func TestRoleBasedGroup_AllowsDependentNamespace(t *testing.T) {
+	rbg := &RoleBasedGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "kv-cache",
+			Namespace:   "shared",
+			Annotations: map[string]string{AllowedDependentNamespacesAnnotationKey: "team-a, team-b"},
+		},
+	}
+
+	assert.True(t, rbg.AllowsDependentNamespace("shared"))
+	assert.True(t, rbg.AllowsDependentNamespace("team-a"))
+	assert.True(t, rbg.AllowsDependentNamespace("team-b"))
+	assert.False(t, rbg.AllowsDependentNamespace("team-c"))
+	assert.False(t, (&RoleBasedGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "shared"}}).AllowsDependentNamespace("team-a"))
+}
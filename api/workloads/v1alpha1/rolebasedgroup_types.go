@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -38,14 +39,186 @@ type RoleBasedGroupSpec struct {
 
 	// Configuration for the PodGroup to enable gang-scheduling via supported plugins.
 	PodGroupPolicy *PodGroupPolicy `json:"podGroupPolicy,omitempty"`
+
+	// Placement declares where the pods of the roles are placed relative to each other.
+	// +optional
+	Placement *PlacementPolicy `json:"placement,omitempty"`
+
+	// Topology declares nested topology domains the pods of the group, its roles or its role instances are placed in.
+	// +optional
+	Topology *TopologyPolicy `json:"topology,omitempty"`
+
+	// Suspend scales the workloads of all roles to zero in reverse dependency order, keeping their services and
+	// configuration. Resuming restores the replicas of the roles in dependency order.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// TopologyPolicy declares an ordered list of topology levels, from the outermost to the innermost domain,
+// e.g. the group inside one spine and each LeaderWorkerSet instance inside one NVLink domain.
+type TopologyPolicy struct {
+	// Levels of the topology, from the outermost to the innermost domain.
+	// +kubebuilder:validation:MinItems=1
+	Levels []TopologyLevel `json:"levels"`
+}
+
+// TopologyLevel requires the pods of each member of a scope to land in one domain of a topology key.
+type TopologyLevel struct {
+	// TopologyKey is the node label of the topology domain, e.g. network.example.com/spine.
+	// +kubebuilder:validation:MinLength=1
+	TopologyKey string `json:"topologyKey"`
+
+	// Scope of the level. Group places all pods of the group in one domain, Role the pods of each role,
+	// and RoleInstance the pods of each LeaderWorkerSet group. Defaults to Group.
+	// +kubebuilder:validation:Enum={Group,Role,RoleInstance}
+	// +kubebuilder:default=Group
+	// +optional
+	Scope TopologyScopeType `json:"scope,omitempty"`
+
+	// Roles the level applies to. Defaults to all roles.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+}
+
+type TopologyScopeType string
+
+const (
+	// GroupTopologyScope places all pods of the group in one domain.
+	GroupTopologyScope TopologyScopeType = "Group"
+
+	// RoleTopologyScope places the pods of each role in one domain.
+	RoleTopologyScope TopologyScopeType = "Role"
+
+	// RoleInstanceTopologyScope places the pods of each LeaderWorkerSet group in one domain.
+	RoleInstanceTopologyScope TopologyScopeType = "RoleInstance"
+)
+
+// PlacementPolicy declares placement constraints between the roles of a RoleBasedGroup.
+// The rules are translated into pod affinity, pod anti-affinity and topology spread constraints
+// of the pod templates of the roles, in addition to those of the templates.
+type PlacementPolicy struct {
+	// Rules of the placement.
+	// +optional
+	Rules []PlacementRule `json:"rules,omitempty"`
 }
 
+// PlacementRule is a placement constraint over a topology domain.
+type PlacementRule struct {
+	// Type of the rule. Colocate places the pods of the roles in the same topology domain,
+	// Spread spreads the pods of each role across topology domains, and Separate keeps the pods of
+	// the roles out of the topology domains of the pods of the target roles.
+	// +kubebuilder:validation:Enum={Colocate,Spread,Separate}
+	// +kubebuilder:validation:Required
+	Type PlacementRuleType `json:"type"`
+
+	// Roles the rule applies to.
+	// +kubebuilder:validation:MinItems=1
+	Roles []string `json:"roles"`
+
+	// TargetRoles whose pods the pods of a Separate rule avoid. Defaults to the roles of the rule,
+	// i.e. their pods avoid each other.
+	// +optional
+	TargetRoles []string `json:"targetRoles,omitempty"`
+
+	// TopologyKey is the node label of the topology domain, e.g. kubernetes.io/hostname.
+	// +kubebuilder:validation:MinLength=1
+	TopologyKey string `json:"topologyKey"`
+
+	// Mode of the rule. Required rules must be met to schedule a pod, Preferred rules are best-effort.
+	// Defaults to Required.
+	// +kubebuilder:validation:Enum={Required,Preferred}
+	// +kubebuilder:default=Required
+	// +optional
+	Mode PlacementRuleMode `json:"mode,omitempty"`
+
+	// Weight of a Preferred Colocate or Separate rule, in the range 1-100. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// MaxSkew of a Spread rule. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+}
+
+type PlacementRuleType string
+
+const (
+	// ColocatePlacementRule places the pods of the roles in the same topology domain.
+	ColocatePlacementRule PlacementRuleType = "Colocate"
+
+	// SpreadPlacementRule spreads the pods of each role across topology domains.
+	SpreadPlacementRule PlacementRuleType = "Spread"
+
+	// SeparatePlacementRule keeps the pods of the roles out of the topology domains of the pods of the target roles.
+	SeparatePlacementRule PlacementRuleType = "Separate"
+)
+
+type PlacementRuleMode string
+
+const (
+	// RequiredPlacementRuleMode rules must be met to schedule a pod.
+	RequiredPlacementRuleMode PlacementRuleMode = "Required"
+
+	// PreferredPlacementRuleMode rules are met if possible.
+	PreferredPlacementRuleMode PlacementRuleMode = "Preferred"
+)
+
 // PodGroupPolicy represents a PodGroup configuration for gang-scheduling.
 type PodGroupPolicy struct {
 	// Configuration for gang-scheduling using various plugins.
 	PodGroupPolicySource `json:",inline"`
+
+	// Scope defines the granularity of the PodGroups created for the RoleBasedGroup.
+	// Group creates a single PodGroup for all pods of the group, Role creates one PodGroup per role,
+	// and RoleInstance creates one PodGroup per LeaderWorkerSet group (leader + workers).
+	// Roles that are not LeaderWorkerSets are not gang-scheduled with RoleInstance scope,
+	// since each of their instances is a single pod.
+	// Defaults to Group.
+	// +kubebuilder:validation:Enum={Group,Role,RoleInstance}
+	// +kubebuilder:default=Group
+	// +optional
+	Scope PodGroupScopeType `json:"scope,omitempty"`
+
+	// OnTimeout is the action taken once the PodGroups of the RoleBasedGroup are not scheduled within the
+	// schedule timeout of the gang-scheduling plugin. Wait keeps waiting, ShrinkOptionalRoles leaves the
+	// optional roles out of the gang, and Suspend deletes the workloads of all roles.
+	// The action is reverted once the spec of the RoleBasedGroup changes.
+	// Only used with kubeScheduling and volcanoScheduling. Defaults to Wait.
+	// +kubebuilder:validation:Enum={Wait,ShrinkOptionalRoles,Suspend}
+	// +kubebuilder:default=Wait
+	// +optional
+	OnTimeout PodGroupTimeoutAction `json:"onTimeout,omitempty"`
 }
 
+type PodGroupTimeoutAction string
+
+const (
+	// WaitPodGroupTimeoutAction keeps waiting for the PodGroups to be scheduled.
+	WaitPodGroupTimeoutAction PodGroupTimeoutAction = "Wait"
+
+	// ShrinkOptionalRolesPodGroupTimeoutAction leaves the pods of the optional roles out of the gang.
+	ShrinkOptionalRolesPodGroupTimeoutAction PodGroupTimeoutAction = "ShrinkOptionalRoles"
+
+	// SuspendPodGroupTimeoutAction deletes the workloads of all roles, releasing the resources already held.
+	SuspendPodGroupTimeoutAction PodGroupTimeoutAction = "Suspend"
+)
+
+type PodGroupScopeType string
+
+const (
+	// GroupPodGroupScope creates one PodGroup covering every pod of the RoleBasedGroup.
+	GroupPodGroupScope PodGroupScopeType = "Group"
+
+	// RolePodGroupScope creates one PodGroup per role.
+	RolePodGroupScope PodGroupScopeType = "Role"
+
+	// RoleInstancePodGroupScope creates one PodGroup per role instance.
+	RoleInstancePodGroupScope PodGroupScopeType = "RoleInstance"
+)
+
 // PodGroupPolicySource represents supported plugins for gang-scheduling.
 // Only one of its members may be specified.
 type PodGroupPolicySource struct {
@@ -53,6 +226,10 @@ type PodGroupPolicySource struct {
 	KubeScheduling *KubeSchedulingPodGroupPolicySource `json:"kubeScheduling,omitempty"`
 
 	VolcanoScheduling *VolcanoSchedulingPodGroupPolicySource `json:"volcanoScheduling,omitempty"`
+
+	// SchedulingGates provides all-or-nothing group start with native pod scheduling gates,
+	// without a third-party gang scheduler.
+	SchedulingGates *SchedulingGatesPodGroupPolicySource `json:"schedulingGates,omitempty"`
 }
 
 // KubeSchedulingPodGroupPolicySource represents configuration for  Kubernetes scheduling plugin.
@@ -65,6 +242,18 @@ type KubeSchedulingPodGroupPolicySource struct {
 	ScheduleTimeoutSeconds *int32 `json:"scheduleTimeoutSeconds,omitempty"`
 }
 
+// SchedulingGatesPodGroupPolicySource represents configuration for gang-scheduling with native pod scheduling gates.
+// All pods of the group are created with a scheduling gate, which is removed only once every pod of the group
+// exists and the free allocatable resources of the nodes can fit the requests of all pods.
+// The PodGroup scope is ignored, the gates always cover the whole group.
+type SchedulingGatesPodGroupPolicySource struct {
+	// Time threshold after which the SchedulingGated condition reports a timeout. The pods stay gated.
+	// Defaults to 300 seconds.
+	// +kubebuilder:default=300
+	// +optional
+	ScheduleTimeoutSeconds *int32 `json:"scheduleTimeoutSeconds,omitempty"`
+}
+
 // VolcanoSchedulingPodGroupPolicySource represents configuration for volcano podgroup scheduling plugin
 type VolcanoSchedulingPodGroupPolicySource struct {
 	// If specified, indicates the PodGroup's priority. "system-node-critical" and
@@ -80,6 +269,12 @@ type VolcanoSchedulingPodGroupPolicySource struct {
 	// the PodGroup will not be scheduled. Defaults to `default` Queue with the lowest weight.
 	// +optional
 	Queue string `json:"queue,omitempty"`
+
+	// Time threshold after which an unschedulable PodGroup is handled by podGroupPolicy.onTimeout.
+	// Defaults to 300 seconds.
+	// +kubebuilder:default=300
+	// +optional
+	ScheduleTimeoutSeconds *int32 `json:"scheduleTimeoutSeconds,omitempty"`
 }
 
 // RolloutStrategy defines the strategy that the rbg controller
@@ -166,10 +361,48 @@ type RoleSpec struct {
 	// +optional
 	RestartPolicy RestartPolicyType `json:"restartPolicy,omitempty"`
 
-	// Dependencies of the role
+	// EvictionPolicy defines how the evictions of the pods of the role are handled. RecreateRoleInstanceOnEviction
+	// evicts the other pods of the instance of an evicted pod, respecting the disruption budget of the role.
+	// Defaults to None.
+	// +kubebuilder:validation:Enum={None,RecreateRoleInstanceOnEviction}
+	// +optional
+	EvictionPolicy EvictionPolicyType `json:"evictionPolicy,omitempty"`
+
+	// Dependencies of the role. The role is only created once its dependencies are ready, i.e. once all replicas
+	// of the roles are ready, unless dependencyConditions declares another condition.
 	// +optional
 	Dependencies []string `json:"dependencies,omitempty"`
 
+	// DependencyConditions declares the conditions under which dependencies are ready, and dependencies on roles
+	// of other rbgs. Every entry is a dependency of the role, whether or not it is listed in dependencies.
+	// +listType=atomic
+	// +optional
+	DependencyConditions []RoleDependency `json:"dependencyConditions,omitempty"`
+
+	// DependencyReadinessGate adds the RoleDependenciesReady readiness gate to the pods of the role. The rbg controller
+	// sets the condition to false while any dependency of the role is not ready, so that the pods stop receiving
+	// traffic from services, and back to true once all dependencies are ready.
+	// +optional
+	DependencyReadinessGate bool `json:"dependencyReadinessGate,omitempty"`
+
+	// DrainTimeoutSeconds is how long the teardown of the rbg waits for the pods of the role to terminate, before
+	// they are force deleted and the teardown continues with the dependencies of the role. Defaults to 300 seconds.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DrainTimeoutSeconds *int32 `json:"drainTimeoutSeconds,omitempty"`
+
+	// Optional marks a role the group can start without. With podGroupPolicy.onTimeout ShrinkOptionalRoles,
+	// the pods of optional roles are left out of the gang once the PodGroups are not scheduled in time.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+
+	// MinAvailable is the number of pods of the role which must be scheduled together with the rest of the group,
+	// e.g. 0 for a role the group can start without. It is the minTaskMember of the role with volcanoScheduling.
+	// Only used with the Group and Role PodGroup scopes. Defaults to all pods of the role.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinAvailable *int32 `json:"minAvailable,omitempty"`
+
 	// Workload type specification
 	// +kubebuilder:default={apiVersion:"apps/v1", kind:"StatefulSet"}
 	// +optional
@@ -183,6 +416,10 @@ type RoleSpec struct {
 	// +optional
 	LeaderWorkerSet LeaderWorkerTemplate `json:"leaderWorkerSet,omitempty"`
 
+	// Job template of roles of batch/v1 Jobs. The Job runs replicas pods to completion.
+	// +optional
+	Job *JobTemplate `json:"job,omitempty"`
+
 	// +optional
 	ServicePorts []corev1.ServicePort `json:"servicePorts,omitempty"`
 
@@ -191,8 +428,123 @@ type RoleSpec struct {
 
 	// +optional
 	ScalingAdapter *ScalingAdapter `json:"scalingAdapter,omitempty"`
+
+	// ResourceClaims requests devices for the pods of the role through dynamic resource allocation.
+	// The controller creates the ResourceClaimTemplates and ResourceClaims and adds them to the
+	// resourceClaims of the pods; the containers reference them by name in resources.claims.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ResourceClaims []RoleResourceClaim `json:"resourceClaims,omitempty"`
+
+	// DisruptionBudget limits the voluntary disruptions of the role, e.g. evictions by node drains, through a
+	// PodDisruptionBudget created by the controller.
+	// +optional
+	DisruptionBudget *RoleDisruptionBudget `json:"disruptionBudget,omitempty"`
 }
 
+// RoleDisruptionBudget limits the number of instances of a role disrupted at a time. An instance is a pod, or
+// a group of leader and workers of LeaderWorkerSet roles. The PodDisruptionBudget of LeaderWorkerSet roles is scaled
+// by the size of the groups, and the pod eviction webhook enforces the budget in groups.
+// Only one of minAvailable and maxUnavailable can be set.
+type RoleDisruptionBudget struct {
+	// MinAvailable is the number or percentage of instances that must stay available.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of instances that can be unavailable.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// RoleDependency is a role another role depends on, and the condition under which it is ready.
+type RoleDependency struct {
+	// Role is the name of the role depended on.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+
+	// RBG is the name of another rbg the role depended on belongs to. Defaults to the rbg of the role.
+	// A role of another rbg is ready once its ready replicas in the status of that rbg reach its replicas.
+	// +optional
+	RBG string `json:"rbg,omitempty"`
+
+	// Namespace of the rbg depended on. Defaults to the namespace of the rbg of the role. Only valid with rbg.
+	// A rbg of another namespace must allow the namespace in its allowed-dependent-namespaces annotation, and the
+	// httpGet and grpc checks of its roles are rejected.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Readiness is the condition under which the dependency is ready. Defaults to all replicas of the role being ready.
+	// +optional
+	Readiness *DependencyReadiness `json:"readiness,omitempty"`
+}
+
+// DependencyReadiness is the condition under which a dependency is ready. The pods of the role are counted,
+// all set conditions must hold.
+type DependencyReadiness struct {
+	// MinReady is the number or the percentage of the pods of the role which must be ready, e.g. 60 or "90%".
+	// Defaults to all pods of the role.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MinReady *intstr.IntOrString `json:"minReady,omitempty"`
+
+	// MinReadySeconds is how long a pod must have been ready to be counted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// HTTPGet checks the readiness of the dependency with an HTTP request against the service of the role.
+	// Responses with a status code from 200 to 399 are successful.
+	// +optional
+	HTTPGet *corev1.HTTPGetAction `json:"httpGet,omitempty"`
+
+	// GRPC checks the readiness of the dependency with a gRPC health check against the service of the role.
+	// +optional
+	GRPC *corev1.GRPCAction `json:"grpc,omitempty"`
+
+	// TimeoutSeconds is the timeout of the HTTP and gRPC checks. Defaults to 1 second.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// RoleResourceClaim is a dynamic resource allocation claim of the pods of a role.
+type RoleResourceClaim struct {
+	// Name of the claim in the resourceClaims of the pods.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Scope of the claim. Pod creates a ResourceClaim for every pod from a ResourceClaimTemplate,
+	// Role shares one ResourceClaim between the pods of the role, Group shares one ResourceClaim
+	// between the pods of all roles declaring a Group claim with the same name, and RoleInstance shares
+	// one ResourceClaim between the pods of each LeaderWorkerSet group. Defaults to Pod.
+	// RoleInstance claims are set on the pods by the pod webhook of the controller. Every instance of
+	// the other workloads is a single pod, so they get a ResourceClaim per pod.
+	// +kubebuilder:validation:Enum={Pod,Role,Group,RoleInstance}
+	// +kubebuilder:default=Pod
+	// +optional
+	Scope ResourceClaimScopeType `json:"scope,omitempty"`
+
+	// Spec of the ResourceClaims.
+	Spec resourcev1.ResourceClaimSpec `json:"spec"`
+}
+
+type ResourceClaimScopeType string
+
+const (
+	// PodResourceClaimScope creates a ResourceClaim for every pod.
+	PodResourceClaimScope ResourceClaimScopeType = "Pod"
+
+	// RoleResourceClaimScope shares one ResourceClaim between the pods of a role.
+	RoleResourceClaimScope ResourceClaimScopeType = "Role"
+
+	// GroupResourceClaimScope shares one ResourceClaim between the pods of the group.
+	GroupResourceClaimScope ResourceClaimScopeType = "Group"
+
+	// RoleInstanceResourceClaimScope shares one ResourceClaim between the pods of a role instance.
+	RoleInstanceResourceClaimScope ResourceClaimScopeType = "RoleInstance"
+)
+
 type WorkloadSpec struct {
 	// +optional
 	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/v[0-9]+((alpha|beta)[0-9]+)?$`
@@ -241,6 +593,90 @@ type LeaderWorkerTemplate struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	PatchWorkerTemplate runtime.RawExtension `json:"patchWorkerTemplate,omitempty"`
+
+	// SubGroupPolicy splits each group into subgroups of pods, e.g. the pods of one node for tensor parallelism
+	// within the node.
+	// +optional
+	SubGroupPolicy *LeaderWorkerSubGroupPolicy `json:"subGroupPolicy,omitempty"`
+
+	// StartupPolicy determines when the workers of a group are created. Defaults to LeaderCreated.
+	// +kubebuilder:validation:Enum={LeaderCreated,LeaderReady}
+	// +optional
+	StartupPolicy LeaderWorkerStartupPolicyType `json:"startupPolicy,omitempty"`
+
+	// NetworkConfig of the groups.
+	// +optional
+	NetworkConfig *LeaderWorkerNetworkConfig `json:"networkConfig,omitempty"`
+}
+
+// LeaderWorkerSubGroupPolicy describes how the groups of a LeaderWorkerSet are split into subgroups.
+type LeaderWorkerSubGroupPolicy struct {
+	// Type of the subgroups. Defaults to LeaderWorker.
+	// +kubebuilder:validation:Enum={LeaderWorker,LeaderExcluded}
+	// +optional
+	Type *LeaderWorkerSubGroupPolicyType `json:"subGroupPolicyType,omitempty"`
+
+	// SubGroupSize is the number of pods per subgroup. The size of the groups, or the size minus the leader,
+	// must be divisible by it. It is immutable.
+	// +kubebuilder:validation:Minimum=1
+	SubGroupSize *int32 `json:"subGroupSize,omitempty"`
+}
+
+type LeaderWorkerSubGroupPolicyType string
+
+const (
+	// SubGroupPolicyTypeLeaderWorker includes the leader in the first subgroup.
+	SubGroupPolicyTypeLeaderWorker LeaderWorkerSubGroupPolicyType = "LeaderWorker"
+
+	// SubGroupPolicyTypeLeaderExcluded excludes the leader from the subgroups.
+	SubGroupPolicyTypeLeaderExcluded LeaderWorkerSubGroupPolicyType = "LeaderExcluded"
+)
+
+type LeaderWorkerStartupPolicyType string
+
+const (
+	// LeaderCreatedStartupPolicy creates the workers once the leader pod is created.
+	LeaderCreatedStartupPolicy LeaderWorkerStartupPolicyType = "LeaderCreated"
+
+	// LeaderReadyStartupPolicy creates the workers once the leader pod is ready.
+	LeaderReadyStartupPolicy LeaderWorkerStartupPolicyType = "LeaderReady"
+)
+
+// LeaderWorkerNetworkConfig is the network configuration of the groups of a LeaderWorkerSet.
+type LeaderWorkerNetworkConfig struct {
+	// SubdomainPolicy determines the headless services of the groups. Defaults to Shared.
+	// +kubebuilder:validation:Enum={Shared,UniquePerReplica}
+	// +optional
+	SubdomainPolicy *LeaderWorkerSubdomainPolicy `json:"subdomainPolicy,omitempty"`
+}
+
+type LeaderWorkerSubdomainPolicy string
+
+const (
+	// SharedSubdomainPolicy creates one headless service shared by all groups.
+	SharedSubdomainPolicy LeaderWorkerSubdomainPolicy = "Shared"
+
+	// UniquePerReplicaSubdomainPolicy creates one headless service per group.
+	UniquePerReplicaSubdomainPolicy LeaderWorkerSubdomainPolicy = "UniquePerReplica"
+)
+
+// JobTemplate configures the Job of a role of batch/v1 Jobs.
+type JobTemplate struct {
+	// BackoffLimit is the number of retries before the Job is marked failed. Defaults to 6.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// ActiveDeadlineSeconds is how long the Job may be active before it is terminated.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// TTLSecondsAfterFinished is how long a finished Job is kept before it is deleted. A succeeded Job cleaned up
+	// this way is not run again until the role changes, and the role stays ready.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
 type ScalingAdapter struct {
@@ -262,6 +698,37 @@ type RoleBasedGroupStatus struct {
 
 	// Status of individual roles
 	RoleStatuses []RoleStatus `json:"roleStatuses"`
+
+	// Topology reports the domains the pods landed in, for each level of spec.topology.
+	// +optional
+	Topology []TopologyLevelStatus `json:"topology,omitempty"`
+}
+
+// TopologyLevelStatus reports the domains of a topology level.
+type TopologyLevelStatus struct {
+	// TopologyKey of the level.
+	TopologyKey string `json:"topologyKey"`
+
+	// Scope of the level.
+	Scope TopologyScopeType `json:"scope"`
+
+	// Domains the scheduled pods of the members of the scope landed in.
+	// +optional
+	Domains []TopologyDomain `json:"domains,omitempty"`
+}
+
+// TopologyDomain is a domain a member of a topology scope landed in.
+type TopologyDomain struct {
+	// Role of the member, empty for the Group scope.
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// Instance is the group index of the member for the RoleInstance scope.
+	// +optional
+	Instance string `json:"instance,omitempty"`
+
+	// Value of the topology key of the nodes of the pods.
+	Value string `json:"value"`
 }
 
 // RoleStatus shows the current state of a specific role
@@ -274,6 +741,11 @@ type RoleStatus struct {
 
 	// Total number of desired replicas
 	Replicas int32 `json:"replicas"`
+
+	// CompletedRevision is the revision of a Job role whose Job succeeded. The role stays ready and the Job is not
+	// run again for this revision once it is cleaned up by its ttlSecondsAfterFinished.
+	// +optional
+	CompletedRevision string `json:"completedRevision,omitempty"`
 }
 
 // +genclient
@@ -315,6 +787,38 @@ const (
 	// RoleBasedGroupRestartInProgress means rbg is restarting. RestartInProgress
 	// is true when the rbg is in restart process after the pod is deleted or the container is restarted.
 	RoleBasedGroupRestartInProgress RoleBasedGroupConditionType = "RestartInProgress"
+
+	// RoleBasedGroupAdmitted means the rbg is admitted by Kueue. It is only set for rbgs submitted to a Kueue queue,
+	// whose roles are not created until the condition is true.
+	RoleBasedGroupAdmitted RoleBasedGroupConditionType = "Admitted"
+
+	// RoleBasedGroupSchedulingGated means the pods of the rbg are held back by native scheduling gates.
+	// It is only set when gang-scheduling with scheduling gates is enabled.
+	RoleBasedGroupSchedulingGated RoleBasedGroupConditionType = "SchedulingGated"
+
+	// RoleBasedGroupPodGroupMigrating means the podGroupPolicy of a running rbg changed, e.g. its gang-scheduling
+	// backend, and some pods are not yet replaced by pods bound to the new PodGroups.
+	RoleBasedGroupPodGroupMigrating RoleBasedGroupConditionType = "PodGroupMigrating"
+
+	// RoleBasedGroupSchedulable reports whether the PodGroups of the rbg are scheduled. It is only set when
+	// gang-scheduling with kubeScheduling or volcanoScheduling is enabled.
+	RoleBasedGroupSchedulable RoleBasedGroupConditionType = "Schedulable"
+
+	// RoleBasedGroupGangShrunk means the optional roles were left out of the gang after a schedule timeout
+	// of the observed generation of the rbg.
+	RoleBasedGroupGangShrunk RoleBasedGroupConditionType = "GangShrunk"
+
+	// RoleBasedGroupSuspended means the rbg is suspended by spec.suspend. It is resumed by setting spec.suspend
+	// to false.
+	RoleBasedGroupSuspended RoleBasedGroupConditionType = "Suspended"
+
+	// RoleBasedGroupSuspendedOnTimeout means the workloads of all roles are deleted after a schedule timeout of the
+	// observed generation of the rbg, with podGroupPolicy.onTimeout Suspend. A change of the spec retries.
+	RoleBasedGroupSuspendedOnTimeout RoleBasedGroupConditionType = "SuspendedOnTimeout"
+
+	// RoleBasedGroupWaitingForDependencies means some roles are not reconciled because the roles they depend on
+	// are not ready yet. Its message lists the blocking roles.
+	RoleBasedGroupWaitingForDependencies RoleBasedGroupConditionType = "WaitingForDependencies"
 )
 
 // +kubebuilder:object:root=true
@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // RoleBasedGroupSetSpec defines the desired state of RoleBasedGroupSet.
@@ -28,12 +30,129 @@ type RoleBasedGroupSetSpec struct {
 
 	// Template describes the RoleBasedGroup that will be created.
 	Template RoleBasedGroupSpec `json:"template"`
+
+	// ScaleStrategy indicates the strategy that the controller will use to
+	// create and delete RoleBasedGroups when scaling.
+	// +optional
+	ScaleStrategy *RoleBasedGroupSetScaleStrategy `json:"scaleStrategy,omitempty"`
+
+	// Overrides customize the roles of specific RoleBasedGroups created from the template,
+	// e.g. to pin each replica to a different zone.
+	// Overrides are applied in order, so a later override wins when several of them patch the same field.
+	// The RoleBasedGroups an invalid override selects are not created nor updated, and the error is reported
+	// by the OverridesApplied condition.
+	// +optional
+	Overrides []RoleBasedGroupSetOverride `json:"overrides,omitempty"`
+}
+
+// RoleBasedGroupSetOverride patches the roles of the RoleBasedGroups it selects.
+// If both IndexRange and Selector are set, a RoleBasedGroup must match both.
+// If neither is set, the override applies to every RoleBasedGroup.
+type RoleBasedGroupSetOverride struct {
+	// IndexRange selects RoleBasedGroups by their index within the RoleBasedGroupSet.
+	// +optional
+	IndexRange *RoleBasedGroupSetIndexRange `json:"indexRange,omitempty"`
+
+	// Selector selects RoleBasedGroups by their labels. The labels of a RoleBasedGroup
+	// include rolebasedgroupset.workloads.x-k8s.io/rbg-index, the labels added by earlier
+	// overrides and the labels set on the RoleBasedGroup directly.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Labels are added to the selected RoleBasedGroups, so that later overrides can select them.
+	// Labels are not removed from a RoleBasedGroup once the override no longer selects it.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Roles are the patches applied to the roles of the selected RoleBasedGroups.
+	// +optional
+	Roles []RoleOverride `json:"roles,omitempty"`
+}
+
+// RoleBasedGroupSetIndexRange is an inclusive range of RoleBasedGroup indices.
+type RoleBasedGroupSetIndexRange struct {
+	// Start is the first index of the range.
+	// +kubebuilder:validation:Minimum=0
+	Start int32 `json:"start"`
+
+	// End is the last index of the range. Defaults to Start.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	End *int32 `json:"end,omitempty"`
 }
 
+// RoleOverride patches a single role of the template.
+type RoleOverride struct {
+	// Name of the role to patch. It must exist in the template.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Patch is a strategic merge patch applied to the RoleSpec,
+	// e.g. {"template":{"spec":{"nodeSelector":{"topology.kubernetes.io/zone":"zone-a"}}}}.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Patch runtime.RawExtension `json:"patch"`
+}
+
+// RoleBasedGroupSetScaleStrategy defines how the RoleBasedGroupSet scales its RoleBasedGroups.
+type RoleBasedGroupSetScaleStrategy struct {
+	// The maximum number of RoleBasedGroups that can be unavailable during scale-up.
+	// Value can be an absolute number (ex: 5) or a percentage of desired replicas (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up, and is at least 1.
+	// New RoleBasedGroups are only created while the number of not-ready RoleBasedGroups
+	// is below this value, so a broken template stops the scale-up early.
+	// By default, scale-up is not gated on readiness.
+	//
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// ScaleDownPolicy decides which RoleBasedGroups are deleted first when scaling down.
+	// RoleBasedGroups listed in RBGsToDelete are always deleted before the others.
+	// Surviving RoleBasedGroups keep their names and indices, so scaling down may leave
+	// gaps in the index sequence. Scaling up fills the lowest free indices first.
+	// Defaults to HighestIndex.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum={HighestIndex,UnreadyFirst,OldRevisionFirst}
+	// +kubebuilder:default=HighestIndex
+	ScaleDownPolicy RoleBasedGroupSetScaleDownPolicyType `json:"scaleDownPolicy,omitempty"`
+
+	// RBGsToDelete is the names of RoleBasedGroups that should be deleted first when scaling down.
+	// Names that do not belong to this RoleBasedGroupSet are ignored.
+	// To delete a RoleBasedGroup without scaling down, label it with
+	// rolebasedgroupset.workloads.x-k8s.io/specified-delete instead, and it will be replaced.
+	//
+	// +optional
+	// +listType=set
+	RBGsToDelete []string `json:"rbgsToDelete,omitempty"`
+}
+
+// RoleBasedGroupSetScaleDownPolicyType defines the order in which RoleBasedGroups are deleted when scaling down.
+type RoleBasedGroupSetScaleDownPolicyType string
+
+const (
+	// HighestIndexScaleDownPolicy deletes the RoleBasedGroups with the highest index first.
+	HighestIndexScaleDownPolicy RoleBasedGroupSetScaleDownPolicyType = "HighestIndex"
+
+	// UnreadyFirstScaleDownPolicy deletes RoleBasedGroups that are not ready first,
+	// then falls back to the highest index.
+	UnreadyFirstScaleDownPolicy RoleBasedGroupSetScaleDownPolicyType = "UnreadyFirst"
+
+	// OldRevisionFirstScaleDownPolicy deletes RoleBasedGroups that do not match the current
+	// template first, so the ones at the newest revision are deleted last,
+	// then falls back to the highest index.
+	OldRevisionFirstScaleDownPolicy RoleBasedGroupSetScaleDownPolicyType = "OldRevisionFirst"
+)
+
 type RoleBasedGroupSetConditionType string
 
 const (
 	RoleBasedGroupSetReady RoleBasedGroupSetConditionType = "Ready"
+
+	// RoleBasedGroupSetOverridesApplied is false while some overrides cannot be applied. The RoleBasedGroups
+	// selected by these overrides are neither created nor updated, the other ones are reconciled as usual.
+	RoleBasedGroupSetOverridesApplied RoleBasedGroupSetConditionType = "OverridesApplied"
 )
 
 // RoleBasedGroupSetStatus defines the observed state of RoleBasedGroupSet.
@@ -48,6 +167,12 @@ type RoleBasedGroupSetStatus struct {
 	// +optional
 	ReadyReplicas int32 `json:"readyReplicas" protobuf:"varint,3,opt,name=readyReplicas"`
 
+	// LabelSelector is the label selector over all pods of all RoleBasedGroups in this
+	// RoleBasedGroupSet, in string form. It is used by the scale subresource so that
+	// HPA and KEDA can compute pod metrics.
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+
 	// Conditions track the condition of the rbgs
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -57,7 +182,7 @@ type RoleBasedGroupSetStatus struct {
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.labelSelector
 // +kubebuilder:printcolumn:name="DESIRED",type="string",JSONPath=".status.replicas",description="desired replicas"
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.readyReplicas",description="ready replicas"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
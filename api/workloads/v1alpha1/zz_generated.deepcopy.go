@@ -167,6 +167,36 @@ func (in *ComponentStatus) DeepCopy() *ComponentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyReadiness) DeepCopyInto(out *DependencyReadiness) {
+	*out = *in
+	if in.MinReady != nil {
+		in, out := &in.MinReady, &out.MinReady
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(v1.HTTPGetAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(v1.GRPCAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyReadiness.
+func (in *DependencyReadiness) DeepCopy() *DependencyReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EngineRuntime) DeepCopyInto(out *EngineRuntime) {
 	*out = *in
@@ -590,6 +620,36 @@ func (in *InstanceTemplate) DeepCopy() *InstanceTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplate) DeepCopyInto(out *JobTemplate) {
+	*out = *in
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplate.
+func (in *JobTemplate) DeepCopy() *JobTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeSchedulingPodGroupPolicySource) DeepCopyInto(out *KubeSchedulingPodGroupPolicySource) {
 	*out = *in
@@ -610,6 +670,51 @@ func (in *KubeSchedulingPodGroupPolicySource) DeepCopy() *KubeSchedulingPodGroup
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderWorkerNetworkConfig) DeepCopyInto(out *LeaderWorkerNetworkConfig) {
+	*out = *in
+	if in.SubdomainPolicy != nil {
+		in, out := &in.SubdomainPolicy, &out.SubdomainPolicy
+		*out = new(LeaderWorkerSubdomainPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderWorkerNetworkConfig.
+func (in *LeaderWorkerNetworkConfig) DeepCopy() *LeaderWorkerNetworkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderWorkerNetworkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderWorkerSubGroupPolicy) DeepCopyInto(out *LeaderWorkerSubGroupPolicy) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(LeaderWorkerSubGroupPolicyType)
+		**out = **in
+	}
+	if in.SubGroupSize != nil {
+		in, out := &in.SubGroupSize, &out.SubGroupSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderWorkerSubGroupPolicy.
+func (in *LeaderWorkerSubGroupPolicy) DeepCopy() *LeaderWorkerSubGroupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderWorkerSubGroupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LeaderWorkerTemplate) DeepCopyInto(out *LeaderWorkerTemplate) {
 	*out = *in
@@ -620,6 +725,16 @@ func (in *LeaderWorkerTemplate) DeepCopyInto(out *LeaderWorkerTemplate) {
 	}
 	in.PatchLeaderTemplate.DeepCopyInto(&out.PatchLeaderTemplate)
 	in.PatchWorkerTemplate.DeepCopyInto(&out.PatchWorkerTemplate)
+	if in.SubGroupPolicy != nil {
+		in, out := &in.SubGroupPolicy, &out.SubGroupPolicy
+		*out = new(LeaderWorkerSubGroupPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkConfig != nil {
+		in, out := &in.NetworkConfig, &out.NetworkConfig
+		*out = new(LeaderWorkerNetworkConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderWorkerTemplate.
@@ -632,6 +747,63 @@ func (in *LeaderWorkerTemplate) DeepCopy() *LeaderWorkerTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementPolicy) DeepCopyInto(out *PlacementPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PlacementRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementPolicy.
+func (in *PlacementPolicy) DeepCopy() *PlacementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementRule) DeepCopyInto(out *PlacementRule) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetRoles != nil {
+		in, out := &in.TargetRoles, &out.TargetRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementRule.
+func (in *PlacementRule) DeepCopy() *PlacementRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodGroupPolicy) DeepCopyInto(out *PodGroupPolicy) {
 	*out = *in
@@ -659,7 +831,12 @@ func (in *PodGroupPolicySource) DeepCopyInto(out *PodGroupPolicySource) {
 	if in.VolcanoScheduling != nil {
 		in, out := &in.VolcanoScheduling, &out.VolcanoScheduling
 		*out = new(VolcanoSchedulingPodGroupPolicySource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchedulingGates != nil {
+		in, out := &in.SchedulingGates, &out.SchedulingGates
+		*out = new(SchedulingGatesPodGroupPolicySource)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -867,6 +1044,26 @@ func (in *RoleBasedGroupSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleBasedGroupSetIndexRange) DeepCopyInto(out *RoleBasedGroupSetIndexRange) {
+	*out = *in
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBasedGroupSetIndexRange.
+func (in *RoleBasedGroupSetIndexRange) DeepCopy() *RoleBasedGroupSetIndexRange {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleBasedGroupSetIndexRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleBasedGroupSetList) DeepCopyInto(out *RoleBasedGroupSetList) {
 	*out = *in
@@ -899,6 +1096,70 @@ func (in *RoleBasedGroupSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleBasedGroupSetOverride) DeepCopyInto(out *RoleBasedGroupSetOverride) {
+	*out = *in
+	if in.IndexRange != nil {
+		in, out := &in.IndexRange, &out.IndexRange
+		*out = new(RoleBasedGroupSetIndexRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]RoleOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBasedGroupSetOverride.
+func (in *RoleBasedGroupSetOverride) DeepCopy() *RoleBasedGroupSetOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleBasedGroupSetOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleBasedGroupSetScaleStrategy) DeepCopyInto(out *RoleBasedGroupSetScaleStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.RBGsToDelete != nil {
+		in, out := &in.RBGsToDelete, &out.RBGsToDelete
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBasedGroupSetScaleStrategy.
+func (in *RoleBasedGroupSetScaleStrategy) DeepCopy() *RoleBasedGroupSetScaleStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleBasedGroupSetScaleStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleBasedGroupSetSpec) DeepCopyInto(out *RoleBasedGroupSetSpec) {
 	*out = *in
@@ -908,6 +1169,18 @@ func (in *RoleBasedGroupSetSpec) DeepCopyInto(out *RoleBasedGroupSetSpec) {
 		**out = **in
 	}
 	in.Template.DeepCopyInto(&out.Template)
+	if in.ScaleStrategy != nil {
+		in, out := &in.ScaleStrategy, &out.ScaleStrategy
+		*out = new(RoleBasedGroupSetScaleStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]RoleBasedGroupSetOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBasedGroupSetSpec.
@@ -957,6 +1230,16 @@ func (in *RoleBasedGroupSpec) DeepCopyInto(out *RoleBasedGroupSpec) {
 		*out = new(PodGroupPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(PlacementPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBasedGroupSpec.
@@ -984,6 +1267,13 @@ func (in *RoleBasedGroupStatus) DeepCopyInto(out *RoleBasedGroupStatus) {
 		*out = make([]RoleStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = make([]TopologyLevelStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBasedGroupStatus.
@@ -996,6 +1286,83 @@ func (in *RoleBasedGroupStatus) DeepCopy() *RoleBasedGroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleDependency) DeepCopyInto(out *RoleDependency) {
+	*out = *in
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(DependencyReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleDependency.
+func (in *RoleDependency) DeepCopy() *RoleDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleDisruptionBudget) DeepCopyInto(out *RoleDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleDisruptionBudget.
+func (in *RoleDisruptionBudget) DeepCopy() *RoleDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleOverride) DeepCopyInto(out *RoleOverride) {
+	*out = *in
+	in.Patch.DeepCopyInto(&out.Patch)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleOverride.
+func (in *RoleOverride) DeepCopy() *RoleOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleResourceClaim) DeepCopyInto(out *RoleResourceClaim) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleResourceClaim.
+func (in *RoleResourceClaim) DeepCopy() *RoleResourceClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleResourceClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 	*out = *in
@@ -1014,9 +1381,31 @@ func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependencyConditions != nil {
+		in, out := &in.DependencyConditions, &out.DependencyConditions
+		*out = make([]RoleDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DrainTimeoutSeconds != nil {
+		in, out := &in.DrainTimeoutSeconds, &out.DrainTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(int32)
+		**out = **in
+	}
 	out.Workload = in.Workload
 	in.Template.DeepCopyInto(&out.Template)
 	in.LeaderWorkerSet.DeepCopyInto(&out.LeaderWorkerSet)
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(JobTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServicePorts != nil {
 		in, out := &in.ServicePorts, &out.ServicePorts
 		*out = make([]v1.ServicePort, len(*in))
@@ -1036,6 +1425,18 @@ func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 		*out = new(ScalingAdapter)
 		**out = **in
 	}
+	if in.ResourceClaims != nil {
+		in, out := &in.ResourceClaims, &out.ResourceClaims
+		*out = make([]RoleResourceClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(RoleDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
@@ -1120,9 +1521,111 @@ func (in *ScalingAdapter) DeepCopy() *ScalingAdapter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingGatesPodGroupPolicySource) DeepCopyInto(out *SchedulingGatesPodGroupPolicySource) {
+	*out = *in
+	if in.ScheduleTimeoutSeconds != nil {
+		in, out := &in.ScheduleTimeoutSeconds, &out.ScheduleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingGatesPodGroupPolicySource.
+func (in *SchedulingGatesPodGroupPolicySource) DeepCopy() *SchedulingGatesPodGroupPolicySource {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingGatesPodGroupPolicySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyDomain) DeepCopyInto(out *TopologyDomain) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyDomain.
+func (in *TopologyDomain) DeepCopy() *TopologyDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyLevel) DeepCopyInto(out *TopologyLevel) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyLevel.
+func (in *TopologyLevel) DeepCopy() *TopologyLevel {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyLevel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyLevelStatus) DeepCopyInto(out *TopologyLevelStatus) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]TopologyDomain, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyLevelStatus.
+func (in *TopologyLevelStatus) DeepCopy() *TopologyLevelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyLevelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyPolicy) DeepCopyInto(out *TopologyPolicy) {
+	*out = *in
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = make([]TopologyLevel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyPolicy.
+func (in *TopologyPolicy) DeepCopy() *TopologyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolcanoSchedulingPodGroupPolicySource) DeepCopyInto(out *VolcanoSchedulingPodGroupPolicySource) {
 	*out = *in
+	if in.ScheduleTimeoutSeconds != nil {
+		in, out := &in.ScheduleTimeoutSeconds, &out.ScheduleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolcanoSchedulingPodGroupPolicySource.
@@ -39,6 +39,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &workloadsv1alpha1.ClusterEngineRuntimeProfileSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ComponentStatus"):
 		return &workloadsv1alpha1.ComponentStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DependencyReadiness"):
+		return &workloadsv1alpha1.DependencyReadinessApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("EngineRuntime"):
 		return &workloadsv1alpha1.EngineRuntimeApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Instance"):
@@ -53,10 +55,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &workloadsv1alpha1.InstanceSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("InstanceStatus"):
 		return &workloadsv1alpha1.InstanceStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("JobTemplate"):
+		return &workloadsv1alpha1.JobTemplateApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("KubeSchedulingPodGroupPolicySource"):
 		return &workloadsv1alpha1.KubeSchedulingPodGroupPolicySourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LeaderWorkerNetworkConfig"):
+		return &workloadsv1alpha1.LeaderWorkerNetworkConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LeaderWorkerSubGroupPolicy"):
+		return &workloadsv1alpha1.LeaderWorkerSubGroupPolicyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("LeaderWorkerTemplate"):
 		return &workloadsv1alpha1.LeaderWorkerTemplateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PlacementPolicy"):
+		return &workloadsv1alpha1.PlacementPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PlacementRule"):
+		return &workloadsv1alpha1.PlacementRuleApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PodGroupPolicy"):
 		return &workloadsv1alpha1.PodGroupPolicyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PodGroupPolicySource"):
@@ -71,6 +83,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &workloadsv1alpha1.RoleBasedGroupScalingAdapterStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RoleBasedGroupSet"):
 		return &workloadsv1alpha1.RoleBasedGroupSetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RoleBasedGroupSetIndexRange"):
+		return &workloadsv1alpha1.RoleBasedGroupSetIndexRangeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RoleBasedGroupSetOverride"):
+		return &workloadsv1alpha1.RoleBasedGroupSetOverrideApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RoleBasedGroupSetScaleStrategy"):
+		return &workloadsv1alpha1.RoleBasedGroupSetScaleStrategyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RoleBasedGroupSetSpec"):
 		return &workloadsv1alpha1.RoleBasedGroupSetSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RoleBasedGroupSetStatus"):
@@ -79,6 +97,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &workloadsv1alpha1.RoleBasedGroupSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RoleBasedGroupStatus"):
 		return &workloadsv1alpha1.RoleBasedGroupStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RoleDependency"):
+		return &workloadsv1alpha1.RoleDependencyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RoleDisruptionBudget"):
+		return &workloadsv1alpha1.RoleDisruptionBudgetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RoleOverride"):
+		return &workloadsv1alpha1.RoleOverrideApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RoleResourceClaim"):
+		return &workloadsv1alpha1.RoleResourceClaimApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RoleSpec"):
 		return &workloadsv1alpha1.RoleSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RoleStatus"):
@@ -89,6 +115,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &workloadsv1alpha1.RolloutStrategyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScalingAdapter"):
 		return &workloadsv1alpha1.ScalingAdapterApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SchedulingGatesPodGroupPolicySource"):
+		return &workloadsv1alpha1.SchedulingGatesPodGroupPolicySourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TopologyDomain"):
+		return &workloadsv1alpha1.TopologyDomainApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TopologyLevel"):
+		return &workloadsv1alpha1.TopologyLevelApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TopologyLevelStatus"):
+		return &workloadsv1alpha1.TopologyLevelStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TopologyPolicy"):
+		return &workloadsv1alpha1.TopologyPolicyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("VolcanoSchedulingPodGroupPolicySource"):
 		return &workloadsv1alpha1.VolcanoSchedulingPodGroupPolicySourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("WorkloadSpec"):
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DependencyReadinessApplyConfiguration represents a declarative configuration of the DependencyReadiness type for use
+// with apply.
+type DependencyReadinessApplyConfiguration struct {
+	MinReady        *intstr.IntOrString `json:"minReady,omitempty"`
+	MinReadySeconds *int32              `json:"minReadySeconds,omitempty"`
+	HTTPGet         *v1.HTTPGetAction   `json:"httpGet,omitempty"`
+	GRPC            *v1.GRPCAction      `json:"grpc,omitempty"`
+	TimeoutSeconds  *int32              `json:"timeoutSeconds,omitempty"`
+}
+
+// DependencyReadinessApplyConfiguration constructs a declarative configuration of the DependencyReadiness type for use with
+// apply.
+func DependencyReadiness() *DependencyReadinessApplyConfiguration {
+	return &DependencyReadinessApplyConfiguration{}
+}
+
+// WithMinReady sets the MinReady field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReady field is set to the value of the last call.
+func (b *DependencyReadinessApplyConfiguration) WithMinReady(value intstr.IntOrString) *DependencyReadinessApplyConfiguration {
+	b.MinReady = &value
+	return b
+}
+
+// WithMinReadySeconds sets the MinReadySeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReadySeconds field is set to the value of the last call.
+func (b *DependencyReadinessApplyConfiguration) WithMinReadySeconds(value int32) *DependencyReadinessApplyConfiguration {
+	b.MinReadySeconds = &value
+	return b
+}
+
+// WithHTTPGet sets the HTTPGet field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HTTPGet field is set to the value of the last call.
+func (b *DependencyReadinessApplyConfiguration) WithHTTPGet(value v1.HTTPGetAction) *DependencyReadinessApplyConfiguration {
+	b.HTTPGet = &value
+	return b
+}
+
+// WithGRPC sets the GRPC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GRPC field is set to the value of the last call.
+func (b *DependencyReadinessApplyConfiguration) WithGRPC(value v1.GRPCAction) *DependencyReadinessApplyConfiguration {
+	b.GRPC = &value
+	return b
+}
+
+// WithTimeoutSeconds sets the TimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeoutSeconds field is set to the value of the last call.
+func (b *DependencyReadinessApplyConfiguration) WithTimeoutSeconds(value int32) *DependencyReadinessApplyConfiguration {
+	b.TimeoutSeconds = &value
+	return b
+}
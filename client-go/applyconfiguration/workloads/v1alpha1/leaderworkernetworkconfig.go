@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// LeaderWorkerNetworkConfigApplyConfiguration represents a declarative configuration of the LeaderWorkerNetworkConfig type for use
+// with apply.
+type LeaderWorkerNetworkConfigApplyConfiguration struct {
+	SubdomainPolicy *workloadsv1alpha1.LeaderWorkerSubdomainPolicy `json:"subdomainPolicy,omitempty"`
+}
+
+// LeaderWorkerNetworkConfigApplyConfiguration constructs a declarative configuration of the LeaderWorkerNetworkConfig type for use with
+// apply.
+func LeaderWorkerNetworkConfig() *LeaderWorkerNetworkConfigApplyConfiguration {
+	return &LeaderWorkerNetworkConfigApplyConfiguration{}
+}
+
+// WithSubdomainPolicy sets the SubdomainPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubdomainPolicy field is set to the value of the last call.
+func (b *LeaderWorkerNetworkConfigApplyConfiguration) WithSubdomainPolicy(value workloadsv1alpha1.LeaderWorkerSubdomainPolicy) *LeaderWorkerNetworkConfigApplyConfiguration {
+	b.SubdomainPolicy = &value
+	return b
+}
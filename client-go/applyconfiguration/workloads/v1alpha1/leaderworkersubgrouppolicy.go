@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// LeaderWorkerSubGroupPolicyApplyConfiguration represents a declarative configuration of the LeaderWorkerSubGroupPolicy type for use
+// with apply.
+type LeaderWorkerSubGroupPolicyApplyConfiguration struct {
+	Type         *workloadsv1alpha1.LeaderWorkerSubGroupPolicyType `json:"subGroupPolicyType,omitempty"`
+	SubGroupSize *int32                                            `json:"subGroupSize,omitempty"`
+}
+
+// LeaderWorkerSubGroupPolicyApplyConfiguration constructs a declarative configuration of the LeaderWorkerSubGroupPolicy type for use with
+// apply.
+func LeaderWorkerSubGroupPolicy() *LeaderWorkerSubGroupPolicyApplyConfiguration {
+	return &LeaderWorkerSubGroupPolicyApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *LeaderWorkerSubGroupPolicyApplyConfiguration) WithType(value workloadsv1alpha1.LeaderWorkerSubGroupPolicyType) *LeaderWorkerSubGroupPolicyApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithSubGroupSize sets the SubGroupSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubGroupSize field is set to the value of the last call.
+func (b *LeaderWorkerSubGroupPolicyApplyConfiguration) WithSubGroupSize(value int32) *LeaderWorkerSubGroupPolicyApplyConfiguration {
+	b.SubGroupSize = &value
+	return b
+}
@@ -19,14 +19,18 @@ package v1alpha1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
 )
 
 // LeaderWorkerTemplateApplyConfiguration represents a declarative configuration of the LeaderWorkerTemplate type for use
 // with apply.
 type LeaderWorkerTemplateApplyConfiguration struct {
-	Size                *int32                `json:"size,omitempty"`
-	PatchLeaderTemplate *runtime.RawExtension `json:"patchLeaderTemplate,omitempty"`
-	PatchWorkerTemplate *runtime.RawExtension `json:"patchWorkerTemplate,omitempty"`
+	Size                *int32                                           `json:"size,omitempty"`
+	PatchLeaderTemplate *runtime.RawExtension                            `json:"patchLeaderTemplate,omitempty"`
+	PatchWorkerTemplate *runtime.RawExtension                            `json:"patchWorkerTemplate,omitempty"`
+	SubGroupPolicy      *LeaderWorkerSubGroupPolicyApplyConfiguration    `json:"subGroupPolicy,omitempty"`
+	StartupPolicy       *workloadsv1alpha1.LeaderWorkerStartupPolicyType `json:"startupPolicy,omitempty"`
+	NetworkConfig       *LeaderWorkerNetworkConfigApplyConfiguration     `json:"networkConfig,omitempty"`
 }
 
 // LeaderWorkerTemplateApplyConfiguration constructs a declarative configuration of the LeaderWorkerTemplate type for use with
@@ -58,3 +62,27 @@ func (b *LeaderWorkerTemplateApplyConfiguration) WithPatchWorkerTemplate(value r
 	b.PatchWorkerTemplate = &value
 	return b
 }
+
+// WithSubGroupPolicy sets the SubGroupPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubGroupPolicy field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithSubGroupPolicy(value *LeaderWorkerSubGroupPolicyApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	b.SubGroupPolicy = value
+	return b
+}
+
+// WithStartupPolicy sets the StartupPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartupPolicy field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithStartupPolicy(value workloadsv1alpha1.LeaderWorkerStartupPolicyType) *LeaderWorkerTemplateApplyConfiguration {
+	b.StartupPolicy = &value
+	return b
+}
+
+// WithNetworkConfig sets the NetworkConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NetworkConfig field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithNetworkConfig(value *LeaderWorkerNetworkConfigApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	b.NetworkConfig = value
+	return b
+}
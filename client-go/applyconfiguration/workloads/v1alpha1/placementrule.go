@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// PlacementRuleApplyConfiguration represents a declarative configuration of the PlacementRule type for use
+// with apply.
+type PlacementRuleApplyConfiguration struct {
+	Type        *workloadsv1alpha1.PlacementRuleType `json:"type,omitempty"`
+	Roles       []string                             `json:"roles,omitempty"`
+	TargetRoles []string                             `json:"targetRoles,omitempty"`
+	TopologyKey *string                              `json:"topologyKey,omitempty"`
+	Mode        *workloadsv1alpha1.PlacementRuleMode `json:"mode,omitempty"`
+	Weight      *int32                               `json:"weight,omitempty"`
+	MaxSkew     *int32                               `json:"maxSkew,omitempty"`
+}
+
+// PlacementRuleApplyConfiguration constructs a declarative configuration of the PlacementRule type for use with
+// apply.
+func PlacementRule() *PlacementRuleApplyConfiguration {
+	return &PlacementRuleApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *PlacementRuleApplyConfiguration) WithType(value workloadsv1alpha1.PlacementRuleType) *PlacementRuleApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithRoles adds the given value to the Roles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Roles field.
+func (b *PlacementRuleApplyConfiguration) WithRoles(values ...string) *PlacementRuleApplyConfiguration {
+	for i := range values {
+		b.Roles = append(b.Roles, values[i])
+	}
+	return b
+}
+
+// WithTargetRoles adds the given value to the TargetRoles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TargetRoles field.
+func (b *PlacementRuleApplyConfiguration) WithTargetRoles(values ...string) *PlacementRuleApplyConfiguration {
+	for i := range values {
+		b.TargetRoles = append(b.TargetRoles, values[i])
+	}
+	return b
+}
+
+// WithTopologyKey sets the TopologyKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopologyKey field is set to the value of the last call.
+func (b *PlacementRuleApplyConfiguration) WithTopologyKey(value string) *PlacementRuleApplyConfiguration {
+	b.TopologyKey = &value
+	return b
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *PlacementRuleApplyConfiguration) WithMode(value workloadsv1alpha1.PlacementRuleMode) *PlacementRuleApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithWeight sets the Weight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Weight field is set to the value of the last call.
+func (b *PlacementRuleApplyConfiguration) WithWeight(value int32) *PlacementRuleApplyConfiguration {
+	b.Weight = &value
+	return b
+}
+
+// WithMaxSkew sets the MaxSkew field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxSkew field is set to the value of the last call.
+func (b *PlacementRuleApplyConfiguration) WithMaxSkew(value int32) *PlacementRuleApplyConfiguration {
+	b.MaxSkew = &value
+	return b
+}
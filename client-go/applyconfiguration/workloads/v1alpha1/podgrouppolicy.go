@@ -17,10 +17,16 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
 // PodGroupPolicyApplyConfiguration represents a declarative configuration of the PodGroupPolicy type for use
 // with apply.
 type PodGroupPolicyApplyConfiguration struct {
 	PodGroupPolicySourceApplyConfiguration `json:",inline"`
+	Scope                                  *workloadsv1alpha1.PodGroupScopeType     `json:"scope,omitempty"`
+	OnTimeout                              *workloadsv1alpha1.PodGroupTimeoutAction `json:"onTimeout,omitempty"`
 }
 
 // PodGroupPolicyApplyConfiguration constructs a declarative configuration of the PodGroupPolicy type for use with
@@ -44,3 +50,27 @@ func (b *PodGroupPolicyApplyConfiguration) WithVolcanoScheduling(value *VolcanoS
 	b.PodGroupPolicySourceApplyConfiguration.VolcanoScheduling = value
 	return b
 }
+
+// WithSchedulingGates sets the SchedulingGates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchedulingGates field is set to the value of the last call.
+func (b *PodGroupPolicyApplyConfiguration) WithSchedulingGates(value *SchedulingGatesPodGroupPolicySourceApplyConfiguration) *PodGroupPolicyApplyConfiguration {
+	b.PodGroupPolicySourceApplyConfiguration.SchedulingGates = value
+	return b
+}
+
+// WithScope sets the Scope field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Scope field is set to the value of the last call.
+func (b *PodGroupPolicyApplyConfiguration) WithScope(value workloadsv1alpha1.PodGroupScopeType) *PodGroupPolicyApplyConfiguration {
+	b.Scope = &value
+	return b
+}
+
+// WithOnTimeout sets the OnTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OnTimeout field is set to the value of the last call.
+func (b *PodGroupPolicyApplyConfiguration) WithOnTimeout(value workloadsv1alpha1.PodGroupTimeoutAction) *PodGroupPolicyApplyConfiguration {
+	b.OnTimeout = &value
+	return b
+}
@@ -22,6 +22,7 @@ package v1alpha1
 type PodGroupPolicySourceApplyConfiguration struct {
 	KubeScheduling    *KubeSchedulingPodGroupPolicySourceApplyConfiguration    `json:"kubeScheduling,omitempty"`
 	VolcanoScheduling *VolcanoSchedulingPodGroupPolicySourceApplyConfiguration `json:"volcanoScheduling,omitempty"`
+	SchedulingGates   *SchedulingGatesPodGroupPolicySourceApplyConfiguration   `json:"schedulingGates,omitempty"`
 }
 
 // PodGroupPolicySourceApplyConfiguration constructs a declarative configuration of the PodGroupPolicySource type for use with
@@ -45,3 +46,11 @@ func (b *PodGroupPolicySourceApplyConfiguration) WithVolcanoScheduling(value *Vo
 	b.VolcanoScheduling = value
 	return b
 }
+
+// WithSchedulingGates sets the SchedulingGates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchedulingGates field is set to the value of the last call.
+func (b *PodGroupPolicySourceApplyConfiguration) WithSchedulingGates(value *SchedulingGatesPodGroupPolicySourceApplyConfiguration) *PodGroupPolicySourceApplyConfiguration {
+	b.SchedulingGates = value
+	return b
+}
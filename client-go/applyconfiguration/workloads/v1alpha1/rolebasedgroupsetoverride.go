@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// RoleBasedGroupSetOverrideApplyConfiguration represents a declarative configuration of the RoleBasedGroupSetOverride type for use
+// with apply.
+type RoleBasedGroupSetOverrideApplyConfiguration struct {
+	IndexRange *RoleBasedGroupSetIndexRangeApplyConfiguration `json:"indexRange,omitempty"`
+	Selector   *v1.LabelSelectorApplyConfiguration            `json:"selector,omitempty"`
+	Labels     map[string]string                              `json:"labels,omitempty"`
+	Roles      []RoleOverrideApplyConfiguration               `json:"roles,omitempty"`
+}
+
+// RoleBasedGroupSetOverrideApplyConfiguration constructs a declarative configuration of the RoleBasedGroupSetOverride type for use with
+// apply.
+func RoleBasedGroupSetOverride() *RoleBasedGroupSetOverrideApplyConfiguration {
+	return &RoleBasedGroupSetOverrideApplyConfiguration{}
+}
+
+// WithIndexRange sets the IndexRange field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IndexRange field is set to the value of the last call.
+func (b *RoleBasedGroupSetOverrideApplyConfiguration) WithIndexRange(value *RoleBasedGroupSetIndexRangeApplyConfiguration) *RoleBasedGroupSetOverrideApplyConfiguration {
+	b.IndexRange = value
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *RoleBasedGroupSetOverrideApplyConfiguration) WithSelector(value *v1.LabelSelectorApplyConfiguration) *RoleBasedGroupSetOverrideApplyConfiguration {
+	b.Selector = value
+	return b
+}
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *RoleBasedGroupSetOverrideApplyConfiguration) WithLabels(entries map[string]string) *RoleBasedGroupSetOverrideApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
+
+// WithRoles adds the given value to the Roles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Roles field.
+func (b *RoleBasedGroupSetOverrideApplyConfiguration) WithRoles(values ...*RoleOverrideApplyConfiguration) *RoleBasedGroupSetOverrideApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRoles")
+		}
+		b.Roles = append(b.Roles, *values[i])
+	}
+	return b
+}
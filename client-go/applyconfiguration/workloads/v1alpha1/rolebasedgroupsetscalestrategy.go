@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// RoleBasedGroupSetScaleStrategyApplyConfiguration represents a declarative configuration of the RoleBasedGroupSetScaleStrategy type for use
+// with apply.
+type RoleBasedGroupSetScaleStrategyApplyConfiguration struct {
+	MaxUnavailable  *intstr.IntOrString                                     `json:"maxUnavailable,omitempty"`
+	ScaleDownPolicy *workloadsv1alpha1.RoleBasedGroupSetScaleDownPolicyType `json:"scaleDownPolicy,omitempty"`
+	RBGsToDelete    []string                                                `json:"rbgsToDelete,omitempty"`
+}
+
+// RoleBasedGroupSetScaleStrategyApplyConfiguration constructs a declarative configuration of the RoleBasedGroupSetScaleStrategy type for use with
+// apply.
+func RoleBasedGroupSetScaleStrategy() *RoleBasedGroupSetScaleStrategyApplyConfiguration {
+	return &RoleBasedGroupSetScaleStrategyApplyConfiguration{}
+}
+
+// WithMaxUnavailable sets the MaxUnavailable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxUnavailable field is set to the value of the last call.
+func (b *RoleBasedGroupSetScaleStrategyApplyConfiguration) WithMaxUnavailable(value intstr.IntOrString) *RoleBasedGroupSetScaleStrategyApplyConfiguration {
+	b.MaxUnavailable = &value
+	return b
+}
+
+// WithScaleDownPolicy sets the ScaleDownPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleDownPolicy field is set to the value of the last call.
+func (b *RoleBasedGroupSetScaleStrategyApplyConfiguration) WithScaleDownPolicy(value workloadsv1alpha1.RoleBasedGroupSetScaleDownPolicyType) *RoleBasedGroupSetScaleStrategyApplyConfiguration {
+	b.ScaleDownPolicy = &value
+	return b
+}
+
+// WithRBGsToDelete adds the given value to the RBGsToDelete field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RBGsToDelete field.
+func (b *RoleBasedGroupSetScaleStrategyApplyConfiguration) WithRBGsToDelete(values ...string) *RoleBasedGroupSetScaleStrategyApplyConfiguration {
+	for i := range values {
+		b.RBGsToDelete = append(b.RBGsToDelete, values[i])
+	}
+	return b
+}
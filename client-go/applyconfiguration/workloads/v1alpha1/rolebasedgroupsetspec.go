@@ -20,8 +20,10 @@ package v1alpha1
 // RoleBasedGroupSetSpecApplyConfiguration represents a declarative configuration of the RoleBasedGroupSetSpec type for use
 // with apply.
 type RoleBasedGroupSetSpecApplyConfiguration struct {
-	Replicas *int32                                `json:"replicas,omitempty"`
-	Template *RoleBasedGroupSpecApplyConfiguration `json:"template,omitempty"`
+	Replicas      *int32                                            `json:"replicas,omitempty"`
+	Template      *RoleBasedGroupSpecApplyConfiguration             `json:"template,omitempty"`
+	ScaleStrategy *RoleBasedGroupSetScaleStrategyApplyConfiguration `json:"scaleStrategy,omitempty"`
+	Overrides     []RoleBasedGroupSetOverrideApplyConfiguration     `json:"overrides,omitempty"`
 }
 
 // RoleBasedGroupSetSpecApplyConfiguration constructs a declarative configuration of the RoleBasedGroupSetSpec type for use with
@@ -45,3 +47,24 @@ func (b *RoleBasedGroupSetSpecApplyConfiguration) WithTemplate(value *RoleBasedG
 	b.Template = value
 	return b
 }
+
+// WithScaleStrategy sets the ScaleStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleStrategy field is set to the value of the last call.
+func (b *RoleBasedGroupSetSpecApplyConfiguration) WithScaleStrategy(value *RoleBasedGroupSetScaleStrategyApplyConfiguration) *RoleBasedGroupSetSpecApplyConfiguration {
+	b.ScaleStrategy = value
+	return b
+}
+
+// WithOverrides adds the given value to the Overrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overrides field.
+func (b *RoleBasedGroupSetSpecApplyConfiguration) WithOverrides(values ...*RoleBasedGroupSetOverrideApplyConfiguration) *RoleBasedGroupSetSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOverrides")
+		}
+		b.Overrides = append(b.Overrides, *values[i])
+	}
+	return b
+}
@@ -27,6 +27,7 @@ type RoleBasedGroupSetStatusApplyConfiguration struct {
 	ObservedGeneration *int64                           `json:"observedGeneration,omitempty"`
 	Replicas           *int32                           `json:"replicas,omitempty"`
 	ReadyReplicas      *int32                           `json:"readyReplicas,omitempty"`
+	LabelSelector      *string                          `json:"labelSelector,omitempty"`
 	Conditions         []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
 
@@ -60,6 +61,14 @@ func (b *RoleBasedGroupSetStatusApplyConfiguration) WithReadyReplicas(value int3
 	return b
 }
 
+// WithLabelSelector sets the LabelSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LabelSelector field is set to the value of the last call.
+func (b *RoleBasedGroupSetStatusApplyConfiguration) WithLabelSelector(value string) *RoleBasedGroupSetStatusApplyConfiguration {
+	b.LabelSelector = &value
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
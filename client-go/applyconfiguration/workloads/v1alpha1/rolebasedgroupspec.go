@@ -20,8 +20,11 @@ package v1alpha1
 // RoleBasedGroupSpecApplyConfiguration represents a declarative configuration of the RoleBasedGroupSpec type for use
 // with apply.
 type RoleBasedGroupSpecApplyConfiguration struct {
-	Roles          []RoleSpecApplyConfiguration      `json:"roles,omitempty"`
-	PodGroupPolicy *PodGroupPolicyApplyConfiguration `json:"podGroupPolicy,omitempty"`
+	Roles          []RoleSpecApplyConfiguration       `json:"roles,omitempty"`
+	PodGroupPolicy *PodGroupPolicyApplyConfiguration  `json:"podGroupPolicy,omitempty"`
+	Placement      *PlacementPolicyApplyConfiguration `json:"placement,omitempty"`
+	Topology       *TopologyPolicyApplyConfiguration  `json:"topology,omitempty"`
+	Suspend        *bool                              `json:"suspend,omitempty"`
 }
 
 // RoleBasedGroupSpecApplyConfiguration constructs a declarative configuration of the RoleBasedGroupSpec type for use with
@@ -50,3 +53,27 @@ func (b *RoleBasedGroupSpecApplyConfiguration) WithPodGroupPolicy(value *PodGrou
 	b.PodGroupPolicy = value
 	return b
 }
+
+// WithPlacement sets the Placement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Placement field is set to the value of the last call.
+func (b *RoleBasedGroupSpecApplyConfiguration) WithPlacement(value *PlacementPolicyApplyConfiguration) *RoleBasedGroupSpecApplyConfiguration {
+	b.Placement = value
+	return b
+}
+
+// WithTopology sets the Topology field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Topology field is set to the value of the last call.
+func (b *RoleBasedGroupSpecApplyConfiguration) WithTopology(value *TopologyPolicyApplyConfiguration) *RoleBasedGroupSpecApplyConfiguration {
+	b.Topology = value
+	return b
+}
+
+// WithSuspend sets the Suspend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Suspend field is set to the value of the last call.
+func (b *RoleBasedGroupSpecApplyConfiguration) WithSuspend(value bool) *RoleBasedGroupSpecApplyConfiguration {
+	b.Suspend = &value
+	return b
+}
@@ -24,9 +24,10 @@ import (
 // RoleBasedGroupStatusApplyConfiguration represents a declarative configuration of the RoleBasedGroupStatus type for use
 // with apply.
 type RoleBasedGroupStatusApplyConfiguration struct {
-	ObservedGeneration *int64                           `json:"observedGeneration,omitempty"`
-	Conditions         []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
-	RoleStatuses       []RoleStatusApplyConfiguration   `json:"roleStatuses,omitempty"`
+	ObservedGeneration *int64                                  `json:"observedGeneration,omitempty"`
+	Conditions         []v1.ConditionApplyConfiguration        `json:"conditions,omitempty"`
+	RoleStatuses       []RoleStatusApplyConfiguration          `json:"roleStatuses,omitempty"`
+	Topology           []TopologyLevelStatusApplyConfiguration `json:"topology,omitempty"`
 }
 
 // RoleBasedGroupStatusApplyConfiguration constructs a declarative configuration of the RoleBasedGroupStatus type for use with
@@ -68,3 +69,16 @@ func (b *RoleBasedGroupStatusApplyConfiguration) WithRoleStatuses(values ...*Rol
 	}
 	return b
 }
+
+// WithTopology adds the given value to the Topology field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Topology field.
+func (b *RoleBasedGroupStatusApplyConfiguration) WithTopology(values ...*TopologyLevelStatusApplyConfiguration) *RoleBasedGroupStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTopology")
+		}
+		b.Topology = append(b.Topology, *values[i])
+	}
+	return b
+}
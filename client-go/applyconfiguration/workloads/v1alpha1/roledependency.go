@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RoleDependencyApplyConfiguration represents a declarative configuration of the RoleDependency type for use
+// with apply.
+type RoleDependencyApplyConfiguration struct {
+	Role      *string                                `json:"role,omitempty"`
+	RBG       *string                                `json:"rbg,omitempty"`
+	Namespace *string                                `json:"namespace,omitempty"`
+	Readiness *DependencyReadinessApplyConfiguration `json:"readiness,omitempty"`
+}
+
+// RoleDependencyApplyConfiguration constructs a declarative configuration of the RoleDependency type for use with
+// apply.
+func RoleDependency() *RoleDependencyApplyConfiguration {
+	return &RoleDependencyApplyConfiguration{}
+}
+
+// WithRole sets the Role field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Role field is set to the value of the last call.
+func (b *RoleDependencyApplyConfiguration) WithRole(value string) *RoleDependencyApplyConfiguration {
+	b.Role = &value
+	return b
+}
+
+// WithRBG sets the RBG field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RBG field is set to the value of the last call.
+func (b *RoleDependencyApplyConfiguration) WithRBG(value string) *RoleDependencyApplyConfiguration {
+	b.RBG = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *RoleDependencyApplyConfiguration) WithNamespace(value string) *RoleDependencyApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithReadiness sets the Readiness field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Readiness field is set to the value of the last call.
+func (b *RoleDependencyApplyConfiguration) WithReadiness(value *DependencyReadinessApplyConfiguration) *RoleDependencyApplyConfiguration {
+	b.Readiness = value
+	return b
+}
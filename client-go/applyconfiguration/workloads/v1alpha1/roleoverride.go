@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// RoleOverrideApplyConfiguration represents a declarative configuration of the RoleOverride type for use
+// with apply.
+type RoleOverrideApplyConfiguration struct {
+	Name  *string               `json:"name,omitempty"`
+	Patch *runtime.RawExtension `json:"patch,omitempty"`
+}
+
+// RoleOverrideApplyConfiguration constructs a declarative configuration of the RoleOverride type for use with
+// apply.
+func RoleOverride() *RoleOverrideApplyConfiguration {
+	return &RoleOverrideApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *RoleOverrideApplyConfiguration) WithName(value string) *RoleOverrideApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithPatch sets the Patch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Patch field is set to the value of the last call.
+func (b *RoleOverrideApplyConfiguration) WithPatch(value runtime.RawExtension) *RoleOverrideApplyConfiguration {
+	b.Patch = &value
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/resource/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// RoleResourceClaimApplyConfiguration represents a declarative configuration of the RoleResourceClaim type for use
+// with apply.
+type RoleResourceClaimApplyConfiguration struct {
+	Name  *string                                   `json:"name,omitempty"`
+	Scope *workloadsv1alpha1.ResourceClaimScopeType `json:"scope,omitempty"`
+	Spec  *v1.ResourceClaimSpec                     `json:"spec,omitempty"`
+}
+
+// RoleResourceClaimApplyConfiguration constructs a declarative configuration of the RoleResourceClaim type for use with
+// apply.
+func RoleResourceClaim() *RoleResourceClaimApplyConfiguration {
+	return &RoleResourceClaimApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *RoleResourceClaimApplyConfiguration) WithName(value string) *RoleResourceClaimApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithScope sets the Scope field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Scope field is set to the value of the last call.
+func (b *RoleResourceClaimApplyConfiguration) WithScope(value workloadsv1alpha1.ResourceClaimScopeType) *RoleResourceClaimApplyConfiguration {
+	b.Scope = &value
+	return b
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *RoleResourceClaimApplyConfiguration) WithSpec(value v1.ResourceClaimSpec) *RoleResourceClaimApplyConfiguration {
+	b.Spec = &value
+	return b
+}
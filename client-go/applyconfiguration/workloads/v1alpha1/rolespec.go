@@ -26,17 +26,26 @@ import (
 // RoleSpecApplyConfiguration represents a declarative configuration of the RoleSpec type for use
 // with apply.
 type RoleSpecApplyConfiguration struct {
-	Name            *string                                 `json:"name,omitempty"`
-	Replicas        *int32                                  `json:"replicas,omitempty"`
-	RolloutStrategy *RolloutStrategyApplyConfiguration      `json:"rolloutStrategy,omitempty"`
-	RestartPolicy   *workloadsv1alpha1.RestartPolicyType    `json:"restartPolicy,omitempty"`
-	Dependencies    []string                                `json:"dependencies,omitempty"`
-	Workload        *WorkloadSpecApplyConfiguration         `json:"workload,omitempty"`
-	Template        *v1.PodTemplateSpecApplyConfiguration   `json:"template,omitempty"`
-	LeaderWorkerSet *LeaderWorkerTemplateApplyConfiguration `json:"leaderWorkerSet,omitempty"`
-	ServicePorts    []corev1.ServicePort                    `json:"servicePorts,omitempty"`
-	EngineRuntimes  []EngineRuntimeApplyConfiguration       `json:"engineRuntimes,omitempty"`
-	ScalingAdapter  *ScalingAdapterApplyConfiguration       `json:"scalingAdapter,omitempty"`
+	Name                    *string                                 `json:"name,omitempty"`
+	Replicas                *int32                                  `json:"replicas,omitempty"`
+	RolloutStrategy         *RolloutStrategyApplyConfiguration      `json:"rolloutStrategy,omitempty"`
+	RestartPolicy           *workloadsv1alpha1.RestartPolicyType    `json:"restartPolicy,omitempty"`
+	EvictionPolicy          *workloadsv1alpha1.EvictionPolicyType   `json:"evictionPolicy,omitempty"`
+	Dependencies            []string                                `json:"dependencies,omitempty"`
+	DependencyConditions    []RoleDependencyApplyConfiguration      `json:"dependencyConditions,omitempty"`
+	DependencyReadinessGate *bool                                   `json:"dependencyReadinessGate,omitempty"`
+	DrainTimeoutSeconds     *int32                                  `json:"drainTimeoutSeconds,omitempty"`
+	Optional                *bool                                   `json:"optional,omitempty"`
+	MinAvailable            *int32                                  `json:"minAvailable,omitempty"`
+	Workload                *WorkloadSpecApplyConfiguration         `json:"workload,omitempty"`
+	Template                *v1.PodTemplateSpecApplyConfiguration   `json:"template,omitempty"`
+	LeaderWorkerSet         *LeaderWorkerTemplateApplyConfiguration `json:"leaderWorkerSet,omitempty"`
+	Job                     *JobTemplateApplyConfiguration          `json:"job,omitempty"`
+	ServicePorts            []corev1.ServicePort                    `json:"servicePorts,omitempty"`
+	EngineRuntimes          []EngineRuntimeApplyConfiguration       `json:"engineRuntimes,omitempty"`
+	ScalingAdapter          *ScalingAdapterApplyConfiguration       `json:"scalingAdapter,omitempty"`
+	ResourceClaims          []RoleResourceClaimApplyConfiguration   `json:"resourceClaims,omitempty"`
+	DisruptionBudget        *RoleDisruptionBudgetApplyConfiguration `json:"disruptionBudget,omitempty"`
 }
 
 // RoleSpecApplyConfiguration constructs a declarative configuration of the RoleSpec type for use with
@@ -77,6 +86,14 @@ func (b *RoleSpecApplyConfiguration) WithRestartPolicy(value workloadsv1alpha1.R
 	return b
 }
 
+// WithEvictionPolicy sets the EvictionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EvictionPolicy field is set to the value of the last call.
+func (b *RoleSpecApplyConfiguration) WithEvictionPolicy(value workloadsv1alpha1.EvictionPolicyType) *RoleSpecApplyConfiguration {
+	b.EvictionPolicy = &value
+	return b
+}
+
 // WithDependencies adds the given value to the Dependencies field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Dependencies field.
@@ -87,6 +104,51 @@ func (b *RoleSpecApplyConfiguration) WithDependencies(values ...string) *RoleSpe
 	return b
 }
 
+// WithDependencyConditions adds the given value to the DependencyConditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependencyConditions field.
+func (b *RoleSpecApplyConfiguration) WithDependencyConditions(values ...*RoleDependencyApplyConfiguration) *RoleSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDependencyConditions")
+		}
+		b.DependencyConditions = append(b.DependencyConditions, *values[i])
+	}
+	return b
+}
+
+// WithDependencyReadinessGate sets the DependencyReadinessGate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DependencyReadinessGate field is set to the value of the last call.
+func (b *RoleSpecApplyConfiguration) WithDependencyReadinessGate(value bool) *RoleSpecApplyConfiguration {
+	b.DependencyReadinessGate = &value
+	return b
+}
+
+// WithDrainTimeoutSeconds sets the DrainTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrainTimeoutSeconds field is set to the value of the last call.
+func (b *RoleSpecApplyConfiguration) WithDrainTimeoutSeconds(value int32) *RoleSpecApplyConfiguration {
+	b.DrainTimeoutSeconds = &value
+	return b
+}
+
+// WithOptional sets the Optional field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Optional field is set to the value of the last call.
+func (b *RoleSpecApplyConfiguration) WithOptional(value bool) *RoleSpecApplyConfiguration {
+	b.Optional = &value
+	return b
+}
+
+// WithMinAvailable sets the MinAvailable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinAvailable field is set to the value of the last call.
+func (b *RoleSpecApplyConfiguration) WithMinAvailable(value int32) *RoleSpecApplyConfiguration {
+	b.MinAvailable = &value
+	return b
+}
+
 // WithWorkload sets the Workload field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Workload field is set to the value of the last call.
@@ -111,6 +173,14 @@ func (b *RoleSpecApplyConfiguration) WithLeaderWorkerSet(value *LeaderWorkerTemp
 	return b
 }
 
+// WithJob sets the Job field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Job field is set to the value of the last call.
+func (b *RoleSpecApplyConfiguration) WithJob(value *JobTemplateApplyConfiguration) *RoleSpecApplyConfiguration {
+	b.Job = value
+	return b
+}
+
 // WithServicePorts adds the given value to the ServicePorts field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ServicePorts field.
@@ -141,3 +211,24 @@ func (b *RoleSpecApplyConfiguration) WithScalingAdapter(value *ScalingAdapterApp
 	b.ScalingAdapter = value
 	return b
 }
+
+// WithResourceClaims adds the given value to the ResourceClaims field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceClaims field.
+func (b *RoleSpecApplyConfiguration) WithResourceClaims(values ...*RoleResourceClaimApplyConfiguration) *RoleSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceClaims")
+		}
+		b.ResourceClaims = append(b.ResourceClaims, *values[i])
+	}
+	return b
+}
+
+// WithDisruptionBudget sets the DisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisruptionBudget field is set to the value of the last call.
+func (b *RoleSpecApplyConfiguration) WithDisruptionBudget(value *RoleDisruptionBudgetApplyConfiguration) *RoleSpecApplyConfiguration {
+	b.DisruptionBudget = value
+	return b
+}
@@ -20,9 +20,10 @@ package v1alpha1
 // RoleStatusApplyConfiguration represents a declarative configuration of the RoleStatus type for use
 // with apply.
 type RoleStatusApplyConfiguration struct {
-	Name          *string `json:"name,omitempty"`
-	ReadyReplicas *int32  `json:"readyReplicas,omitempty"`
-	Replicas      *int32  `json:"replicas,omitempty"`
+	Name              *string `json:"name,omitempty"`
+	ReadyReplicas     *int32  `json:"readyReplicas,omitempty"`
+	Replicas          *int32  `json:"replicas,omitempty"`
+	CompletedRevision *string `json:"completedRevision,omitempty"`
 }
 
 // RoleStatusApplyConfiguration constructs a declarative configuration of the RoleStatus type for use with
@@ -54,3 +55,11 @@ func (b *RoleStatusApplyConfiguration) WithReplicas(value int32) *RoleStatusAppl
 	b.Replicas = &value
 	return b
 }
+
+// WithCompletedRevision sets the CompletedRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletedRevision field is set to the value of the last call.
+func (b *RoleStatusApplyConfiguration) WithCompletedRevision(value string) *RoleStatusApplyConfiguration {
+	b.CompletedRevision = &value
+	return b
+}
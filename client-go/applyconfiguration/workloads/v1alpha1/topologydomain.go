@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// TopologyDomainApplyConfiguration represents a declarative configuration of the TopologyDomain type for use
+// with apply.
+type TopologyDomainApplyConfiguration struct {
+	Role     *string `json:"role,omitempty"`
+	Instance *string `json:"instance,omitempty"`
+	Value    *string `json:"value,omitempty"`
+}
+
+// TopologyDomainApplyConfiguration constructs a declarative configuration of the TopologyDomain type for use with
+// apply.
+func TopologyDomain() *TopologyDomainApplyConfiguration {
+	return &TopologyDomainApplyConfiguration{}
+}
+
+// WithRole sets the Role field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Role field is set to the value of the last call.
+func (b *TopologyDomainApplyConfiguration) WithRole(value string) *TopologyDomainApplyConfiguration {
+	b.Role = &value
+	return b
+}
+
+// WithInstance sets the Instance field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Instance field is set to the value of the last call.
+func (b *TopologyDomainApplyConfiguration) WithInstance(value string) *TopologyDomainApplyConfiguration {
+	b.Instance = &value
+	return b
+}
+
+// WithValue sets the Value field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Value field is set to the value of the last call.
+func (b *TopologyDomainApplyConfiguration) WithValue(value string) *TopologyDomainApplyConfiguration {
+	b.Value = &value
+	return b
+}
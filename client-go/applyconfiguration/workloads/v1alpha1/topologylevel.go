@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// TopologyLevelApplyConfiguration represents a declarative configuration of the TopologyLevel type for use
+// with apply.
+type TopologyLevelApplyConfiguration struct {
+	TopologyKey *string                              `json:"topologyKey,omitempty"`
+	Scope       *workloadsv1alpha1.TopologyScopeType `json:"scope,omitempty"`
+	Roles       []string                             `json:"roles,omitempty"`
+}
+
+// TopologyLevelApplyConfiguration constructs a declarative configuration of the TopologyLevel type for use with
+// apply.
+func TopologyLevel() *TopologyLevelApplyConfiguration {
+	return &TopologyLevelApplyConfiguration{}
+}
+
+// WithTopologyKey sets the TopologyKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopologyKey field is set to the value of the last call.
+func (b *TopologyLevelApplyConfiguration) WithTopologyKey(value string) *TopologyLevelApplyConfiguration {
+	b.TopologyKey = &value
+	return b
+}
+
+// WithScope sets the Scope field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Scope field is set to the value of the last call.
+func (b *TopologyLevelApplyConfiguration) WithScope(value workloadsv1alpha1.TopologyScopeType) *TopologyLevelApplyConfiguration {
+	b.Scope = &value
+	return b
+}
+
+// WithRoles adds the given value to the Roles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Roles field.
+func (b *TopologyLevelApplyConfiguration) WithRoles(values ...string) *TopologyLevelApplyConfiguration {
+	for i := range values {
+		b.Roles = append(b.Roles, values[i])
+	}
+	return b
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// TopologyLevelStatusApplyConfiguration represents a declarative configuration of the TopologyLevelStatus type for use
+// with apply.
+type TopologyLevelStatusApplyConfiguration struct {
+	TopologyKey *string                              `json:"topologyKey,omitempty"`
+	Scope       *workloadsv1alpha1.TopologyScopeType `json:"scope,omitempty"`
+	Domains     []TopologyDomainApplyConfiguration   `json:"domains,omitempty"`
+}
+
+// TopologyLevelStatusApplyConfiguration constructs a declarative configuration of the TopologyLevelStatus type for use with
+// apply.
+func TopologyLevelStatus() *TopologyLevelStatusApplyConfiguration {
+	return &TopologyLevelStatusApplyConfiguration{}
+}
+
+// WithTopologyKey sets the TopologyKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopologyKey field is set to the value of the last call.
+func (b *TopologyLevelStatusApplyConfiguration) WithTopologyKey(value string) *TopologyLevelStatusApplyConfiguration {
+	b.TopologyKey = &value
+	return b
+}
+
+// WithScope sets the Scope field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Scope field is set to the value of the last call.
+func (b *TopologyLevelStatusApplyConfiguration) WithScope(value workloadsv1alpha1.TopologyScopeType) *TopologyLevelStatusApplyConfiguration {
+	b.Scope = &value
+	return b
+}
+
+// WithDomains adds the given value to the Domains field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Domains field.
+func (b *TopologyLevelStatusApplyConfiguration) WithDomains(values ...*TopologyDomainApplyConfiguration) *TopologyLevelStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDomains")
+		}
+		b.Domains = append(b.Domains, *values[i])
+	}
+	return b
+}
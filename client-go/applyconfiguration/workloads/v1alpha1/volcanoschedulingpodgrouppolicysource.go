@@ -20,8 +20,9 @@ package v1alpha1
 // VolcanoSchedulingPodGroupPolicySourceApplyConfiguration represents a declarative configuration of the VolcanoSchedulingPodGroupPolicySource type for use
 // with apply.
 type VolcanoSchedulingPodGroupPolicySourceApplyConfiguration struct {
-	PriorityClassName *string `json:"priorityClassName,omitempty"`
-	Queue             *string `json:"queue,omitempty"`
+	PriorityClassName      *string `json:"priorityClassName,omitempty"`
+	Queue                  *string `json:"queue,omitempty"`
+	ScheduleTimeoutSeconds *int32  `json:"scheduleTimeoutSeconds,omitempty"`
 }
 
 // VolcanoSchedulingPodGroupPolicySourceApplyConfiguration constructs a declarative configuration of the VolcanoSchedulingPodGroupPolicySource type for use with
@@ -45,3 +46,11 @@ func (b *VolcanoSchedulingPodGroupPolicySourceApplyConfiguration) WithQueue(valu
 	b.Queue = &value
 	return b
 }
+
+// WithScheduleTimeoutSeconds sets the ScheduleTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScheduleTimeoutSeconds field is set to the value of the last call.
+func (b *VolcanoSchedulingPodGroupPolicySourceApplyConfiguration) WithScheduleTimeoutSeconds(value int32) *VolcanoSchedulingPodGroupPolicySourceApplyConfiguration {
+	b.ScheduleTimeoutSeconds = &value
+	return b
+}
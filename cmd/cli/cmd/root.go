@@ -29,6 +29,7 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/rbgs/cmd/cli/cmd/rollout"
 	"sigs.k8s.io/rbgs/cmd/cli/cmd/status"
+	"sigs.k8s.io/rbgs/cmd/cli/cmd/suspend"
 	"sigs.k8s.io/rbgs/version"
 )
 
@@ -81,4 +82,6 @@ func init() {
 
 	rootCmd.AddCommand(status.NewStatusCmd(cf))
 	rootCmd.AddCommand(rollout.NewRolloutCmd(cf))
+	rootCmd.AddCommand(suspend.NewSuspendCmd(cf))
+	rootCmd.AddCommand(suspend.NewResumeCmd(cf))
 }
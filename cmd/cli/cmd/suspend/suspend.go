@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suspend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/rbgs/client-go/clientset/versioned"
+	"sigs.k8s.io/rbgs/cmd/cli/util"
+)
+
+func NewSuspendCmd(cf *genericclioptions.ConfigFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "suspend <rbgName>",
+		Short: "Scale the workloads of all roles of a rbg to zero in reverse dependency order",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rbgClient, err := util.GetRBGClient(cf)
+			if err != nil {
+				return err
+			}
+			return runSetSuspend(context.TODO(), rbgClient, args[0], util.GetNamespace(cf), true)
+		},
+	}
+}
+
+func NewResumeCmd(cf *genericclioptions.ConfigFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <rbgName>",
+		Short: "Restore the replicas of all roles of a suspended rbg in dependency order",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rbgClient, err := util.GetRBGClient(cf)
+			if err != nil {
+				return err
+			}
+			return runSetSuspend(context.TODO(), rbgClient, args[0], util.GetNamespace(cf), false)
+		},
+	}
+}
+
+func runSetSuspend(ctx context.Context, rbgClient versioned.Interface, rbgName, namespace string, suspend bool) error {
+	rbgs := rbgClient.WorkloadsV1alpha1().RoleBasedGroups(namespace)
+	if !suspend {
+		rbg, err := rbgs.Get(ctx, rbgName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		// the workloads deleted after a schedule timeout are only recreated for a new generation of the spec
+		if !rbg.Spec.Suspend && rbg.IsSuspendedOnTimeout() {
+			return fmt.Errorf(
+				"rbg %s is suspended after a schedule timeout of its PodGroups, change its spec to retry", rbgName,
+			)
+		}
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+	_, err := rbgs.Patch(ctx, rbgName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+
+	if suspend {
+		fmt.Printf("rbg %s suspended\n", rbgName)
+	} else {
+		fmt.Printf("rbg %s resumed\n", rbgName)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package suspend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/client-go/clientset/versioned/fake"
+)
+
+func TestRunSetSuspend(t *testing.T) {
+	rbg := &workloadsv1alpha1.RoleBasedGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rbg", Namespace: "default"},
+	}
+	client := fake.NewSimpleClientset(rbg)
+	ctx := context.TODO()
+
+	assert.NoError(t, runSetSuspend(ctx, client, "test-rbg", "default", true))
+	got, err := client.WorkloadsV1alpha1().RoleBasedGroups("default").Get(ctx, "test-rbg", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, got.Spec.Suspend)
+
+	assert.NoError(t, runSetSuspend(ctx, client, "test-rbg", "default", false))
+	got, err = client.WorkloadsV1alpha1().RoleBasedGroups("default").Get(ctx, "test-rbg", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.False(t, got.Spec.Suspend)
+
+	assert.Error(t, runSetSuspend(ctx, client, "not-found", "default", true))
+	assert.Error(t, runSetSuspend(ctx, client, "not-found", "default", false))
+}
+
+func TestRunSetSuspend_SuspendedOnTimeout(t *testing.T) {
+	rbg := &workloadsv1alpha1.RoleBasedGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rbg", Namespace: "default", Generation: 1},
+		Status: workloadsv1alpha1.RoleBasedGroupStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(workloadsv1alpha1.RoleBasedGroupSuspendedOnTimeout),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+				},
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(rbg)
+
+	// resuming does not change the spec, so the workloads would not be recreated
+	assert.ErrorContains(t, runSetSuspend(context.TODO(), client, "test-rbg", "default", false), "change its spec")
+}
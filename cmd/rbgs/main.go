@@ -17,18 +17,23 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	goruntime "runtime"
+	"strings"
 	"time"
 
+	kruiseappsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
@@ -52,6 +57,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
 	workloadscontroller "sigs.k8s.io/rbgs/internal/controller/workloads"
+	webhookv1 "sigs.k8s.io/rbgs/internal/webhook/v1"
+	"sigs.k8s.io/rbgs/pkg/reconciler"
 	"sigs.k8s.io/rbgs/pkg/utils/fieldindex"
 	"sigs.k8s.io/rbgs/version"
 	// +kubebuilder:scaffold:imports
@@ -66,6 +73,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(apiextv1.AddToScheme(scheme))
 	utilruntime.Must(lwsv1.AddToScheme(scheme))
+	utilruntime.Must(kruiseappsv1beta1.AddToScheme(scheme))
 	utilruntime.Must(schev1alpha1.AddToScheme(scheme))
 	utilruntime.Must(volcanoschedulingv1beta1.AddToScheme(scheme))
 
@@ -99,6 +107,9 @@ func main() {
 		// Controller runtime options
 		maxConcurrentReconciles int
 		cacheSyncTimeout        time.Duration
+		// Workload plugins
+		workloadPluginsConfigMap string
+		enablePodWebhook         bool
 	)
 	flag.StringVar(
 		&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -133,7 +144,16 @@ func main() {
 		"The number of worker threads used by the the RBGS controller.",
 	)
 	flag.DurationVar(&cacheSyncTimeout, "cache-sync-timeout", 120*time.Second, "Informer cache sync timeout.")
+	flag.StringVar(
+		&workloadPluginsConfigMap, "workload-plugins-configmap", "",
+		"The namespace/name of the ConfigMap declaring additional workload kinds roles can run on.",
+	)
 
+	flag.BoolVar(
+		&enablePodWebhook, "enable-pod-webhook", false,
+		"If set, the pod webhooks setting the ResourceClaims of RoleInstance resource claims and enforcing the "+
+			"disruption budgets of LeaderWorkerSet roles in instances are served.",
+	)
 	flag.Parse()
 	opts := zap.Options{
 		Development: development,
@@ -280,6 +300,16 @@ func main() {
 		CacheSyncTimeout:        cacheSyncTimeout,
 	}
 
+	if workloadPluginsConfigMap != "" {
+		namespace, name, _ := strings.Cut(workloadPluginsConfigMap, "/")
+		if err = reconciler.LoadWorkloadPlugins(
+			context.Background(), mgr.GetAPIReader(), types.NamespacedName{Namespace: namespace, Name: name},
+		); err != nil {
+			setupLog.Error(err, "unable to load workload plugins", "configmap", workloadPluginsConfigMap)
+			os.Exit(1)
+		}
+	}
+
 	rbgReconciler := workloadscontroller.NewRoleBasedGroupReconciler(mgr)
 	if err = rbgReconciler.CheckCrdExists(); err != nil {
 		setupLog.Error(err, "unable to create rbg controller", "controller", "RoleBasedGroup")
@@ -297,6 +327,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	evictionReconciler := workloadscontroller.NewEvictionReconciler(mgr)
+	if err = evictionReconciler.SetupWithManager(mgr, options); err != nil {
+		setupLog.Error(err, "unable to create eviction controller", "controller", "Pod")
+		os.Exit(1)
+	}
+
+	podGroupGateReconciler := workloadscontroller.NewPodGroupGateReconciler(mgr)
+	if err = podGroupGateReconciler.SetupWithManager(mgr, options); err != nil {
+		setupLog.Error(err, "unable to create podgroup gate controller", "controller", "Pod")
+		os.Exit(1)
+	}
+
+	gangGateReconciler := workloadscontroller.NewGangGateReconciler(mgr)
+	if err = gangGateReconciler.SetupWithManager(mgr, options); err != nil {
+		setupLog.Error(err, "unable to create gang gate controller", "controller", "RoleBasedGroup")
+		os.Exit(1)
+	}
+
 	rbgScalingAdapterReconciler := workloadscontroller.NewRoleBasedGroupScalingAdapterReconciler(mgr)
 	if err = rbgScalingAdapterReconciler.CheckCrdExists(); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RoleBasedGroupScalingAdapter")
@@ -329,6 +377,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	if enablePodWebhook {
+		if err = webhookv1.SetupPodWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Pod")
+			os.Exit(1)
+		}
+		if err = webhookv1.SetupPodEvictionWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Pod eviction")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("register field index")
 	if err = fieldindex.RegisterFieldIndexes(mgr.GetCache()); err != nil {
 		setupLog.Error(err, "failed to register field index")
@@ -384,6 +443,9 @@ func cacheOptions() cache.Options {
 			&appsv1.Deployment{}: {
 				Label: keyExistsSelector,
 			},
+			&batchv1.Job{}: {
+				Label: keyExistsSelector,
+			},
 			&corev1.Service{}: {
 				Label: keyExistsSelector,
 			},
@@ -2,17 +2,34 @@ package workloads
 
 // rbg-controller events
 const (
-	FailedGetRBG               = "FailedGetRBG"
-	InvalidRoleDependency      = "InvalidRoleDependency"
-	FailedCheckRoleDependency  = "FailedCheckRoleDependency"
-	DependencyNotMet           = "DependencyNotMet"
-	FailedReconcileWorkload    = "FailedReconcileWorkload"
-	FailedCreateScalingAdapter = "FailedCreateScalingAdapter"
-	Succeed                    = "Succeed"
-	FailedUpdateStatus         = "FailedUpdateStatus"
-	FailedCreatePodGroup       = "FailedCreatePodGroup"
-	FailedCreateRevision       = "FailedCreateRevision"
-	SucceedCreateRevision      = "SucceedCreateRevision"
+	FailedGetRBG                    = "FailedGetRBG"
+	InvalidRoleDependency           = "InvalidRoleDependency"
+	FailedCheckRoleDependency       = "FailedCheckRoleDependency"
+	WaitingForDependencies          = "WaitingForDependencies"
+	DependenciesReady               = "DependenciesReady"
+	FailedReconcileWorkload         = "FailedReconcileWorkload"
+	FailedCreateScalingAdapter      = "FailedCreateScalingAdapter"
+	Succeed                         = "Succeed"
+	FailedUpdateStatus              = "FailedUpdateStatus"
+	FailedCreatePodGroup            = "FailedCreatePodGroup"
+	FailedCreateRevision            = "FailedCreateRevision"
+	SucceedCreateRevision           = "SucceedCreateRevision"
+	FailedReconcileKueue            = "FailedReconcileKueue"
+	PodGroupMigrating               = "PodGroupMigrating"
+	PodGroupMigrationCompleted      = "PodGroupMigrationCompleted"
+	PodGroupUnschedulable           = "PodGroupUnschedulable"
+	PodGroupScheduleTimeout         = "PodGroupScheduleTimeout"
+	ShrunkOptionalRoles             = "ShrunkOptionalRoles"
+	SuspendedOnScheduleTimeout      = "SuspendedOnScheduleTimeout"
+	FailedReconcileResourceClaims   = "FailedReconcileResourceClaims"
+	FailedSuspend                   = "FailedSuspend"
+	Suspended                       = "Suspended"
+	Resumed                         = "Resumed"
+	FailedTeardown                  = "FailedTeardown"
+	FailedUpdateReadinessGate       = "FailedUpdateReadinessGate"
+	FailedReconcileDisruptionBudget = "FailedReconcileDisruptionBudget"
+	EvictedRoleInstance             = "EvictedRoleInstance"
+	RoleInstanceEvictionBlocked     = "RoleInstanceEvictionBlocked"
 )
 
 // rbg-scaling-adapter events
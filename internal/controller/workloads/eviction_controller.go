@@ -0,0 +1,181 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// evictionRequeueInterval is the interval at which the eviction of an instance blocked by the disruption budget of
+// its role is retried.
+const evictionRequeueInterval = 10 * time.Second
+
+// EvictionReconciler evicts the whole instance of a pod being disrupted, e.g. evicted by a node drain, for the roles
+// with the RecreateRoleInstanceOnEviction eviction policy. The other pods of the instance are evicted through the
+// eviction API, so that the disruption budget of the role is respected. The pods whose eviction is blocked by the
+// budget are annotated, so that their eviction is retried once the disrupted pod is gone.
+type EvictionReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+func NewEvictionReconciler(mgr ctrl.Manager) *EvictionReconciler {
+	return &EvictionReconciler{
+		client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor("RoleBasedGroup"),
+	}
+}
+
+func (r *EvictionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, req.NamespacedName, pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !podDisrupted(pod) && !evictionRequested(pod) {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithValues("pod", req.NamespacedName)
+
+	rbg := &workloadsv1alpha1.RoleBasedGroup{}
+	if err := r.client.Get(
+		ctx, types.NamespacedName{Name: pod.Labels[workloadsv1alpha1.SetNameLabelKey], Namespace: pod.Namespace}, rbg,
+	); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if rbg.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	role, err := rbg.GetRole(pod.Labels[workloadsv1alpha1.SetRoleLabelKey])
+	if err != nil || role.EvictionPolicy != workloadsv1alpha1.RecreateRoleInstanceOnEviction {
+		return ctrl.Result{}, nil
+	}
+
+	// the instances of the other workloads are single pods, recreated by their workloads
+	groupIndex, found := pod.Labels[lwsv1.GroupIndexLabelKey]
+	if role.Workload.String() != workloadsv1alpha1.LeaderWorkerSetWorkloadType || !found {
+		return ctrl.Result{}, nil
+	}
+	instance := fmt.Sprintf("%s-%s", rbg.GetWorkloadName(role), groupIndex)
+
+	podList := &corev1.PodList{}
+	if err := r.client.List(
+		ctx, podList, client.InNamespace(pod.Namespace),
+		client.MatchingLabels{
+			workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			workloadsv1alpha1.SetRoleLabelKey: role.Name,
+			lwsv1.GroupIndexLabelKey:          groupIndex,
+		},
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var evicted, blocked []string
+	for i := range podList.Items {
+		instancePod := &podList.Items[i]
+		if instancePod.DeletionTimestamp != nil || podDisrupted(instancePod) {
+			continue
+		}
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: instancePod.Name, Namespace: instancePod.Namespace},
+		}
+		if err := r.client.SubResource("eviction").Create(ctx, instancePod, eviction); err != nil {
+			switch {
+			case apierrors.IsNotFound(err):
+			case apierrors.IsTooManyRequests(err):
+				// the disruption budget of the role does not allow evicting the pod yet
+				if err := r.requestEviction(ctx, instancePod); err != nil {
+					return ctrl.Result{}, err
+				}
+				blocked = append(blocked, instancePod.Name)
+			default:
+				return ctrl.Result{}, fmt.Errorf("evict pod %s of instance %s: %w", instancePod.Name, instance, err)
+			}
+			continue
+		}
+		evicted = append(evicted, instancePod.Name)
+	}
+
+	if len(evicted) > 0 {
+		logger.Info("Evicted role instance of disrupted pod", "instance", instance, "pods", evicted)
+		r.recorder.Eventf(
+			rbg, corev1.EventTypeNormal, EvictedRoleInstance,
+			"Evicted %d pods of instance %s of role %s together with the pod %s",
+			len(evicted), instance, role.Name, pod.Name,
+		)
+	}
+	if len(blocked) > 0 {
+		r.recorder.Eventf(
+			rbg, corev1.EventTypeWarning, RoleInstanceEvictionBlocked,
+			"The disruption budget of role %s blocks evicting %d pods of instance %s, retrying",
+			role.Name, len(blocked), instance,
+		)
+		return ctrl.Result{RequeueAfter: evictionRequeueInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// requestEviction annotates a pod of an instance being evicted, so that its eviction is retried even once the
+// disrupted pod of the instance is gone.
+func (r *EvictionReconciler) requestEviction(ctx context.Context, pod *corev1.Pod) error {
+	if evictionRequested(pod) {
+		return nil
+	}
+	original := pod.DeepCopy()
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[workloadsv1alpha1.EvictRoleInstanceAnnotationKey] = "true"
+	if err := r.client.Patch(ctx, pod, client.MergeFrom(original)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("annotate pod %s to evict: %w", pod.Name, err)
+	}
+	return nil
+}
+
+// evictionRequested returns true if the pod belongs to an instance being evicted.
+func evictionRequested(pod *corev1.Pod) bool {
+	return pod.Annotations[workloadsv1alpha1.EvictRoleInstanceAnnotationKey] == "true"
+}
+
+// podDisrupted returns true if the pod is about to be terminated by a disruption, e.g. the eviction API.
+func podDisrupted(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (r *EvictionReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	disruptedPodPredicate := predicate.NewPredicateFuncs(
+		func(obj client.Object) bool {
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			_, exist := pod.Labels[workloadsv1alpha1.SetNameLabelKey]
+			return exist && (podDisrupted(pod) || evictionRequested(pod))
+		},
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		Named("eviction-controller").
+		For(&corev1.Pod{}, builder.WithPredicates(disruptedPodPredicate)).
+		Complete(r)
+}
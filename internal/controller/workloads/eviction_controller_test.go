@@ -0,0 +1,152 @@
+package workloads
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestEvictionReconciler_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	buildPod := func(name, groupIndex string, disrupted bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+					workloadsv1alpha1.SetRoleLabelKey: "decode",
+					lwsv1.GroupIndexLabelKey:          groupIndex,
+				},
+			},
+		}
+		if disrupted {
+			pod.Status.Conditions = []corev1.PodCondition{
+				{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: "EvictionByEvictionAPI"},
+			}
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name           string
+		evictionPolicy workloadsv1alpha1.EvictionPolicyType
+		budgetExceeded bool
+		// disruptedPodGone requests the eviction of the instance from a pod annotated by an earlier blocked
+		// eviction, the disrupted pod of the instance being gone
+		disruptedPodGone bool
+		expectPods       []string
+		expectAnnotated  []string
+		expectRequeue    bool
+		expectEvent      string
+	}{
+		{
+			name:           "evict the other pods of the instance",
+			evictionPolicy: workloadsv1alpha1.RecreateRoleInstanceOnEviction,
+			expectPods:     []string{"test-rbg-decode-0-1", "test-rbg-decode-1", "test-rbg-decode-1-1"},
+			expectEvent:    EvictedRoleInstance,
+		},
+		{
+			name:           "retry while the disruption budget blocks the eviction",
+			evictionPolicy: workloadsv1alpha1.RecreateRoleInstanceOnEviction,
+			budgetExceeded: true,
+			expectPods: []string{
+				"test-rbg-decode-0", "test-rbg-decode-0-1", "test-rbg-decode-1", "test-rbg-decode-1-1",
+			},
+			expectAnnotated: []string{"test-rbg-decode-0"},
+			expectRequeue:   true,
+			expectEvent:     RoleInstanceEvictionBlocked,
+		},
+		{
+			name:             "retry the eviction once the disrupted pod is gone",
+			evictionPolicy:   workloadsv1alpha1.RecreateRoleInstanceOnEviction,
+			disruptedPodGone: true,
+			expectPods:       []string{"test-rbg-decode-1", "test-rbg-decode-1-1"},
+			expectEvent:      EvictedRoleInstance,
+		},
+		{
+			name: "only evict the disrupted pod without eviction policy",
+			expectPods: []string{
+				"test-rbg-decode-0", "test-rbg-decode-0-1", "test-rbg-decode-1", "test-rbg-decode-1-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := wrappers.BuildLwsRole("decode").WithReplicas(2).Obj()
+			role.EvictionPolicy = tt.evictionPolicy
+			rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles([]workloadsv1alpha1.RoleSpec{role}).Obj()
+			pods := []client.Object{
+				buildPod("test-rbg-decode-0", "0", false),
+				buildPod("test-rbg-decode-1", "1", false),
+				buildPod("test-rbg-decode-1-1", "1", false),
+			}
+			request := "test-rbg-decode-0-1"
+			if tt.disruptedPodGone {
+				pods[0].SetAnnotations(map[string]string{workloadsv1alpha1.EvictRoleInstanceAnnotationKey: "true"})
+				request = "test-rbg-decode-0"
+			} else {
+				pods = append(pods, buildPod("test-rbg-decode-0-1", "0", true))
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rbg).WithObjects(pods...)
+			if tt.budgetExceeded {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					SubResourceCreate: func(
+						ctx context.Context, c client.Client, subResourceName string, obj client.Object,
+						subResource client.Object, opts ...client.SubResourceCreateOption,
+					) error {
+						return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the disruption budget.", 10)
+					},
+				})
+			}
+			fakeClient := builder.Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &EvictionReconciler{client: fakeClient, recorder: recorder}
+
+			result, err := r.Reconcile(context.TODO(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: request, Namespace: "default"},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectRequeue, result.RequeueAfter > 0)
+
+			// the disrupted pod is left to the eviction in progress
+			podList := &corev1.PodList{}
+			assert.NoError(t, fakeClient.List(context.TODO(), podList))
+			var names, annotated []string
+			for _, pod := range podList.Items {
+				names = append(names, pod.Name)
+				if evictionRequested(&pod) {
+					annotated = append(annotated, pod.Name)
+				}
+			}
+			assert.ElementsMatch(t, tt.expectPods, names)
+			assert.ElementsMatch(t, tt.expectAnnotated, annotated)
+
+			if tt.expectEvent == "" {
+				assert.Empty(t, recorder.Events)
+			} else {
+				assert.Contains(t, <-recorder.Events, tt.expectEvent)
+			}
+		})
+	}
+}
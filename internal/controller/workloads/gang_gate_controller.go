@@ -0,0 +1,166 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/scheduler"
+	"sigs.k8s.io/rbgs/pkg/utils"
+)
+
+const (
+	// gangGateRequeueInterval is the interval at which the capacity of a gated group is checked again.
+	gangGateRequeueInterval = 10 * time.Second
+
+	// defaultGangGateTimeoutSeconds is used when the schedule timeout of the scheduling gates is not set.
+	defaultGangGateTimeoutSeconds = 300
+)
+
+// GangGateReconciler releases the pods of a rbg gang-scheduled with native scheduling gates once the whole group
+// can start. It reconciles rbgs, the gated pods of a rbg being checked together.
+type GangGateReconciler struct {
+	client client.Client
+}
+
+func NewGangGateReconciler(mgr ctrl.Manager) *GangGateReconciler {
+	return &GangGateReconciler{
+		client: mgr.GetClient(),
+	}
+}
+
+func (r *GangGateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	rbg := &workloadsv1alpha1.RoleBasedGroup{}
+	if err := r.client.Get(ctx, req.NamespacedName, rbg); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if rbg.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithValues("rbg", req.NamespacedName)
+	ctx = ctrl.LoggerInto(ctx, logger)
+	gate := scheduler.NewGangGate(r.client)
+
+	// The gates are removed if the rbg no longer uses scheduling gates, so that pods are never stuck after a policy change.
+	if !rbg.IsSchedulingGatesGangScheduling() {
+		return ctrl.Result{}, gate.Ungate(ctx, rbg)
+	}
+
+	// The gates of an ungating group are removed without checking the group again, so that a failure midway
+	// never leaves the group partially ungated.
+	current := meta.FindStatusCondition(rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupSchedulingGated))
+	if current == nil || current.Reason != scheduler.GangGateUngating {
+		result, err := gate.Check(ctx, rbg)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if result.Reason != scheduler.GangGateUngating {
+			return r.reportGated(ctx, rbg, result)
+		}
+		if err := r.setSchedulingGatedCondition(ctx, rbg, metav1.Condition{
+			Type:    string(workloadsv1alpha1.RoleBasedGroupSchedulingGated),
+			Status:  metav1.ConditionTrue,
+			Reason:  result.Reason,
+			Message: result.Message,
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := gate.Ungate(ctx, rbg); err != nil {
+		logger.Error(err, "Failed to ungate the group, retrying")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.setSchedulingGatedCondition(ctx, rbg, metav1.Condition{
+		Type:    string(workloadsv1alpha1.RoleBasedGroupSchedulingGated),
+		Status:  metav1.ConditionFalse,
+		Reason:  scheduler.GangGateUngated,
+		Message: "All pods of the group are ungated",
+	})
+}
+
+// reportGated sets the SchedulingGated condition of a group which can not be ungated yet, and checks it again later.
+func (r *GangGateReconciler) reportGated(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, result *scheduler.GangGateResult,
+) (ctrl.Result, error) {
+	condition := metav1.Condition{
+		Type:    string(workloadsv1alpha1.RoleBasedGroupSchedulingGated),
+		Status:  metav1.ConditionFalse,
+		Reason:  result.Reason,
+		Message: result.Message,
+	}
+	var requeueAfter time.Duration
+	if !result.Ungated {
+		condition.Status = metav1.ConditionTrue
+		timeout := time.Duration(defaultGangGateTimeoutSeconds) * time.Second
+		if seconds := rbg.Spec.PodGroupPolicy.SchedulingGates.ScheduleTimeoutSeconds; seconds != nil {
+			timeout = time.Duration(*seconds) * time.Second
+		}
+		if waited := time.Since(result.OldestGatedPod.CreationTimestamp.Time); waited >= timeout {
+			condition.Reason = "ScheduleTimeout"
+			condition.Message = fmt.Sprintf("pods gated for more than %s: %s", timeout, result.Message)
+		}
+		requeueAfter = gangGateRequeueInterval
+	}
+
+	if err := r.setSchedulingGatedCondition(ctx, rbg, condition); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *GangGateReconciler) setSchedulingGatedCondition(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, condition metav1.Condition,
+) error {
+	current := meta.FindStatusCondition(rbg.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason {
+		return nil
+	}
+	meta.SetStatusCondition(&rbg.Status.Conditions, condition)
+	return utils.PatchObjectApplyConfiguration(ctx, r.client, ToRBGApplyConfigurationForStatus(rbg), utils.PatchStatus)
+}
+
+func (r *GangGateReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	gatedPodPredicate := predicate.NewPredicateFuncs(
+		func(obj client.Object) bool {
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			_, exist := pod.Labels[workloadsv1alpha1.SetNameLabelKey]
+			return exist && scheduler.HasGangSchedulingGate(pod)
+		},
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		Named("gang-gate-controller").
+		For(&workloadsv1alpha1.RoleBasedGroup{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(
+				func(ctx context.Context, obj client.Object) []reconcile.Request {
+					return []reconcile.Request{
+						{NamespacedName: types.NamespacedName{
+							Name: obj.GetLabels()[workloadsv1alpha1.SetNameLabelKey], Namespace: obj.GetNamespace(),
+						}},
+					}
+				},
+			),
+			builder.WithPredicates(gatedPodPredicate),
+		).
+		Complete(r)
+}
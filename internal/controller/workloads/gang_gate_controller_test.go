@@ -0,0 +1,173 @@
+package workloads
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/scheduler"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestGangGateReconciler_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{wrappers.BuildBasicRole("worker").WithReplicas(2).Obj()}).Obj()
+	rbg.Spec.PodGroupPolicy = &workloadsv1alpha1.PodGroupPolicy{
+		PodGroupPolicySource: workloadsv1alpha1.PodGroupPolicySource{
+			SchedulingGates: &workloadsv1alpha1.SchedulingGatesPodGroupPolicySource{
+				ScheduleTimeoutSeconds: ptr.To(int32(300)),
+			},
+		},
+	}
+
+	buildPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         rbg.Namespace,
+				Labels:            map[string]string{workloadsv1alpha1.SetNameLabelKey: rbg.Name},
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: corev1.PodSpec{
+				SchedulingGates: []corev1.PodSchedulingGate{{Name: scheduler.GangSchedulingGateName}},
+				Containers:      []corev1.Container{{Name: "nginx", Image: "nginx"}},
+			},
+		}
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("110")},
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		pods            []*corev1.Pod
+		ungating        bool
+		expectGated     bool
+		expectCondition metav1.ConditionStatus
+		expectReason    string
+	}{
+		{
+			name:            "keep pods gated until the whole group exists",
+			pods:            []*corev1.Pod{buildPod("test-rbg-worker-0")},
+			expectGated:     true,
+			expectCondition: metav1.ConditionTrue,
+			expectReason:    scheduler.GangGateWaitingForPods,
+		},
+		{
+			name:            "release the group once it can start",
+			pods:            []*corev1.Pod{buildPod("test-rbg-worker-0"), buildPod("test-rbg-worker-1")},
+			expectGated:     false,
+			expectCondition: metav1.ConditionFalse,
+			expectReason:    scheduler.GangGateUngated,
+		},
+		{
+			name:            "finish ungating a group without checking it again",
+			pods:            []*corev1.Pod{buildPod("test-rbg-worker-0")},
+			ungating:        true,
+			expectGated:     false,
+			expectCondition: metav1.ConditionFalse,
+			expectReason:    scheduler.GangGateUngated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				gatedRBG := rbg.DeepCopy()
+				if tt.ungating {
+					gatedRBG.Status.Conditions = []metav1.Condition{{
+						Type:   string(workloadsv1alpha1.RoleBasedGroupSchedulingGated),
+						Status: metav1.ConditionTrue,
+						Reason: scheduler.GangGateUngating,
+					}}
+				}
+				builder := fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(gatedRBG, node).
+					WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{})
+				for _, pod := range tt.pods {
+					builder.WithObjects(pod.DeepCopy())
+				}
+				fakeClient := builder.Build()
+				r := &GangGateReconciler{client: fakeClient}
+
+				result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rbg)})
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectGated, result.RequeueAfter > 0)
+
+				pod := &corev1.Pod{}
+				assert.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(tt.pods[0]), pod))
+				assert.Equal(t, tt.expectGated, scheduler.HasGangSchedulingGate(pod))
+
+				updated := &workloadsv1alpha1.RoleBasedGroup{}
+				assert.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(rbg), updated))
+				condition := meta.FindStatusCondition(updated.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupSchedulingGated))
+				if assert.NotNil(t, condition) {
+					assert.Equal(t, tt.expectCondition, condition.Status)
+					assert.Equal(t, tt.expectReason, condition.Reason)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"retry ungating after a failure", func(t *testing.T) {
+			failing := true
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(rbg.DeepCopy(), node, buildPod("test-rbg-worker-0"), buildPod("test-rbg-worker-1")).
+				WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(
+						ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+						opts ...client.PatchOption,
+					) error {
+						if failing && obj.GetName() == "test-rbg-worker-1" {
+							return apierrors.NewConflict(corev1.Resource("pods"), obj.GetName(), nil)
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).Build()
+			r := &GangGateReconciler{client: fakeClient}
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rbg)}
+
+			_, err := r.Reconcile(context.TODO(), req)
+			assert.Error(t, err)
+			updated := &workloadsv1alpha1.RoleBasedGroup{}
+			assert.NoError(t, fakeClient.Get(context.TODO(), req.NamespacedName, updated))
+			condition := meta.FindStatusCondition(updated.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupSchedulingGated))
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, scheduler.GangGateUngating, condition.Reason)
+			}
+
+			// The pod ungated before the failure is not counted in the group anymore, the retry completes anyway.
+			failing = false
+			_, err = r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+			pods := &corev1.PodList{}
+			assert.NoError(t, fakeClient.List(context.TODO(), pods))
+			for i := range pods.Items {
+				assert.False(t, scheduler.HasGangSchedulingGate(&pods.Items[i]))
+			}
+		},
+	)
+}
@@ -166,16 +166,47 @@ func ToRBGApplyConfigurationForStatus(rbg *workloadsv1alpha1.RoleBasedGroup) *ap
 		WithKind(gkv.Kind).
 		WithAPIVersion(gkv.GroupVersion().String()).
 		WithStatus(applyconfiguration.RoleBasedGroupStatus().WithRoleStatuses(ToRoleStatusApplyConfiguration(rbg.Status.RoleStatuses)...).WithConditions(ToConditionApplyConfigurations(rbg.Status.Conditions)...))
+	if len(rbg.Status.Topology) > 0 {
+		rbgApplyConfig.Status.WithTopology(ToTopologyLevelStatusApplyConfigurations(rbg.Status.Topology)...)
+	}
 	return rbgApplyConfig
 }
 
+func ToTopologyLevelStatusApplyConfigurations(
+	levels []workloadsv1alpha1.TopologyLevelStatus,
+) []*applyconfiguration.TopologyLevelStatusApplyConfiguration {
+	out := make([]*applyconfiguration.TopologyLevelStatusApplyConfiguration, 0, len(levels))
+	for _, level := range levels {
+		domains := make([]*applyconfiguration.TopologyDomainApplyConfiguration, 0, len(level.Domains))
+		for _, domain := range level.Domains {
+			d := applyconfiguration.TopologyDomain().WithValue(domain.Value)
+			if domain.Role != "" {
+				d.WithRole(domain.Role)
+			}
+			if domain.Instance != "" {
+				d.WithInstance(domain.Instance)
+			}
+			domains = append(domains, d)
+		}
+		out = append(out, applyconfiguration.TopologyLevelStatus().
+			WithTopologyKey(level.TopologyKey).
+			WithScope(level.Scope).
+			WithDomains(domains...))
+	}
+	return out
+}
+
 func ToRoleStatusApplyConfiguration(roleStatus []workloadsv1alpha1.RoleStatus) []*applyconfiguration.RoleStatusApplyConfiguration {
 	out := make([]*applyconfiguration.RoleStatusApplyConfiguration, 0, len(roleStatus))
 	for _, rs := range roleStatus {
-		out = append(out, applyconfiguration.RoleStatus().
+		status := applyconfiguration.RoleStatus().
 			WithName(rs.Name).
 			WithReplicas(rs.Replicas).
-			WithReadyReplicas(rs.ReadyReplicas))
+			WithReadyReplicas(rs.ReadyReplicas)
+		if rs.CompletedRevision != "" {
+			status.WithCompletedRevision(rs.CompletedRevision)
+		}
+		out = append(out, status)
 	}
 	return out
 }
@@ -188,6 +219,7 @@ func ToConditionApplyConfigurations(conds []metav1.Condition) []*metav1ac.Condit
 			WithStatus(c.Status).
 			WithReason(c.Reason).
 			WithMessage(c.Message).
+			WithObservedGeneration(c.ObservedGeneration).
 			WithLastTransitionTime(c.LastTransitionTime))
 	}
 	return out
@@ -0,0 +1,89 @@
+package workloads
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/scheduler"
+)
+
+// PodGroupGateReconciler binds the pods of lws roles to the PodGroup of their lws group when the PodGroup scope is
+// RoleInstance, and lifts their scheduling gate.
+type PodGroupGateReconciler struct {
+	client client.Client
+}
+
+func NewPodGroupGateReconciler(mgr ctrl.Manager) *PodGroupGateReconciler {
+	return &PodGroupGateReconciler{
+		client: mgr.GetClient(),
+	}
+}
+
+func (r *PodGroupGateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, req.NamespacedName, pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if pod.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	if !scheduler.HasPodGroupSchedulingGate(pod) {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithValues("pod", req.NamespacedName)
+	ctx = ctrl.LoggerInto(ctx, logger)
+
+	rbg := &workloadsv1alpha1.RoleBasedGroup{}
+	if err := r.client.Get(
+		ctx, types.NamespacedName{Name: pod.Labels[workloadsv1alpha1.SetNameLabelKey], Namespace: pod.Namespace}, rbg,
+	); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	role, err := rbg.GetRole(pod.Labels[workloadsv1alpha1.SetRoleLabelKey])
+	if err != nil {
+		logger.Error(err, "get role of gated pod")
+		return ctrl.Result{}, nil
+	}
+
+	if !scheduler.BindPodToPodGroup(rbg, role, pod) {
+		logger.V(1).Info("pod is not ready to be bound to its pod group yet")
+		return ctrl.Result{}, nil
+	}
+	if err := r.client.Update(ctx, pod); err != nil {
+		if apierrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("bound pod to pod group and removed scheduling gate")
+	return ctrl.Result{}, nil
+}
+
+func (r *PodGroupGateReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	gatedPodPredicate := predicate.NewPredicateFuncs(
+		func(obj client.Object) bool {
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			_, exist := pod.Labels[workloadsv1alpha1.SetNameLabelKey]
+			return exist && scheduler.HasPodGroupSchedulingGate(pod)
+		},
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		Named("podgroup-gate-controller").
+		For(&corev1.Pod{}, builder.WithPredicates(gatedPodPredicate)).
+		Complete(r)
+}
@@ -0,0 +1,84 @@
+package workloads
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/scheduler"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestPodGroupGateReconciler_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{wrappers.BuildLwsRole("decode").WithReplicas(2).Obj()}).
+		WithKubeGangScheduling(true).
+		WithPodGroupScope(workloadsv1alpha1.RoleInstancePodGroupScope).Obj()
+
+	buildPod := func(name string, labels map[string]string) *corev1.Pod {
+		podLabels := map[string]string{
+			workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			workloadsv1alpha1.SetRoleLabelKey: "decode",
+		}
+		for k, v := range labels {
+			podLabels[k] = v
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: rbg.Namespace, Labels: podLabels},
+			Spec: corev1.PodSpec{
+				SchedulingGates: []corev1.PodSchedulingGate{{Name: scheduler.PodGroupSchedulingGateName}},
+				Containers:      []corev1.Container{{Name: "nginx", Image: "nginx"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		pod            *corev1.Pod
+		expectGated    bool
+		expectPodGroup string
+	}{
+		{
+			name:           "bind gated pod to the pod group of its lws group",
+			pod:            buildPod("test-rbg-decode-1-1", map[string]string{lwsv1.GroupIndexLabelKey: "1"}),
+			expectGated:    false,
+			expectPodGroup: "test-rbg-decode-1",
+		},
+		{
+			name:        "keep pod gated until the lws group index is set",
+			pod:         buildPod("test-rbg-decode-1", nil),
+			expectGated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rbg.DeepCopy(), tt.pod).Build()
+				r := &PodGroupGateReconciler{client: fakeClient}
+
+				key := types.NamespacedName{Name: tt.pod.Name, Namespace: tt.pod.Namespace}
+				_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+				assert.NoError(t, err)
+
+				pod := &corev1.Pod{}
+				assert.NoError(t, fakeClient.Get(context.TODO(), key, pod))
+				assert.Equal(t, tt.expectGated, scheduler.HasPodGroupSchedulingGate(pod))
+				assert.Equal(t, tt.expectPodGroup, pod.Labels[scheduler.KubePodGroupLabelKey])
+			},
+		)
+	}
+}
@@ -21,12 +21,17 @@ import (
 	stderrors "errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -39,21 +44,49 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
 	"sigs.k8s.io/rbgs/pkg/dependency"
+	"sigs.k8s.io/rbgs/pkg/kueue"
 	"sigs.k8s.io/rbgs/pkg/reconciler"
 	"sigs.k8s.io/rbgs/pkg/scale"
 	"sigs.k8s.io/rbgs/pkg/scheduler"
 	"sigs.k8s.io/rbgs/pkg/utils"
+	"sigs.k8s.io/rbgs/pkg/utils/fieldindex"
 	schev1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
 	volcanoschedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
+// podGroupMigrationRequeueInterval is the interval at which the migration of the pods to a changed
+// podGroupPolicy is checked again.
+const podGroupMigrationRequeueInterval = 10 * time.Second
+
+// podGroupScheduleTimeoutReason is the condition reason of the PodGroups not scheduled within the schedule timeout.
+const podGroupScheduleTimeoutReason = "ScheduleTimeout"
+
+// teardownRequeueInterval is the interval at which the termination of the pods of a deleted rbg is checked again.
+const teardownRequeueInterval = 5 * time.Second
+
+// suspendRequeueInterval is the interval at which the termination of the pods of a suspended rbg is checked again.
+const suspendRequeueInterval = 5 * time.Second
+
+// dependencyRequeueInterval is the interval at which the dependencies of waiting roles are checked again. Readiness
+// changes of the workloads requeue the rbg right away, it covers the readiness conditions based on pods and probes.
+const dependencyRequeueInterval = 10 * time.Second
+
+// Condition reasons of the Suspended condition set by spec.suspend.
+const (
+	suspendingReason = "Suspending"
+	suspendedReason  = "Suspended"
+	resumedReason    = "Resumed"
+)
+
 var (
 	runtimeController *builder.TypedBuilder[reconcile.Request]
 	watchedWorkload   sync.Map
@@ -84,6 +117,8 @@ func NewRoleBasedGroupReconciler(mgr ctrl.Manager) *RoleBasedGroupReconciler {
 // +kubebuilder:rbac:groups=workloads.x-k8s.io,resources=rolebasedgroups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=workloads.x-k8s.io,resources=rolebasedgroups/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceclaims;resourceclaimtemplates,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions/status,verbs=get;update;patch
 func (r *RoleBasedGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	// Fetch the RoleBasedGroup instance
@@ -95,12 +130,19 @@ func (r *RoleBasedGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	if rbg.DeletionTimestamp != nil {
-		return ctrl.Result{}, nil
-	}
 
 	logger := log.FromContext(ctx).WithValues("rbg", klog.KObj(rbg))
 	ctx = ctrl.LoggerInto(ctx, logger)
+	if rbg.DeletionTimestamp != nil {
+		return r.reconcileTeardown(ctx, rbg)
+	}
+	if !controllerutil.ContainsFinalizer(rbg, workloadsv1alpha1.TeardownFinalizer) {
+		patch := client.MergeFromWithOptions(rbg.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		controllerutil.AddFinalizer(rbg, workloadsv1alpha1.TeardownFinalizer)
+		if err := r.client.Patch(ctx, rbg, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 	logger.Info("Start reconciling")
 	start := time.Now()
 	defer func() {
@@ -133,6 +175,29 @@ func (r *RoleBasedGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	// Process Kueue admission, the roles are not created until the rbg is admitted. A suspended rbg releases its
+	// Kueue Workload once its pods are gone, and waits for a new admission when it is resumed.
+	if _, queued := kueue.QueueName(rbg); queued && !rbg.Spec.Suspend {
+		admission, err := kueue.NewWorkloadManager(r.client).Reconcile(ctx, rbg, runtimeController, &watchedWorkload, r.apiReader)
+		if err != nil {
+			r.recorder.Event(rbg, corev1.EventTypeWarning, FailedReconcileKueue, err.Error())
+			return ctrl.Result{}, err
+		}
+		if err := r.setAdmittedCondition(ctx, rbg, admission.Admitted); err != nil {
+			return ctrl.Result{}, err
+		}
+		if !admission.Admitted {
+			if admission.Evicted {
+				logger.Info("Kueue workload is evicted, the workloads of all roles are deleted")
+				return ctrl.Result{}, r.deleteAllWorkloads(ctx, rbg)
+			}
+			// The running roles are kept while a resized kueue workload waits for a new admission
+			logger.Info("Waiting for kueue admission")
+			return ctrl.Result{}, nil
+		}
+		kueue.InjectNodeSelectors(rbg, admission.NodeSelectors)
+	}
+
 	// Process roles in dependency order
 	dependencyManager := dependency.NewDefaultDependencyManager(r.scheme, r.client)
 	sortedRoles, err := dependencyManager.SortRoles(ctx, rbg)
@@ -141,36 +206,78 @@ func (r *RoleBasedGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
-	// Process PodGroup
+	// Process suspension before the PodGroups, since a suspended rbg has none
 	podGroupManager := scheduler.NewPodGroupScheduler(r.client)
-	if err := podGroupManager.Reconcile(ctx, rbg, runtimeController, &watchedWorkload, r.apiReader); err != nil {
+	if rbg.Spec.Suspend {
+		return r.reconcileSuspend(ctx, rbg, sortedRoles, podGroupManager)
+	}
+	if err := r.setResumedCondition(ctx, rbg); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Process PodGroup schedule status first, since its timeout actions apply to the PodGroups and roles
+	scheduleRequeueAfter, err := r.reconcilePodGroupSchedule(ctx, rbg, podGroupManager)
+	if err != nil {
+		r.recorder.Event(rbg, corev1.EventTypeWarning, FailedUpdateStatus, err.Error())
+		return ctrl.Result{}, err
+	}
+	if rbg.IsSuspendedOnTimeout() {
+		logger.Info("Suspended on schedule timeout, the workloads of all roles are deleted")
+		return ctrl.Result{}, r.deleteAllWorkloads(ctx, rbg)
+	}
+
+	// Process PodGroup
+	outdatedPods, err := podGroupManager.Reconcile(ctx, rbg, runtimeController, &watchedWorkload, r.apiReader)
+	if err != nil {
 		r.recorder.Event(rbg, corev1.EventTypeWarning, FailedCreatePodGroup, err.Error())
 		return ctrl.Result{}, err
 	}
+	if err := r.setPodGroupMigratingCondition(ctx, rbg, outdatedPods); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Process the resource claims before the pods referencing them are created
+	if err := reconciler.NewResourceClaimReconciler(r.scheme, r.client).Reconcile(ctx, rbg); err != nil {
+		r.recorder.Event(rbg, corev1.EventTypeWarning, FailedReconcileResourceClaims, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Process the dependency readiness gates before the roles, since the roles of the next levels are not reconciled
+	// while a role waits for its dependencies
+	gatedBlocking, err := r.reconcileDependencyReadinessGates(ctx, rbg, dependencyManager)
+	if err != nil {
+		r.recorder.Event(rbg, corev1.EventTypeWarning, FailedUpdateReadinessGate, err.Error())
+		return ctrl.Result{}, err
+	}
 
 	// Reconcile role, add & update
+	disruptionBudgetReconciler := reconciler.NewDisruptionBudgetReconciler(r.scheme, r.client)
 	roleStatuses := []workloadsv1alpha1.RoleStatus{}
 	var updateStatus bool
 	for _, roleList := range sortedRoles {
 		var errs error
+		var waitingRoles []string
 
 		for _, role := range roleList {
 			logger := log.FromContext(ctx)
 			roleCtx := log.IntoContext(ctx, logger.WithValues("role", role.Name))
 
-			// first check whether watch lws cr
-			dynamicWatchCustomCRD(roleCtx, role.Workload.Kind)
-			// Check dependencies first
-			ready, err := dependencyManager.CheckDependencyReady(roleCtx, rbg, role)
+			// first check whether watch the workload cr
+			dynamicWatchCustomCRD(roleCtx, role.Workload, r.apiReader)
+			// Check dependencies first, the ones of roles with a readiness gate are checked already
+			blocking, checked := gatedBlocking[role.Name]
+			var err error
+			if !checked {
+				blocking, err = dependencyManager.BlockingDependencies(roleCtx, rbg, role)
+			}
 			if err != nil {
 				r.recorder.Event(rbg, corev1.EventTypeWarning, FailedCheckRoleDependency, err.Error())
 				errs = stderrors.Join(errs, err)
 				continue
 			}
-			if !ready {
-				err := fmt.Errorf("dependencies not met for role '%s'", role.Name)
-				r.recorder.Event(rbg, corev1.EventTypeWarning, DependencyNotMet, err.Error())
-				errs = stderrors.Join(errs, err)
+			if len(blocking) > 0 {
+				logger.V(1).Info("Waiting for dependencies", "role", role.Name, "dependencies", blocking)
+				waitingRoles = append(waitingRoles, fmt.Sprintf("%s waits for %s", role.Name, strings.Join(blocking, ", ")))
 				continue
 			}
 
@@ -195,6 +302,16 @@ func (r *RoleBasedGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				continue
 			}
 
+			if err := disruptionBudgetReconciler.Reconcile(roleCtx, rbg, role); err != nil {
+				logger.Error(err, "Failed to reconcile disruption budget")
+				r.recorder.Eventf(
+					rbg, corev1.EventTypeWarning, FailedReconcileDisruptionBudget,
+					"Failed to reconcile disruption budget of role %s: %v", role.Name, err,
+				)
+				errs = stderrors.Join(errs, err)
+				continue
+			}
+
 			if err := r.ReconcileScalingAdapter(roleCtx, rbg, role); err != nil {
 				logger.Error(err, "Failed to reconcile scaling adapter")
 				r.recorder.Eventf(
@@ -223,6 +340,16 @@ func (r *RoleBasedGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		if errs != nil {
 			return ctrl.Result{}, errs
 		}
+		// the roles of the next levels are not reconciled until the roles they wait for are ready
+		if len(waitingRoles) > 0 {
+			if err := r.setWaitingForDependenciesCondition(ctx, rbg, waitingRoles); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: dependencyRequeueInterval}, nil
+		}
+	}
+	if err := r.setWaitingForDependenciesCondition(ctx, rbg, nil); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	if updateStatus {
@@ -235,6 +362,14 @@ func (r *RoleBasedGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}
 
+	if err := r.updateTopologyStatus(ctx, rbg); err != nil {
+		r.recorder.Eventf(
+			rbg, corev1.EventTypeWarning, FailedUpdateStatus,
+			"Failed to update topology status for %s: %v", rbg.Name, err,
+		)
+		return ctrl.Result{}, err
+	}
+
 	// delete role
 	if err := r.deleteRoles(ctx, rbg); err != nil {
 		r.recorder.Eventf(
@@ -254,10 +389,220 @@ func (r *RoleBasedGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	r.recorder.Event(rbg, corev1.EventTypeNormal, Succeed, "ReconcileSucceed")
-	return ctrl.Result{}, nil
+	requeueAfter := scheduleRequeueAfter
+	if outdatedPods > 0 && (requeueAfter == 0 || requeueAfter > podGroupMigrationRequeueInterval) {
+		// pods are not watched, check the migration progress periodically
+		requeueAfter = podGroupMigrationRequeueInterval
+	}
+	if len(gatedBlocking) > 0 && (requeueAfter == 0 || requeueAfter > dependencyRequeueInterval) {
+		// new pods and the readiness conditions based on pods and probes are not watched
+		requeueAfter = dependencyRequeueInterval
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *RoleBasedGroupReconciler) deleteRoles(ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup) error {
+	errs := r.cleanupOrphanedWorkloads(ctx, rbg)
+
+	if err := r.CleanupOrphanedScalingAdapters(ctx, rbg); err != nil {
+		errs = append(errs, err)
+	}
+
+	disruptionBudgetReconciler := reconciler.NewDisruptionBudgetReconciler(r.scheme, r.client)
+	if err := disruptionBudgetReconciler.CleanupOrphanedDisruptionBudgets(ctx, rbg); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.NewAggregate(errs)
+}
+
+// reconcileSuspend scales the workloads of all roles to zero in reverse dependency order, so that the workloads of
+// a role are only scaled down once the pods of the roles depending on it are gone. Services and configuration are kept.
+func (r *RoleBasedGroupReconciler) reconcileSuspend(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+	sortedRoles [][]*workloadsv1alpha1.RoleSpec, podGroupManager *scheduler.PodGroupScheduler,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	if err := podGroupManager.DeletePodGroups(ctx, rbg, &watchedWorkload); err != nil {
+		r.recorder.Event(rbg, corev1.EventTypeWarning, FailedSuspend, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	for i := len(sortedRoles) - 1; i >= 0; i-- {
+		var pendingRoles []string
+		for _, role := range sortedRoles[i] {
+			suspended, err := reconciler.SuspendWorkload(ctx, r.client, rbg, role)
+			if err != nil {
+				r.recorder.Eventf(
+					rbg, corev1.EventTypeWarning, FailedSuspend,
+					"Failed to suspend role %s: %v", role.Name, err,
+				)
+				return ctrl.Result{}, err
+			}
+			if !suspended {
+				pendingRoles = append(pendingRoles, role.Name)
+			}
+		}
+		if len(pendingRoles) > 0 {
+			logger.Info("Waiting for the pods of the roles to terminate", "roles", pendingRoles)
+			message := fmt.Sprintf("Waiting for the pods of the roles %v to terminate", pendingRoles)
+			if err := r.updateSuspendedStatus(ctx, rbg); err != nil {
+				return ctrl.Result{}, err
+			}
+			err := r.setSuspendedCondition(ctx, rbg, metav1.ConditionFalse, suspendingReason, message)
+			return ctrl.Result{RequeueAfter: suspendRequeueInterval}, err
+		}
+	}
+
+	// the quota is only released once no pod of the rbg is left
+	if _, queued := kueue.QueueName(rbg); queued {
+		if err := kueue.NewWorkloadManager(r.client).Release(ctx, rbg); err != nil {
+			r.recorder.Event(rbg, corev1.EventTypeWarning, FailedSuspend, err.Error())
+			return ctrl.Result{}, err
+		}
+		if err := r.setAdmittedCondition(ctx, rbg, false); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.updateSuspendedStatus(ctx, rbg); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.setSuspendedCondition(
+		ctx, rbg, metav1.ConditionTrue, suspendedReason, "The workloads of all roles are scaled to zero",
+	)
+}
+
+// updateSuspendedStatus reports the replicas of the roles of a suspended rbg, which is not ready.
+func (r *RoleBasedGroupReconciler) updateSuspendedStatus(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+) error {
+	roleStatuses := []workloadsv1alpha1.RoleStatus{}
+	updateStatus := meta.IsStatusConditionTrue(rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupReady))
+	for i := range rbg.Spec.Roles {
+		role := &rbg.Spec.Roles[i]
+		workloadReconciler, err := reconciler.NewWorkloadReconciler(role.Workload, r.scheme, r.client)
+		if err != nil {
+			return err
+		}
+		roleStatus, updateRoleStatus, err := workloadReconciler.ConstructRoleStatus(ctx, rbg, role)
+		if apierrors.IsNotFound(err) {
+			// the workloads of the role are not created yet
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		updateStatus = updateStatus || updateRoleStatus
+		roleStatuses = append(roleStatuses, roleStatus)
+	}
+	if !updateStatus {
+		return nil
+	}
+	if err := r.updateRBGStatus(ctx, rbg, roleStatuses); err != nil {
+		r.recorder.Eventf(
+			rbg, corev1.EventTypeWarning, FailedUpdateStatus,
+			"Failed to update status for %s: %v", rbg.Name, err,
+		)
+		return err
+	}
+	return nil
+}
+
+// reconcileTeardown deletes the workloads of the roles of a deleted rbg in reverse dependency order, so that a role
+// keeps serving until the pods of the roles depending on it are gone. The PodGroups are deleted last, then the
+// finalizer of the rbg is removed.
+func (r *RoleBasedGroupReconciler) reconcileTeardown(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(rbg, workloadsv1alpha1.TeardownFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx)
+
+	sortedRoles, err := dependency.NewDefaultDependencyManager(r.scheme, r.client).SortRoles(ctx, rbg)
+	if err != nil {
+		// the roles can't be ordered, tear them down at once
+		logger.Error(err, "Failed to sort roles, tear down all roles at once")
+		sortedRoles = [][]*workloadsv1alpha1.RoleSpec{{}}
+		for i := range rbg.Spec.Roles {
+			sortedRoles[0] = append(sortedRoles[0], &rbg.Spec.Roles[i])
+		}
+	}
+
+	for i := len(sortedRoles) - 1; i >= 0; i-- {
+		var pendingRoles []string
+		for _, role := range sortedRoles[i] {
+			done, err := reconciler.TeardownRole(ctx, r.client, rbg, role)
+			if err != nil {
+				r.recorder.Eventf(
+					rbg, corev1.EventTypeWarning, FailedTeardown,
+					"Failed to tear down role %s: %v", role.Name, err,
+				)
+				return ctrl.Result{}, err
+			}
+			if !done {
+				pendingRoles = append(pendingRoles, role.Name)
+			}
+		}
+		if len(pendingRoles) > 0 {
+			logger.Info("Waiting for the pods of the roles to terminate", "roles", pendingRoles)
+			return ctrl.Result{RequeueAfter: teardownRequeueInterval}, nil
+		}
+	}
+
+	if err := scheduler.NewPodGroupScheduler(r.client).DeletePodGroups(ctx, rbg, &watchedWorkload); err != nil {
+		r.recorder.Event(rbg, corev1.EventTypeWarning, FailedTeardown, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("All roles are torn down, remove the finalizer")
+	patch := client.MergeFromWithOptions(rbg.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(rbg, workloadsv1alpha1.TeardownFinalizer)
+	return ctrl.Result{}, client.IgnoreNotFound(r.client.Patch(ctx, rbg, patch))
+}
+
+// setResumedCondition ends a suspension by spec.suspend. The replicas of the roles are restored by the reconcile of
+// the roles in dependency order.
+func (r *RoleBasedGroupReconciler) setResumedCondition(ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup) error {
+	current := meta.FindStatusCondition(rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupSuspended))
+	if current == nil || (current.Reason != suspendingReason && current.Reason != suspendedReason) {
+		return nil
+	}
+	return r.setSuspendedCondition(
+		ctx, rbg, metav1.ConditionFalse, resumedReason, "The replicas of the roles are restored in dependency order",
+	)
+}
+
+func (r *RoleBasedGroupReconciler) setSuspendedCondition(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, status metav1.ConditionStatus, reason, message string,
+) error {
+	if !meta.SetStatusCondition(&rbg.Status.Conditions, metav1.Condition{
+		Type:               string(workloadsv1alpha1.RoleBasedGroupSuspended),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: rbg.Generation,
+	}) {
+		return nil
+	}
+	switch reason {
+	case suspendedReason:
+		r.recorder.Event(rbg, corev1.EventTypeNormal, Suspended, message)
+	case resumedReason:
+		r.recorder.Event(rbg, corev1.EventTypeNormal, Resumed, message)
+	}
+	return utils.PatchObjectApplyConfiguration(ctx, r.client, ToRBGApplyConfigurationForStatus(rbg), utils.PatchStatus)
+}
+
+// deleteAllWorkloads deletes the workloads of all roles, e.g. once the kueue workload of the rbg is evicted.
+func (r *RoleBasedGroupReconciler) deleteAllWorkloads(ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup) error {
+	withoutRoles := rbg.DeepCopy()
+	withoutRoles.Spec.Roles = nil
+	return errors.NewAggregate(r.cleanupOrphanedWorkloads(ctx, withoutRoles))
+}
+
+func (r *RoleBasedGroupReconciler) cleanupOrphanedWorkloads(ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup) []error {
 	errs := make([]error, 0)
 	deployRecon := reconciler.NewDeploymentReconciler(r.scheme, r.client)
 	if err := deployRecon.CleanupOrphanedWorkloads(ctx, rbg); err != nil {
@@ -274,18 +619,282 @@ func (r *RoleBasedGroupReconciler) deleteRoles(ctx context.Context, rbg *workloa
 		errs = append(errs, err)
 	}
 
-	if err := r.CleanupOrphanedScalingAdapters(ctx, rbg); err != nil {
+	jobRecon := reconciler.NewJobReconciler(r.scheme, r.client)
+	if err := jobRecon.CleanupOrphanedWorkloads(ctx, rbg); err != nil {
 		errs = append(errs, err)
 	}
 
-	return errors.NewAggregate(errs)
+	for _, plugin := range reconciler.WorkloadPlugins() {
+		// the workloads of a plugin can only be listed once its CRD exists
+		if plugin.CRDName != "" {
+			if _, pluginExist := watchedWorkload.Load(plugin.CRDName); !pluginExist {
+				continue
+			}
+		}
+		if err := plugin.NewReconciler(r.scheme, r.client).CleanupOrphanedWorkloads(ctx, rbg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func (r *RoleBasedGroupReconciler) setAdmittedCondition(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, admitted bool,
+) error {
+	condition := metav1.Condition{
+		Type:               string(workloadsv1alpha1.RoleBasedGroupAdmitted),
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "WaitingForAdmission",
+		Message:            "Waiting for the kueue workload to be admitted",
+	}
+	if admitted {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Admitted"
+		condition.Message = "The kueue workload is admitted"
+	}
+
+	current := meta.FindStatusCondition(rbg.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status {
+		return nil
+	}
+	setCondition(rbg, condition)
+	return utils.PatchObjectApplyConfiguration(ctx, r.client, ToRBGApplyConfigurationForStatus(rbg), utils.PatchStatus)
+}
+
+// setPodGroupMigratingCondition reports the migration of the pods to a changed podGroupPolicy.
+// The condition is only added once a migration starts.
+func (r *RoleBasedGroupReconciler) setPodGroupMigratingCondition(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, outdatedPods int32,
+) error {
+	current := meta.FindStatusCondition(rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupPodGroupMigrating))
+	migrating := current != nil && current.Status == metav1.ConditionTrue
+	if outdatedPods == 0 && !migrating {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:    string(workloadsv1alpha1.RoleBasedGroupPodGroupMigrating),
+		Status:  metav1.ConditionTrue,
+		Reason:  "OutdatedPods",
+		Message: fmt.Sprintf("%d pods are not bound to their PodGroup by the current podGroupPolicy", outdatedPods),
+	}
+	if outdatedPods == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "MigrationCompleted"
+		condition.Message = "All pods are bound to their PodGroup by the current podGroupPolicy"
+		r.recorder.Event(rbg, corev1.EventTypeNormal, PodGroupMigrationCompleted, condition.Message)
+	} else if !migrating {
+		r.recorder.Event(rbg, corev1.EventTypeNormal, PodGroupMigrating, condition.Message)
+	}
+
+	if !meta.SetStatusCondition(&rbg.Status.Conditions, condition) {
+		return nil
+	}
+	return utils.PatchObjectApplyConfiguration(ctx, r.client, ToRBGApplyConfigurationForStatus(rbg), utils.PatchStatus)
+}
+
+// reconcileDependencyReadinessGates sets the dependency readiness gates of the pods of the roles with
+// dependencyReadinessGate from the readiness of their dependencies.
+// It returns the blocking dependencies of every role with the readiness gate, so that they are only checked once.
+func (r *RoleBasedGroupReconciler) reconcileDependencyReadinessGates(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, dependencyManager dependency.DependencyManager,
+) (map[string][]string, error) {
+	gatedBlocking := map[string][]string{}
+	for i := range rbg.Spec.Roles {
+		role := &rbg.Spec.Roles[i]
+		if !role.DependencyReadinessGate {
+			continue
+		}
+		roleCtx := log.IntoContext(ctx, log.FromContext(ctx).WithValues("role", role.Name))
+		blocking, err := dependencyManager.BlockingDependencies(roleCtx, rbg, role)
+		if err != nil {
+			return gatedBlocking, err
+		}
+		gatedBlocking[role.Name] = blocking
+		if err := reconciler.SyncDependencyReadinessGate(roleCtx, r.client, rbg, role, blocking); err != nil {
+			return gatedBlocking, fmt.Errorf("failed to update the dependency readiness gate of role %s: %w", role.Name, err)
+		}
+	}
+	return gatedBlocking, nil
+}
+
+// setWaitingForDependenciesCondition reports the roles waiting for their dependencies in the WaitingForDependencies
+// condition. The condition is only set to false if it was set before.
+func (r *RoleBasedGroupReconciler) setWaitingForDependenciesCondition(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, waitingRoles []string,
+) error {
+	current := meta.FindStatusCondition(
+		rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupWaitingForDependencies),
+	)
+	waiting := current != nil && current.Status == metav1.ConditionTrue
+	if len(waitingRoles) == 0 && !waiting {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:    string(workloadsv1alpha1.RoleBasedGroupWaitingForDependencies),
+		Status:  metav1.ConditionTrue,
+		Reason:  "DependenciesNotReady",
+		Message: fmt.Sprintf("Roles waiting for their dependencies: %s", strings.Join(waitingRoles, "; ")),
+	}
+	if len(waitingRoles) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "DependenciesReady"
+		condition.Message = "The dependencies of all roles are ready"
+	}
+
+	if !meta.SetStatusCondition(&rbg.Status.Conditions, condition) {
+		return nil
+	}
+	// the event is only recorded when the blocking roles change, not on every check
+	if len(waitingRoles) == 0 {
+		r.recorder.Event(rbg, corev1.EventTypeNormal, DependenciesReady, condition.Message)
+	} else {
+		r.recorder.Event(rbg, corev1.EventTypeNormal, WaitingForDependencies, condition.Message)
+	}
+	return utils.PatchObjectApplyConfiguration(ctx, r.client, ToRBGApplyConfigurationForStatus(rbg), utils.PatchStatus)
+}
+
+// reconcilePodGroupSchedule reports the scheduling state of the PodGroups in the Schedulable condition, and applies
+// podGroupPolicy.onTimeout once they are not scheduled within the schedule timeout.
+// It returns when the timeout has to be checked again.
+func (r *RoleBasedGroupReconciler) reconcilePodGroupSchedule(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, podGroupManager *scheduler.PodGroupScheduler,
+) (time.Duration, error) {
+	changed := revertPodGroupTimeoutActions(rbg)
+
+	var requeueAfter time.Duration
+	if rbg.IsKubeGangScheduling() || rbg.IsVolcanoGangScheduling() {
+		status, err := podGroupManager.ScheduleStatus(ctx, rbg)
+		if err != nil {
+			return 0, err
+		}
+		if status != nil {
+			var conditionChanged bool
+			requeueAfter, conditionChanged = r.setSchedulableCondition(rbg, status)
+			changed = changed || conditionChanged
+		}
+	}
+
+	if !changed {
+		return requeueAfter, nil
+	}
+	return requeueAfter, utils.PatchObjectApplyConfiguration(ctx, r.client, ToRBGApplyConfigurationForStatus(rbg), utils.PatchStatus)
+}
+
+func (r *RoleBasedGroupReconciler) setSchedulableCondition(
+	rbg *workloadsv1alpha1.RoleBasedGroup, status *scheduler.PodGroupScheduleStatus,
+) (time.Duration, bool) {
+	conditionType := string(workloadsv1alpha1.RoleBasedGroupSchedulable)
+	changed := false
+	current := meta.FindStatusCondition(rbg.Status.Conditions, conditionType)
+	if current != nil && current.ObservedGeneration != rbg.Generation {
+		// the schedule timeout of a changed spec starts again
+		meta.RemoveStatusCondition(&rbg.Status.Conditions, conditionType)
+		current = nil
+		changed = true
+	}
+
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             status.Reason,
+		Message:            status.Message,
+		ObservedGeneration: rbg.Generation,
+	}
+	var requeueAfter time.Duration
+	if !status.Scheduled {
+		condition.Status = metav1.ConditionFalse
+		since := time.Now()
+		if current != nil && current.Status == metav1.ConditionFalse {
+			since = current.LastTransitionTime.Time
+		}
+		timeout := scheduler.ScheduleTimeout(rbg)
+		if elapsed := time.Since(since); elapsed < timeout {
+			requeueAfter = timeout - elapsed
+			if status.Reason == scheduler.PodGroupUnschedulable && (current == nil || current.Reason != status.Reason) {
+				r.recorder.Event(rbg, corev1.EventTypeWarning, PodGroupUnschedulable, status.Message)
+			}
+		} else {
+			condition.Reason = podGroupScheduleTimeoutReason
+			condition.Message = fmt.Sprintf("%s, not scheduled within %s", status.Message, timeout)
+			if current == nil || current.Reason != podGroupScheduleTimeoutReason {
+				r.recorder.Event(rbg, corev1.EventTypeWarning, PodGroupScheduleTimeout, condition.Message)
+				r.applyPodGroupTimeoutAction(rbg)
+				changed = true
+			}
+		}
+	}
+
+	return requeueAfter, meta.SetStatusCondition(&rbg.Status.Conditions, condition) || changed
+}
+
+// applyPodGroupTimeoutAction records the podGroupPolicy.onTimeout action for the current generation of the rbg.
+func (r *RoleBasedGroupReconciler) applyPodGroupTimeoutAction(rbg *workloadsv1alpha1.RoleBasedGroup) {
+	switch rbg.Spec.PodGroupPolicy.GetOnTimeout() {
+	case workloadsv1alpha1.ShrinkOptionalRolesPodGroupTimeoutAction:
+		var optionalRoles []string
+		for _, role := range rbg.Spec.Roles {
+			if role.Optional {
+				optionalRoles = append(optionalRoles, role.Name)
+			}
+		}
+		if len(optionalRoles) == 0 || rbg.IsGangShrunk() {
+			return
+		}
+		message := fmt.Sprintf("The optional roles %v are left out of the gang after a schedule timeout", optionalRoles)
+		meta.SetStatusCondition(&rbg.Status.Conditions, metav1.Condition{
+			Type:               string(workloadsv1alpha1.RoleBasedGroupGangShrunk),
+			Status:             metav1.ConditionTrue,
+			Reason:             podGroupScheduleTimeoutReason,
+			Message:            message,
+			ObservedGeneration: rbg.Generation,
+		})
+		r.recorder.Event(rbg, corev1.EventTypeNormal, ShrunkOptionalRoles, message)
+	case workloadsv1alpha1.SuspendPodGroupTimeoutAction:
+		message := "The workloads of all roles are deleted after a schedule timeout, change the spec to retry"
+		meta.SetStatusCondition(&rbg.Status.Conditions, metav1.Condition{
+			Type:               string(workloadsv1alpha1.RoleBasedGroupSuspendedOnTimeout),
+			Status:             metav1.ConditionTrue,
+			Reason:             podGroupScheduleTimeoutReason,
+			Message:            message,
+			ObservedGeneration: rbg.Generation,
+		})
+		r.recorder.Event(rbg, corev1.EventTypeNormal, SuspendedOnScheduleTimeout, message)
+	}
+}
+
+// revertPodGroupTimeoutActions ends the timeout actions of a previous generation of the rbg,
+// so that a changed spec is tried with the whole gang again.
+func revertPodGroupTimeoutActions(rbg *workloadsv1alpha1.RoleBasedGroup) bool {
+	changed := false
+	for _, conditionType := range []workloadsv1alpha1.RoleBasedGroupConditionType{
+		workloadsv1alpha1.RoleBasedGroupGangShrunk, workloadsv1alpha1.RoleBasedGroupSuspendedOnTimeout,
+	} {
+		condition := meta.FindStatusCondition(rbg.Status.Conditions, string(conditionType))
+		if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != podGroupScheduleTimeoutReason ||
+			condition.ObservedGeneration == rbg.Generation {
+			continue
+		}
+		meta.SetStatusCondition(&rbg.Status.Conditions, metav1.Condition{
+			Type:               string(conditionType),
+			Status:             metav1.ConditionFalse,
+			Reason:             "SpecChanged",
+			Message:            "The spec changed since the schedule timeout",
+			ObservedGeneration: rbg.Generation,
+		})
+		changed = true
+	}
+	return changed
 }
 
 func (r *RoleBasedGroupReconciler) updateRBGStatus(
 	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, roleStatus []workloadsv1alpha1.RoleStatus,
 ) error {
-	// update ready condition
-	rbgReady := true
+	// update ready condition, a suspended rbg is not ready
+	rbgReady := !rbg.Spec.Suspend
 	for _, role := range roleStatus {
 		if role.ReadyReplicas != role.Replicas {
 			rbgReady = false
@@ -302,6 +911,14 @@ func (r *RoleBasedGroupReconciler) updateRBGStatus(
 			Reason:             "AllRolesReady",
 			Message:            "All roles are ready",
 		}
+	} else if rbg.Spec.Suspend {
+		readyCondition = metav1.Condition{
+			Type:               string(workloadsv1alpha1.RoleBasedGroupReady),
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "Suspended",
+			Message:            "The rbg is suspended",
+		}
 	} else {
 		readyCondition = metav1.Condition{
 			Type:               string(workloadsv1alpha1.RoleBasedGroupReady),
@@ -321,7 +938,7 @@ func (r *RoleBasedGroupReconciler) updateRBGStatus(
 			// if found, update
 			if roleStatus[i].Name == oldStatus.Name {
 				found = true
-				if roleStatus[i].Replicas != oldStatus.Replicas || roleStatus[i].ReadyReplicas != oldStatus.ReadyReplicas {
+				if roleStatus[i] != oldStatus {
 					rbg.Status.RoleStatuses[j] = roleStatus[i]
 				}
 				break
@@ -339,6 +956,19 @@ func (r *RoleBasedGroupReconciler) updateRBGStatus(
 
 }
 
+// updateTopologyStatus reports the topology domains the pods of the rbg landed in.
+func (r *RoleBasedGroupReconciler) updateTopologyStatus(ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup) error {
+	topology, err := reconciler.ConstructTopologyStatus(ctx, r.client, rbg)
+	if err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(rbg.Status.Topology, topology) {
+		return nil
+	}
+	rbg.Status.Topology = topology
+	return utils.PatchObjectApplyConfiguration(ctx, r.client, ToRBGApplyConfigurationForStatus(rbg), utils.PatchStatus)
+}
+
 func (r *RoleBasedGroupReconciler) ReconcileScalingAdapter(
 	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, roleSpec *workloadsv1alpha1.RoleSpec,
 ) error {
@@ -459,9 +1089,15 @@ func (r *RoleBasedGroupReconciler) SetupWithManager(mgr ctrl.Manager, options co
 	runtimeController = ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		For(&workloadsv1alpha1.RoleBasedGroup{}, builder.WithPredicates(RBGPredicate())).
+		Watches(
+			&workloadsv1alpha1.RoleBasedGroup{}, handler.EnqueueRequestsFromMapFunc(r.rbgToDependentRBGs),
+			builder.WithPredicates(DependencyRBGPredicate()),
+		).
 		Owns(&appsv1.StatefulSet{}, builder.WithPredicates(WorkloadPredicate())).
 		Owns(&appsv1.Deployment{}, builder.WithPredicates(WorkloadPredicate())).
+		Owns(&batchv1.Job{}, builder.WithPredicates(WorkloadPredicate())).
 		Owns(&corev1.Service{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Named("workloads-rolebasedgroup")
 
 	err := utils.CheckCrdExists(r.apiReader, utils.LwsCrdName)
@@ -479,6 +1115,20 @@ func (r *RoleBasedGroupReconciler) SetupWithManager(mgr ctrl.Manager, options co
 		watchedWorkload.LoadOrStore(scheduler.VolcanoPodGroupCrdName, struct{}{})
 		runtimeController.Owns(&volcanoschedulingv1beta1.PodGroup{})
 	}
+	err = utils.CheckCrdExists(r.apiReader, kueue.WorkloadCrdName)
+	if err == nil {
+		watchedWorkload.LoadOrStore(kueue.WorkloadCrdName, struct{}{})
+		runtimeController.Owns(kueue.NewWorkload())
+	}
+	for _, plugin := range reconciler.WorkloadPlugins() {
+		if plugin.CRDName != "" {
+			if err := utils.CheckCrdExists(r.apiReader, plugin.CRDName); err != nil {
+				continue
+			}
+			watchedWorkload.LoadOrStore(plugin.CRDName, struct{}{})
+		}
+		runtimeController.Owns(plugin.NewObject(), builder.WithPredicates(WorkloadPredicate()))
+	}
 
 	return runtimeController.Complete(r)
 }
@@ -516,6 +1166,10 @@ func RBGPredicate() predicate.Funcs {
 					ctrl.Log.Info("enqueue: rbg update event", "rbg", klog.KObj(e.ObjectOld))
 					return true
 				}
+				if oldRbg.DeletionTimestamp == nil && newRbg.DeletionTimestamp != nil {
+					ctrl.Log.Info("enqueue: rbg teardown event", "rbg", klog.KObj(e.ObjectOld))
+					return true
+				}
 			}
 			return false
 		},
@@ -533,6 +1187,52 @@ func RBGPredicate() predicate.Funcs {
 	}
 }
 
+// DependencyRBGPredicate passes the events of rbgs which may change the readiness of their roles for the rbgs
+// depending on them.
+func DependencyRBGPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldRbg, ok1 := e.ObjectOld.(*workloadsv1alpha1.RoleBasedGroup)
+			newRbg, ok2 := e.ObjectNew.(*workloadsv1alpha1.RoleBasedGroup)
+			if ok1 && ok2 {
+				return !apiequality.Semantic.DeepEqual(oldRbg.Status.RoleStatuses, newRbg.Status.RoleStatuses) ||
+					!reflect.DeepEqual(oldRbg.Spec.Roles, newRbg.Spec.Roles) ||
+					oldRbg.Annotations[workloadsv1alpha1.AllowedDependentNamespacesAnnotationKey] !=
+						newRbg.Annotations[workloadsv1alpha1.AllowedDependentNamespacesAnnotationKey] ||
+					oldRbg.DeletionTimestamp == nil && newRbg.DeletionTimestamp != nil
+			}
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// rbgToDependentRBGs enqueues the rbgs whose roles depend on the roles of a rbg.
+func (r *RoleBasedGroupReconciler) rbgToDependentRBGs(ctx context.Context, obj client.Object) []reconcile.Request {
+	rbgs := &workloadsv1alpha1.RoleBasedGroupList{}
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+	if err := r.client.List(
+		ctx, rbgs, client.MatchingFields{fieldindex.IndexNameForDependencyRBG: key},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list the rbgs depending on rbg", "rbg", key)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(rbgs.Items))
+	for i := range rbgs.Items {
+		ctrl.Log.V(1).Info("enqueue: dependency rbg event", "rbg", klog.KObj(&rbgs.Items[i]), "dependency", key)
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rbgs.Items[i])})
+	}
+	return requests
+}
+
 func WorkloadPredicate() predicate.Funcs {
 	return predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
@@ -595,9 +1295,9 @@ func hasValidOwnerRef(obj client.Object, targetGVK schema.GroupVersionKind) bool
 	return utils.CheckOwnerReference(refs, targetGVK)
 }
 
-func dynamicWatchCustomCRD(ctx context.Context, kind string) {
+func dynamicWatchCustomCRD(ctx context.Context, workload workloadsv1alpha1.WorkloadSpec, apiReader client.Reader) {
 	logger := log.FromContext(ctx)
-	switch kind {
+	switch workload.Kind {
 	case utils.GetLwsGVK().Kind:
 		_, lwsExist := watchedWorkload.Load(utils.LwsCrdName)
 		if !lwsExist {
@@ -605,5 +1305,20 @@ func dynamicWatchCustomCRD(ctx context.Context, kind string) {
 			runtimeController.Owns(&lwsv1.LeaderWorkerSet{}, builder.WithPredicates(WorkloadPredicate()))
 			logger.Info("rbgs controller watch LeaderWorkerSet CRD")
 		}
+	default:
+		plugin, found := reconciler.LookupWorkloadPlugin(workload)
+		if !found || plugin.CRDName == "" {
+			return
+		}
+		if _, pluginExist := watchedWorkload.Load(plugin.CRDName); pluginExist {
+			return
+		}
+		if err := utils.CheckCrdExists(apiReader, plugin.CRDName); err != nil {
+			logger.Info("workload CRD not found", "crd", plugin.CRDName)
+			return
+		}
+		watchedWorkload.LoadOrStore(plugin.CRDName, struct{}{})
+		runtimeController.Owns(plugin.NewObject(), builder.WithPredicates(WorkloadPredicate()))
+		logger.Info("rbgs controller watch workload CRD", "crd", plugin.CRDName)
 	}
 }
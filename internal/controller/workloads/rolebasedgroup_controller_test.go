@@ -18,14 +18,19 @@ package workloads
 
 import (
 	"context"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,12 +41,18 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/kueue"
 	"sigs.k8s.io/rbgs/pkg/scale"
+	"sigs.k8s.io/rbgs/pkg/scheduler"
 	"sigs.k8s.io/rbgs/pkg/utils"
+	"sigs.k8s.io/rbgs/pkg/utils/fieldindex"
 	"sigs.k8s.io/rbgs/test/wrappers"
+	schev1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
 )
 
 func TestRoleBasedGroupReconciler_CheckCrdExists_Partial(t *testing.T) {
@@ -629,3 +640,777 @@ func TestRoleBasedGroupReconciler_CleanupOrphanedScalingAdapters(t *testing.T) {
 		)
 	}
 }
+
+func TestRoleBasedGroupReconciler_Reconcile_KueueAdmission(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+
+	// The kueue workload CRD is considered watched, so that no CRD lookup is needed.
+	watchedWorkload.LoadOrStore(kueue.WorkloadCrdName, struct{}{})
+
+	kueueWorkload := func(
+		rbg *workloadsv1alpha1.RoleBasedGroup, name string, count int64, conditions ...interface{},
+	) *unstructured.Unstructured {
+		workload := kueue.NewWorkload()
+		workload.SetName(name)
+		workload.SetNamespace(rbg.Namespace)
+		workload.SetLabels(map[string]string{workloadsv1alpha1.SetNameLabelKey: rbg.Name})
+		workload.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())})
+		_ = unstructured.SetNestedField(workload.Object, "gpu-queue", "spec", "queueName")
+		_ = unstructured.SetNestedSlice(workload.Object, []interface{}{
+			map[string]interface{}{"name": "test-role", "count": count},
+		}, "spec", "podSets")
+		_ = unstructured.SetNestedSlice(workload.Object, conditions, "status", "conditions")
+		return workload
+	}
+
+	tests := []struct {
+		name       string
+		conditions []interface{}
+		// resized adds the admitted workload of the size before a resize
+		resized         bool
+		runningRole     bool
+		suspend         bool
+		expectAdmitted  metav1.ConditionStatus
+		expectWorkloads bool
+	}{
+		{
+			name:            "roles are not created before admission",
+			expectAdmitted:  metav1.ConditionFalse,
+			expectWorkloads: false,
+		},
+		{
+			name: "roles are created once admitted",
+			conditions: []interface{}{
+				map[string]interface{}{"type": kueue.WorkloadAdmittedCondition, "status": "True"},
+			},
+			expectAdmitted:  metav1.ConditionTrue,
+			expectWorkloads: true,
+		},
+		{
+			name:            "running roles are kept while a resized workload waits for admission",
+			conditions:      []interface{}{},
+			resized:         true,
+			runningRole:     true,
+			expectAdmitted:  metav1.ConditionFalse,
+			expectWorkloads: true,
+		},
+		{
+			name: "running roles are deleted once evicted",
+			conditions: []interface{}{
+				map[string]interface{}{"type": kueue.WorkloadAdmittedCondition, "status": "False"},
+				map[string]interface{}{"type": kueue.WorkloadEvictedCondition, "status": "True"},
+			},
+			runningRole:     true,
+			expectAdmitted:  metav1.ConditionFalse,
+			expectWorkloads: false,
+		},
+		{
+			name: "admitted workload is released once suspended",
+			conditions: []interface{}{
+				map[string]interface{}{"type": kueue.WorkloadAdmittedCondition, "status": "True"},
+			},
+			runningRole:     true,
+			suspend:         true,
+			expectAdmitted:  metav1.ConditionFalse,
+			expectWorkloads: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj()
+				rbg.Labels[kueue.QueueNameLabelKey] = "gpu-queue"
+				rbg.Spec.Suspend = tt.suspend
+				objs := []client.Object{rbg}
+				if tt.conditions != nil {
+					objs = append(objs, kueueWorkload(rbg, kueue.GetWorkloadName(rbg)+"-resized", 1, tt.conditions...))
+				}
+				if tt.resized {
+					objs = append(objs, kueueWorkload(rbg, kueue.GetWorkloadName(rbg), 2,
+						map[string]interface{}{"type": kueue.WorkloadAdmittedCondition, "status": "True"},
+					))
+				}
+				if tt.runningRole {
+					objs = append(objs, &appsv1.StatefulSet{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:            "test-rbg-test-role",
+							Namespace:       rbg.Namespace,
+							Labels:          map[string]string{workloadsv1alpha1.SetNameLabelKey: rbg.Name},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())},
+						},
+					})
+				}
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(testScheme).
+					WithObjects(objs...).
+					WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+					Build()
+
+				r := &RoleBasedGroupReconciler{
+					client:    fakeClient,
+					apiReader: fakeClient,
+					scheme:    testScheme,
+					recorder:  record.NewFakeRecorder(10),
+				}
+				ctx := ctrl.LoggerInto(context.TODO(), zap.New().WithValues("env", "unit-test"))
+
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}})
+				if err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+
+				updated := &workloadsv1alpha1.RoleBasedGroup{}
+				if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rbg), updated); err != nil {
+					t.Fatalf("get rbg error = %v", err)
+				}
+				condition := meta.FindStatusCondition(updated.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupAdmitted))
+				if condition == nil || condition.Status != tt.expectAdmitted {
+					t.Errorf("expect Admitted condition %s, got %v", tt.expectAdmitted, condition)
+				}
+
+				sts := &appsv1.StatefulSet{}
+				err = fakeClient.Get(ctx, types.NamespacedName{Name: "test-rbg-test-role", Namespace: rbg.Namespace}, sts)
+				if tt.expectWorkloads != (err == nil) {
+					t.Errorf("expect workloads created: %v, got err: %v", tt.expectWorkloads, err)
+				}
+
+				kueueWorkloads := &unstructured.UnstructuredList{}
+				kueueWorkloads.SetGroupVersionKind(kueue.WorkloadGVK.GroupVersion().WithKind(kueue.WorkloadGVK.Kind + "List"))
+				if err := fakeClient.List(ctx, kueueWorkloads, client.InNamespace(rbg.Namespace)); err != nil {
+					t.Fatalf("list kueue workloads error = %v", err)
+				}
+				if tt.suspend && len(kueueWorkloads.Items) > 0 {
+					t.Errorf("expect kueue workloads released, got %d", len(kueueWorkloads.Items))
+				}
+			},
+		)
+	}
+}
+
+func TestRoleBasedGroupReconciler_SetPodGroupMigratingCondition(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+
+	tests := []struct {
+		name            string
+		current         *metav1.ConditionStatus
+		outdatedPods    int32
+		expectCondition *metav1.ConditionStatus
+		expectEvent     string
+	}{
+		{
+			name:            "no migration",
+			outdatedPods:    0,
+			expectCondition: nil,
+		},
+		{
+			name:            "migration starts",
+			outdatedPods:    2,
+			expectCondition: ptr.To(metav1.ConditionTrue),
+			expectEvent:     PodGroupMigrating,
+		},
+		{
+			name:            "migration in progress",
+			current:         ptr.To(metav1.ConditionTrue),
+			outdatedPods:    1,
+			expectCondition: ptr.To(metav1.ConditionTrue),
+		},
+		{
+			name:            "migration completes",
+			current:         ptr.To(metav1.ConditionTrue),
+			outdatedPods:    0,
+			expectCondition: ptr.To(metav1.ConditionFalse),
+			expectEvent:     PodGroupMigrationCompleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj()
+				if tt.current != nil {
+					rbg.Status.Conditions = []metav1.Condition{{
+						Type:               string(workloadsv1alpha1.RoleBasedGroupPodGroupMigrating),
+						Status:             *tt.current,
+						Reason:             "OutdatedPods",
+						LastTransitionTime: metav1.Now(),
+					}}
+				}
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(testScheme).
+					WithObjects(rbg).
+					WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+					Build()
+				recorder := record.NewFakeRecorder(10)
+				r := &RoleBasedGroupReconciler{client: fakeClient, scheme: testScheme, recorder: recorder}
+
+				if err := r.setPodGroupMigratingCondition(context.TODO(), rbg, tt.outdatedPods); err != nil {
+					t.Fatalf("setPodGroupMigratingCondition() error = %v", err)
+				}
+
+				condition := meta.FindStatusCondition(rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupPodGroupMigrating))
+				if tt.expectCondition == nil && condition != nil {
+					t.Errorf("expect no PodGroupMigrating condition, got %v", condition)
+				}
+				if tt.expectCondition != nil && (condition == nil || condition.Status != *tt.expectCondition) {
+					t.Errorf("expect PodGroupMigrating condition %s, got %v", *tt.expectCondition, condition)
+				}
+
+				select {
+				case event := <-recorder.Events:
+					if tt.expectEvent == "" || !strings.Contains(event, tt.expectEvent) {
+						t.Errorf("expect event %q, got %q", tt.expectEvent, event)
+					}
+				default:
+					if tt.expectEvent != "" {
+						t.Errorf("expect event %q, got none", tt.expectEvent)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestRoleBasedGroupReconciler_ReconcilePodGroupSchedule(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+	_ = schev1alpha1.AddToScheme(testScheme)
+
+	pendingPodGroup := &schev1alpha1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rbg", Namespace: "default"},
+		Spec:       schev1alpha1.PodGroupSpec{MinMember: 2},
+		Status:     schev1alpha1.PodGroupStatus{Phase: schev1alpha1.PodGroupPending},
+	}
+	runningPodGroup := pendingPodGroup.DeepCopy()
+	runningPodGroup.Status.Phase = schev1alpha1.PodGroupRunning
+
+	unschedulableSince := func(since time.Time) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(workloadsv1alpha1.RoleBasedGroupSchedulable),
+			Status:             metav1.ConditionFalse,
+			Reason:             scheduler.PodGroupNotScheduled,
+			ObservedGeneration: 2,
+			LastTransitionTime: metav1.NewTime(since),
+		}
+	}
+
+	tests := []struct {
+		name              string
+		onTimeout         workloadsv1alpha1.PodGroupTimeoutAction
+		conditions        []metav1.Condition
+		podGroup          *schev1alpha1.PodGroup
+		expectSchedulable metav1.ConditionStatus
+		expectReason      string
+		expectRequeue     bool
+		expectShrunk      bool
+		expectSuspended   bool
+	}{
+		{
+			name:              "pod groups scheduled",
+			podGroup:          runningPodGroup,
+			expectSchedulable: metav1.ConditionTrue,
+			expectReason:      scheduler.PodGroupsScheduled,
+		},
+		{
+			name:              "pod groups waiting within the schedule timeout",
+			podGroup:          pendingPodGroup,
+			expectSchedulable: metav1.ConditionFalse,
+			expectReason:      scheduler.PodGroupNotScheduled,
+			expectRequeue:     true,
+		},
+		{
+			name:              "wait on schedule timeout",
+			podGroup:          pendingPodGroup,
+			conditions:        []metav1.Condition{unschedulableSince(time.Now().Add(-time.Hour))},
+			expectSchedulable: metav1.ConditionFalse,
+			expectReason:      podGroupScheduleTimeoutReason,
+		},
+		{
+			name:              "shrink optional roles on schedule timeout",
+			onTimeout:         workloadsv1alpha1.ShrinkOptionalRolesPodGroupTimeoutAction,
+			podGroup:          pendingPodGroup,
+			conditions:        []metav1.Condition{unschedulableSince(time.Now().Add(-time.Hour))},
+			expectSchedulable: metav1.ConditionFalse,
+			expectReason:      podGroupScheduleTimeoutReason,
+			expectShrunk:      true,
+		},
+		{
+			name:              "suspend on schedule timeout",
+			onTimeout:         workloadsv1alpha1.SuspendPodGroupTimeoutAction,
+			podGroup:          pendingPodGroup,
+			conditions:        []metav1.Condition{unschedulableSince(time.Now().Add(-time.Hour))},
+			expectSchedulable: metav1.ConditionFalse,
+			expectReason:      podGroupScheduleTimeoutReason,
+			expectSuspended:   true,
+		},
+		{
+			name:      "timeout actions of a previous generation are reverted",
+			onTimeout: workloadsv1alpha1.ShrinkOptionalRolesPodGroupTimeoutAction,
+			podGroup:  pendingPodGroup,
+			conditions: []metav1.Condition{
+				func() metav1.Condition {
+					condition := unschedulableSince(time.Now().Add(-time.Hour))
+					condition.Reason = podGroupScheduleTimeoutReason
+					condition.ObservedGeneration = 1
+					return condition
+				}(),
+				{
+					Type:               string(workloadsv1alpha1.RoleBasedGroupGangShrunk),
+					Status:             metav1.ConditionTrue,
+					Reason:             podGroupScheduleTimeoutReason,
+					ObservedGeneration: 1,
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+			expectSchedulable: metav1.ConditionFalse,
+			expectReason:      scheduler.PodGroupNotScheduled,
+			expectRequeue:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				router := wrappers.BuildBasicRole("router").Obj()
+				prefill := wrappers.BuildBasicRole("prefill").Obj()
+				prefill.Optional = true
+				rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+					WithRoles([]workloadsv1alpha1.RoleSpec{router, prefill}).
+					WithKubeGangScheduling(true).Obj()
+				rbg.Generation = 2
+				rbg.Spec.PodGroupPolicy.OnTimeout = tt.onTimeout
+				rbg.Status.Conditions = tt.conditions
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(testScheme).
+					WithObjects(rbg, tt.podGroup.DeepCopy()).
+					WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+					Build()
+				r := &RoleBasedGroupReconciler{client: fakeClient, scheme: testScheme, recorder: record.NewFakeRecorder(10)}
+
+				requeueAfter, err := r.reconcilePodGroupSchedule(context.TODO(), rbg, scheduler.NewPodGroupScheduler(fakeClient))
+				if err != nil {
+					t.Fatalf("reconcilePodGroupSchedule() error = %v", err)
+				}
+				if (requeueAfter > 0) != tt.expectRequeue {
+					t.Errorf("expect requeue %v, got %v", tt.expectRequeue, requeueAfter)
+				}
+
+				condition := meta.FindStatusCondition(rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupSchedulable))
+				if condition == nil || condition.Status != tt.expectSchedulable || condition.Reason != tt.expectReason {
+					t.Errorf("expect Schedulable condition %s/%s, got %v", tt.expectSchedulable, tt.expectReason, condition)
+				}
+				if rbg.IsGangShrunk() != tt.expectShrunk {
+					t.Errorf("expect gang shrunk %v, got %v", tt.expectShrunk, rbg.IsGangShrunk())
+				}
+				if rbg.IsSuspendedOnTimeout() != tt.expectSuspended {
+					t.Errorf("expect suspended %v, got %v", tt.expectSuspended, rbg.IsSuspendedOnTimeout())
+				}
+			},
+		)
+	}
+}
+
+func TestRoleBasedGroupReconciler_Reconcile_Suspend(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{
+			wrappers.BuildBasicRole("backend").WithReplicas(2).Obj(),
+			wrappers.BuildBasicRole("router").WithReplicas(2).WithDependencies([]string{"backend"}).Obj(),
+		}).Obj()
+	rbg.Spec.Suspend = true
+
+	workload := func(role string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-rbg-" + role, Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](2)},
+		}
+	}
+	routerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rbg-router-0",
+			Namespace: "default",
+			Labels: map[string]string{
+				workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+				workloadsv1alpha1.SetRoleLabelKey: "router",
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(rbg, workload("backend"), workload("router"), routerPod).
+		WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+		Build()
+
+	r := &RoleBasedGroupReconciler{
+		client:    fakeClient,
+		apiReader: fakeClient,
+		scheme:    testScheme,
+		recorder:  record.NewFakeRecorder(10),
+	}
+	ctx := ctrl.LoggerInto(context.TODO(), zap.New().WithValues("env", "unit-test"))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}}
+
+	expectReplicas := func(role string, replicas int32) {
+		t.Helper()
+		sts := &appsv1.StatefulSet{}
+		if err := fakeClient.Get(ctx, types.NamespacedName{Name: "test-rbg-" + role, Namespace: "default"}, sts); err != nil {
+			t.Fatalf("get sts error = %v", err)
+		}
+		if *sts.Spec.Replicas != replicas {
+			t.Errorf("expect %d replicas of role %s, got %d", replicas, role, *sts.Spec.Replicas)
+		}
+	}
+	expectCondition := func(status metav1.ConditionStatus, reason string) {
+		t.Helper()
+		updated := &workloadsv1alpha1.RoleBasedGroup{}
+		if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rbg), updated); err != nil {
+			t.Fatalf("get rbg error = %v", err)
+		}
+		condition := meta.FindStatusCondition(updated.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupSuspended))
+		if condition == nil || condition.Status != status || condition.Reason != reason {
+			t.Errorf("expect Suspended condition %s with reason %s, got %v", status, reason, condition)
+		}
+	}
+
+	// the dependent role is scaled down first, its dependency waits for its pods to terminate
+	result, err := r.Reconcile(ctx, request)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != suspendRequeueInterval {
+		t.Errorf("expect requeue after %s, got %s", suspendRequeueInterval, result.RequeueAfter)
+	}
+	expectReplicas("router", 0)
+	expectReplicas("backend", 2)
+	expectCondition(metav1.ConditionFalse, suspendingReason)
+
+	// the dependency is scaled down once the pods of the dependent role are gone
+	if err := fakeClient.Delete(ctx, routerPod); err != nil {
+		t.Fatalf("delete pod error = %v", err)
+	}
+	result, err = r.Reconcile(ctx, request)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expect no requeue, got %s", result.RequeueAfter)
+	}
+	expectReplicas("backend", 0)
+	expectCondition(metav1.ConditionTrue, suspendedReason)
+
+	updated := &workloadsv1alpha1.RoleBasedGroup{}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rbg), updated); err != nil {
+		t.Fatalf("get rbg error = %v", err)
+	}
+	ready := meta.FindStatusCondition(updated.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupReady))
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != "Suspended" {
+		t.Errorf("expect Ready condition False with reason Suspended, got %v", ready)
+	}
+	for _, role := range []string{"backend", "router"} {
+		status, found := updated.GetRoleStatus(role)
+		if !found || status.Replicas != 0 {
+			t.Errorf("expect status of role %s with 0 replicas, got %v", role, status)
+		}
+	}
+}
+
+func TestRoleBasedGroupReconciler_Reconcile_SuspendCompletedJob(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{
+			wrappers.BuildBasicRole("init").WithWorkload(workloadsv1alpha1.JobWorkloadType).Obj(),
+		}).Obj()
+	rbg.Spec.Suspend = true
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rbg-init", Namespace: "default"},
+		Status:     batchv1.JobStatus{Succeeded: 1},
+	}
+	// the succeeded pod of the completed job is kept until the job is deleted
+	succeededPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rbg-init-abcde",
+			Namespace: "default",
+			Labels: map[string]string{
+				workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+				workloadsv1alpha1.SetRoleLabelKey: "init",
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(rbg, job, succeededPod).
+		WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+		Build()
+
+	r := &RoleBasedGroupReconciler{
+		client:    fakeClient,
+		apiReader: fakeClient,
+		scheme:    testScheme,
+		recorder:  record.NewFakeRecorder(10),
+	}
+	ctx := ctrl.LoggerInto(context.TODO(), zap.New().WithValues("env", "unit-test"))
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expect no requeue, got %s", result.RequeueAfter)
+	}
+
+	updatedJob := &batchv1.Job{}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(job), updatedJob); err != nil {
+		t.Fatalf("get job error = %v", err)
+	}
+	if !ptr.Deref(updatedJob.Spec.Suspend, false) {
+		t.Errorf("expect job to be suspended")
+	}
+	updated := &workloadsv1alpha1.RoleBasedGroup{}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rbg), updated); err != nil {
+		t.Fatalf("get rbg error = %v", err)
+	}
+	condition := meta.FindStatusCondition(updated.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupSuspended))
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != suspendedReason {
+		t.Errorf("expect Suspended condition True with reason %s, got %v", suspendedReason, condition)
+	}
+}
+
+func TestRoleBasedGroupReconciler_Reconcile_AddFinalizer(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(rbg).
+		WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				// an update would overwrite the spec with a stale copy of the rbg
+				if _, ok := obj.(*workloadsv1alpha1.RoleBasedGroup); ok {
+					t.Errorf("expect the rbg to be patched, got an update")
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	r := &RoleBasedGroupReconciler{
+		client:    fakeClient,
+		apiReader: fakeClient,
+		scheme:    testScheme,
+		recorder:  record.NewFakeRecorder(100),
+	}
+	ctx := ctrl.LoggerInto(context.TODO(), zap.New().WithValues("env", "unit-test"))
+	_, _ = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}})
+
+	updated := &workloadsv1alpha1.RoleBasedGroup{}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rbg), updated); err != nil {
+		t.Fatalf("get rbg error = %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(updated, workloadsv1alpha1.TeardownFinalizer) {
+		t.Errorf("expect finalizer %s, got %v", workloadsv1alpha1.TeardownFinalizer, updated.Finalizers)
+	}
+}
+
+func TestRoleBasedGroupReconciler_Reconcile_Teardown(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{
+			wrappers.BuildBasicRole("backend").Obj(),
+			wrappers.BuildBasicRole("router").WithDependencies([]string{"backend"}).Obj(),
+		}).Obj()
+	rbg.Finalizers = []string{workloadsv1alpha1.TeardownFinalizer}
+
+	workload := func(role string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-rbg-" + role, Namespace: "default"},
+		}
+	}
+	routerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rbg-router-0",
+			Namespace: "default",
+			Labels: map[string]string{
+				workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+				workloadsv1alpha1.SetRoleLabelKey: "router",
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(rbg, workload("backend"), workload("router"), routerPod).
+		WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+		Build()
+
+	r := &RoleBasedGroupReconciler{
+		client:    fakeClient,
+		apiReader: fakeClient,
+		scheme:    testScheme,
+		recorder:  record.NewFakeRecorder(10),
+	}
+	ctx := ctrl.LoggerInto(context.TODO(), zap.New().WithValues("env", "unit-test"))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}}
+	if err := fakeClient.Delete(ctx, rbg); err != nil {
+		t.Fatalf("delete rbg error = %v", err)
+	}
+
+	workloadExists := func(role string) bool {
+		err := fakeClient.Get(ctx, types.NamespacedName{Name: "test-rbg-" + role, Namespace: "default"}, &appsv1.StatefulSet{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			t.Fatalf("get sts error = %v", err)
+		}
+		return err == nil
+	}
+
+	// the dependent role is deleted first, its dependency is kept until its pods terminate
+	result, err := r.Reconcile(ctx, request)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != teardownRequeueInterval {
+		t.Errorf("expect requeue after %s, got %s", teardownRequeueInterval, result.RequeueAfter)
+	}
+	if workloadExists("router") || !workloadExists("backend") {
+		t.Errorf("expect only the workload of the router deleted")
+	}
+
+	// the dependency is deleted once the pods of the dependent role are gone, then the finalizer is removed
+	if err := fakeClient.Delete(ctx, routerPod); err != nil {
+		t.Fatalf("delete pod error = %v", err)
+	}
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if workloadExists("backend") {
+		t.Errorf("expect the workload of the backend deleted")
+	}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rbg), &workloadsv1alpha1.RoleBasedGroup{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expect the rbg deleted after the teardown, got err: %v", err)
+	}
+}
+
+func TestRoleBasedGroupReconciler_Reconcile_WaitingForDependencies(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{
+			wrappers.BuildBasicRole("backend").WithReplicas(1).Obj(),
+			wrappers.BuildBasicRole("router").WithReplicas(1).WithDependencies([]string{"backend"}).Obj(),
+		}).Obj()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(rbg).
+		WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroup{}).
+		Build()
+
+	r := &RoleBasedGroupReconciler{
+		client:    fakeClient,
+		apiReader: fakeClient,
+		scheme:    testScheme,
+		recorder:  record.NewFakeRecorder(10),
+	}
+	ctx := ctrl.LoggerInto(context.TODO(), zap.New().WithValues("env", "unit-test"))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}}
+
+	getWorkload := func(role string) (*appsv1.StatefulSet, error) {
+		sts := &appsv1.StatefulSet{}
+		err := fakeClient.Get(ctx, types.NamespacedName{Name: "test-rbg-" + role, Namespace: "default"}, sts)
+		return sts, err
+	}
+	expectCondition := func(status metav1.ConditionStatus, reason string) {
+		t.Helper()
+		updated := &workloadsv1alpha1.RoleBasedGroup{}
+		if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rbg), updated); err != nil {
+			t.Fatalf("get rbg error = %v", err)
+		}
+		condition := meta.FindStatusCondition(
+			updated.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupWaitingForDependencies),
+		)
+		if condition == nil || condition.Status != status || condition.Reason != reason {
+			t.Errorf("expect WaitingForDependencies condition %s with reason %s, got %v", status, reason, condition)
+		}
+	}
+
+	// the router waits for the backend without failing the reconcile
+	result, err := r.Reconcile(ctx, request)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != dependencyRequeueInterval {
+		t.Errorf("expect requeue after %s, got %s", dependencyRequeueInterval, result.RequeueAfter)
+	}
+	if _, err := getWorkload("router"); !apierrors.IsNotFound(err) {
+		t.Errorf("expect the workload of the router not created, got error %v", err)
+	}
+	expectCondition(metav1.ConditionTrue, "DependenciesNotReady")
+
+	// the router is created once the backend is ready
+	backend, err := getWorkload("backend")
+	if err != nil {
+		t.Fatalf("get sts error = %v", err)
+	}
+	backend.Status.Replicas = 1
+	backend.Status.ReadyReplicas = 1
+	if err := fakeClient.Status().Update(ctx, backend); err != nil {
+		t.Fatalf("update sts status error = %v", err)
+	}
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if _, err := getWorkload("router"); err != nil {
+		t.Errorf("expect the workload of the router created, got error %v", err)
+	}
+	expectCondition(metav1.ConditionFalse, "DependenciesReady")
+}
+
+func TestRoleBasedGroupReconciler_rbgToDependentRBGs(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = workloadsv1alpha1.AddToScheme(testScheme)
+
+	kvCache := wrappers.BuildBasicRoleBasedGroup("kv-cache", "shared").
+		WithRoles([]workloadsv1alpha1.RoleSpec{wrappers.BuildBasicRole("cache").Obj()}).Obj()
+	dependent := func(name, namespace string) *workloadsv1alpha1.RoleBasedGroup {
+		return wrappers.BuildBasicRoleBasedGroup(name, "default").
+			WithRoles([]workloadsv1alpha1.RoleSpec{
+				wrappers.BuildBasicRole("router").
+					WithDependency(workloadsv1alpha1.RoleDependency{Role: "cache", RBG: "kv-cache", Namespace: namespace}).
+					Obj(),
+			}).Obj()
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithIndex(&workloadsv1alpha1.RoleBasedGroup{}, fieldindex.IndexNameForDependencyRBG, fieldindex.DependencyRBGIndexFunc).
+		WithObjects(kvCache, dependent("model-a", "shared"), dependent("model-b", "other")).
+		Build()
+
+	r := &RoleBasedGroupReconciler{client: fakeClient, scheme: testScheme}
+	ctx := ctrl.LoggerInto(context.TODO(), zap.New().WithValues("env", "unit-test"))
+
+	requests := r.rbgToDependentRBGs(ctx, kvCache)
+	expected := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "model-a", Namespace: "default"}}}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expect requests %v, got %v", expected, requests)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -47,6 +48,9 @@ import (
 	"sigs.k8s.io/rbgs/pkg/utils"
 )
 
+// suspendedScalingRequeueInterval is the interval at which the scaling of a role of a suspended rbg is retried.
+const suspendedScalingRequeueInterval = 30 * time.Second
+
 // RoleBasedGroupScalingAdapterReconciler reconciles a RoleBasedGroupScalingAdapter object
 type RoleBasedGroupScalingAdapterReconciler struct {
 	client    client.Client
@@ -192,6 +196,12 @@ func (r *RoleBasedGroupScalingAdapterReconciler) Reconcile(ctx context.Context,
 		return ctrl.Result{}, nil
 	}
 
+	if rbg.Spec.Suspend {
+		// the replicas of a suspended rbg are restored on resume, scaling is paused until then
+		logger.Info("Scaling paused, the rbg is suspended", "desired replicas", *desiredReplicas)
+		return ctrl.Result{RequeueAfter: suspendedScalingRequeueInterval}, nil
+	}
+
 	logger.Info("Start scaling", "desired replicas", *desiredReplicas, "current replicas", *currentReplicas)
 
 	// scale role
@@ -18,10 +18,12 @@ package workloads
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -30,17 +32,27 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	instanceutil "sigs.k8s.io/rbgs/pkg/reconciler/instance/utils"
 	"sigs.k8s.io/rbgs/pkg/utils"
 )
 
+const (
+	// rbgsetInitialBatchSize is the size of the first batch when creating RoleBasedGroups in slow-start batches.
+	rbgsetInitialBatchSize = 1
+)
+
 // RoleBasedGroupSetReconciler reconciles a RoleBasedGroupSet object
 type RoleBasedGroupSetReconciler struct {
 	client    client.Client
@@ -114,22 +126,21 @@ func (r *RoleBasedGroupSetReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	desiredReplicas := int(*rbgset.Spec.Replicas)
-	var rbgsToCreate []*workloadsv1alpha1.RoleBasedGroup
 
-	// Determine which RBGs need to be created.
-	for i := 0; i < desiredReplicas; i++ {
-		if _, exists := existingRBGs[i]; !exists {
-			rbg := newRBGForSet(rbgset, i)
-			rbgsToCreate = append(rbgsToCreate, rbg)
-		}
+	// Invalid overrides are reported in the status and only block the RBGs they select.
+	var overrideErrs []error
+	if err := validateOverrides(rbgset); err != nil {
+		logger.Error(err, "Invalid overrides")
+		overrideErrs = append(overrideErrs, err)
 	}
 
-	// Determine which RBGs need to be deleted (e.g., index is out of bounds of desired replicas).
-	for index, rbg := range existingRBGs {
-		if index >= desiredReplicas {
-			rbgsToDelete = append(rbgsToDelete, rbg)
-		}
+	// Determine which RBGs need to be deleted or created.
+	victims, rbgsToCreate, err := r.calculateScale(rbgset, existingRBGs, desiredReplicas)
+	if err != nil {
+		logger.Error(err, "Failed to apply overrides to new RoleBasedGroups")
+		overrideErrs = append(overrideErrs, err)
 	}
+	rbgsToDelete = append(rbgsToDelete, victims...)
 
 	// 4. Perform operations in optimized order.
 	// First, scale down to avoid unnecessary updates on RBGs that will be deleted.
@@ -149,6 +160,11 @@ func (r *RoleBasedGroupSetReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	for _, rbg := range rbgsToDelete {
 		rbgsToDeleteMap[rbg.Name] = true
 	}
+	for _, rbg := range existingRBGs {
+		if !rbg.DeletionTimestamp.IsZero() {
+			rbgsToDeleteMap[rbg.Name] = true
+		}
+	}
 
 	// Check for updates needed on existing RBGs that won't be deleted
 	var rbgsToUpdate []*workloadsv1alpha1.RoleBasedGroup
@@ -157,7 +173,13 @@ func (r *RoleBasedGroupSetReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		if rbgsToDeleteMap[rbg.Name] {
 			continue
 		}
-		if r.needsUpdate(rbgset, rbg) {
+		update, err := r.needsUpdate(rbgset, rbg)
+		if err != nil {
+			logger.Error(err, "Failed to apply overrides to RoleBasedGroup, skip updating it", "rbgName", rbg.Name)
+			overrideErrs = append(overrideErrs, err)
+			continue
+		}
+		if update {
 			rbgsToUpdate = append(rbgsToUpdate, rbg)
 		}
 	}
@@ -171,7 +193,19 @@ func (r *RoleBasedGroupSetReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}
 
-	// Finally, scale up to create new RBGs
+	// Finally, scale up to create new RBGs, gated by the number of unavailable RBGs.
+	allowedToCreate, err := r.limitScaleUp(rbgset, existingRBGs, rbgsToDeleteMap, rbgsToCreate)
+	if err != nil {
+		logger.Error(err, "Failed to calculate scale-up budget")
+		return ctrl.Result{}, err
+	}
+	if len(allowedToCreate) < len(rbgsToCreate) {
+		logger.Info(
+			"Scale-up is limited by maxUnavailable, waiting for existing RoleBasedGroups to become ready",
+			"allowed", len(allowedToCreate), "pending", len(rbgsToCreate)-len(allowedToCreate),
+		)
+	}
+	rbgsToCreate = allowedToCreate
 	if len(rbgsToCreate) > 0 {
 		logger.Info(
 			fmt.Sprintf("Scaling up RoleBasedGroups, %d -> %d", len(existingRBGs), desiredReplicas), "count",
@@ -192,7 +226,7 @@ func (r *RoleBasedGroupSetReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		logger.Error(err, "Failed to re-list child RoleBasedGroups for status update")
 		return ctrl.Result{}, err
 	}
-	if err := r.updateStatus(ctx, rbgset, &rbglist); err != nil {
+	if err := r.updateStatus(ctx, rbgset, &rbglist, overrideErrs); err != nil {
 		logger.Error(err, "Failed to update RoleBasedGroupSet status")
 		return ctrl.Result{}, err
 	}
@@ -201,45 +235,193 @@ func (r *RoleBasedGroupSetReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	return ctrl.Result{}, nil
 }
 
-// scaleUp concurrently creates a given set of RoleBasedGroup instances.
+// scaleUp creates a given set of RoleBasedGroup instances in slow-start batches.
+// Batches start with a single RoleBasedGroup and double in size after each fully
+// successful batch, so a broken template fails fast instead of producing a failed
+// RoleBasedGroup for every missing replica. Remaining batches are skipped once a batch fails.
 func (r *RoleBasedGroupSetReconciler) scaleUp(
 	ctx context.Context, rbgset *workloadsv1alpha1.RoleBasedGroupSet, rbgsToCreate []*workloadsv1alpha1.RoleBasedGroup,
 ) error {
 	logger := log.FromContext(ctx)
-	// TODO: we need to enhance it by following the way:
-	// https://github.com/openkruise/kruise/blob/master/pkg/controller/statefulset/stateful_set_control.go#L478
-	allErrs := make([]error, 0, len(rbgsToCreate))
+
+	rbgCreationChan := make(chan *workloadsv1alpha1.RoleBasedGroup, len(rbgsToCreate))
 	for _, rbg := range rbgsToCreate {
 		// Set the owner reference.
 		if err := controllerutil.SetControllerReference(rbgset, rbg, r.scheme); err != nil {
-			allErrs = append(allErrs, fmt.Errorf("failed to set controller reference for rbg %s: %w", rbg.Name, err))
+			return fmt.Errorf("failed to set controller reference for rbg %s: %w", rbg.Name, err)
+		}
+		rbgCreationChan <- rbg
+	}
+
+	successes, err := instanceutil.DoItSlowly(
+		len(rbgsToCreate), rbgsetInitialBatchSize, func() error {
+			rbg := <-rbgCreationChan
+			if err := r.client.Create(ctx, rbg); err != nil {
+				// If it already exists, ignore the error. This ensures idempotency,
+				// e.g., if the previous reconcile was interrupted after a successful creation.
+				if apierrors.IsAlreadyExists(err) {
+					logger.V(1).Info("RoleBasedGroup has been created", "name", rbg.Name)
+					return nil
+				}
+				return fmt.Errorf("failed to create RoleBasedGroup %s: %w", rbg.Name, err)
+			}
+			logger.Info("Successfully created RoleBasedGroup", "name", rbg.Name)
+			return nil
+		},
+	)
+	if err != nil {
+		skipped := len(rbgsToCreate) - successes
+		logger.Info("Slow-start scale-up stopped after a failed batch", "created", successes, "skipped", skipped)
+		return err
+	}
+	return nil
+}
+
+// calculateScale returns the RoleBasedGroups to delete and to create so that the number of
+// active RoleBasedGroups matches desiredReplicas.
+// RoleBasedGroups labeled with the specified-delete label are always deleted and replaced.
+// When scaling down, victims are chosen by rankScaleDownCandidates. Surviving RoleBasedGroups keep
+// their indices, and new RoleBasedGroups take the lowest indices that are not in use, including
+// indices still held by terminating RoleBasedGroups.
+// The RoleBasedGroups whose overrides cannot be applied are not created, they keep their indices and the errors of
+// their overrides are returned along with the other RoleBasedGroups to create.
+func (r *RoleBasedGroupSetReconciler) calculateScale(
+	rbgset *workloadsv1alpha1.RoleBasedGroupSet, existingRBGs map[int]*workloadsv1alpha1.RoleBasedGroup,
+	desiredReplicas int,
+) ([]*workloadsv1alpha1.RoleBasedGroup, []*workloadsv1alpha1.RoleBasedGroup, error) {
+	indices := make([]int, 0, len(existingRBGs))
+	for index := range existingRBGs {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	var rbgsToDelete []*workloadsv1alpha1.RoleBasedGroup
+	var candidates []int
+	for _, index := range indices {
+		rbg := existingRBGs[index]
+		if !rbg.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if isRBGSpecifiedDelete(rbg) {
+			rbgsToDelete = append(rbgsToDelete, rbg)
 			continue
 		}
+		candidates = append(candidates, index)
+	}
+
+	if len(candidates) > desiredReplicas {
+		r.rankScaleDownCandidates(rbgset, existingRBGs, candidates)
+		for _, index := range candidates[:len(candidates)-desiredReplicas] {
+			rbgsToDelete = append(rbgsToDelete, existingRBGs[index])
+		}
+		return rbgsToDelete, nil, nil
+	}
 
-		// Already created not need to continue
-		got := &workloadsv1alpha1.RoleBasedGroup{}
-		if err := r.client.Get(
-			ctx, types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}, got,
-		); err == nil {
+	var rbgsToCreate []*workloadsv1alpha1.RoleBasedGroup
+	var errs []error
+	for index := 0; len(candidates)+len(rbgsToCreate)+len(errs) < desiredReplicas; index++ {
+		if _, exists := existingRBGs[index]; exists {
 			continue
 		}
+		rbg, err := newRBGForSet(rbgset, index)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rbgsToCreate = append(rbgsToCreate, rbg)
+	}
+	return rbgsToDelete, rbgsToCreate, utilerrors.NewAggregate(errs)
+}
+
+// rankScaleDownCandidates sorts the indices of candidate RoleBasedGroups so that the ones
+// to be deleted first come first. RoleBasedGroups listed in scaleStrategy.rbgsToDelete always
+// rank first, followed by the order of scaleStrategy.scaleDownPolicy, and finally the highest index.
+func (r *RoleBasedGroupSetReconciler) rankScaleDownCandidates(
+	rbgset *workloadsv1alpha1.RoleBasedGroupSet, existingRBGs map[int]*workloadsv1alpha1.RoleBasedGroup,
+	candidates []int,
+) {
+	policy := workloadsv1alpha1.HighestIndexScaleDownPolicy
+	specified := make(map[string]bool)
+	if rbgset.Spec.ScaleStrategy != nil {
+		if rbgset.Spec.ScaleStrategy.ScaleDownPolicy != "" {
+			policy = rbgset.Spec.ScaleStrategy.ScaleDownPolicy
+		}
+		for _, name := range rbgset.Spec.ScaleStrategy.RBGsToDelete {
+			specified[name] = true
+		}
+	}
 
-		if err := r.client.Create(ctx, rbg); err != nil {
-			// If it already exists, ignore the error. This ensures idempotency,
-			// e.g., if the previous reconcile was interrupted after a successful creation.
-			if !apierrors.IsAlreadyExists(err) {
-				allErrs = append(allErrs, fmt.Errorf("failed to create RoleBasedGroup %s: %w", rbg.Name, err))
-			} else {
-				logger.V(1).Info("RoleBasedGroup has been created", "name", rbg.Name)
+	// rank returns a smaller value for RoleBasedGroups that should be deleted earlier.
+	rank := func(rbg *workloadsv1alpha1.RoleBasedGroup) int {
+		if specified[rbg.Name] {
+			return 0
+		}
+		switch policy {
+		case workloadsv1alpha1.UnreadyFirstScaleDownPolicy:
+			if !meta.IsStatusConditionTrue(rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupReady)) {
+				return 1
+			}
+		case workloadsv1alpha1.OldRevisionFirstScaleDownPolicy:
+			// Overrides have been validated when the candidates were listed, treat errors as outdated.
+			if update, err := r.needsUpdate(rbgset, rbg); err != nil || update {
+				return 1
 			}
-		} else {
-			logger.Info("Successfully created RoleBasedGroup", "name", rbg.Name)
 		}
+		return 2
+	}
 
+	sort.SliceStable(
+		candidates, func(i, j int) bool {
+			rankI, rankJ := rank(existingRBGs[candidates[i]]), rank(existingRBGs[candidates[j]])
+			if rankI != rankJ {
+				return rankI < rankJ
+			}
+			return candidates[i] > candidates[j]
+		},
+	)
+}
+
+// limitScaleUp truncates rbgsToCreate so that the number of unavailable RoleBasedGroups
+// does not exceed scaleStrategy.maxUnavailable. RoleBasedGroups that are going to be
+// deleted are not taken into account.
+func (r *RoleBasedGroupSetReconciler) limitScaleUp(
+	rbgset *workloadsv1alpha1.RoleBasedGroupSet, existingRBGs map[int]*workloadsv1alpha1.RoleBasedGroup,
+	rbgsToDeleteMap map[string]bool, rbgsToCreate []*workloadsv1alpha1.RoleBasedGroup,
+) ([]*workloadsv1alpha1.RoleBasedGroup, error) {
+	if rbgset.Spec.ScaleStrategy == nil || rbgset.Spec.ScaleStrategy.MaxUnavailable == nil {
+		return rbgsToCreate, nil
 	}
 
-	// Aggregate all errors.
-	return utilerrors.NewAggregate(allErrs)
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(
+		rbgset.Spec.ScaleStrategy.MaxUnavailable, int(*rbgset.Spec.Replicas), true,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Never block scale-up completely.
+	if maxUnavailable < 1 {
+		maxUnavailable = 1
+	}
+
+	unavailable := 0
+	for _, rbg := range existingRBGs {
+		// Terminating RBGs are part of rbgsToDeleteMap as well.
+		if rbgsToDeleteMap[rbg.Name] {
+			continue
+		}
+		if !meta.IsStatusConditionTrue(rbg.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupReady)) {
+			unavailable++
+		}
+	}
+
+	allowed := maxUnavailable - unavailable
+	if allowed <= 0 {
+		return nil, nil
+	}
+	if allowed < len(rbgsToCreate) {
+		return rbgsToCreate[:allowed], nil
+	}
+	return rbgsToCreate, nil
 }
 
 // scaleDown concurrently deletes a given set of RoleBasedGroup instances.
@@ -262,15 +444,18 @@ func (r *RoleBasedGroupSetReconciler) scaleDown(
 	return utilerrors.NewAggregate(allErrs)
 }
 
-// updateStatus updates the status of the RoleBasedGroupSet.
+// updateStatus updates the status of the RoleBasedGroupSet, reporting the errors of the overrides that cannot be
+// applied.
 func (r *RoleBasedGroupSetReconciler) updateStatus(
 	ctx context.Context, rbgset *workloadsv1alpha1.RoleBasedGroupSet, rbglist *workloadsv1alpha1.RoleBasedGroupList,
+	overrideErrs []error,
 ) error {
 	logger := log.FromContext(ctx)
 
 	// Create a deep copy of the status to modify.
 	newStatus := *rbgset.Status.DeepCopy()
 	newStatus.Replicas = int32(len(rbglist.Items))
+	newStatus.LabelSelector = metav1.FormatLabelSelector(rbgSetPodSelector(rbgset))
 
 	// Calculate the number of ready replicas.
 	readyReplicas := 0
@@ -305,6 +490,11 @@ func (r *RoleBasedGroupSetReconciler) updateStatus(
 	}
 	// Use apimeta.SetStatusCondition to safely set or update the condition. It correctly handles the LastTransitionTime.
 	meta.SetStatusCondition(&newStatus.Conditions, condition)
+	if len(rbgset.Spec.Overrides) > 0 {
+		meta.SetStatusCondition(&newStatus.Conditions, overridesAppliedCondition(overrideErrs))
+	} else {
+		meta.RemoveStatusCondition(&newStatus.Conditions, string(workloadsv1alpha1.RoleBasedGroupSetOverridesApplied))
+	}
 
 	// Only update the status if it has changed to avoid unnecessary API calls.
 	if reflect.DeepEqual(rbgset.Status, newStatus) {
@@ -337,6 +527,63 @@ func (r *RoleBasedGroupSetReconciler) updateStatus(
 	)
 }
 
+// overridesAppliedCondition returns the OverridesApplied condition of the errors of the overrides. The errors of
+// several RBGs failing on the same override are reported once.
+func overridesAppliedCondition(overrideErrs []error) metav1.Condition {
+	if len(overrideErrs) == 0 {
+		return metav1.Condition{
+			Type:    string(workloadsv1alpha1.RoleBasedGroupSetOverridesApplied),
+			Status:  metav1.ConditionTrue,
+			Reason:  "OverridesApplied",
+			Message: "All overrides are applied.",
+		}
+	}
+	messages := sets.New[string]()
+	for _, err := range overrideErrs {
+		for _, e := range utilerrors.Flatten(utilerrors.NewAggregate([]error{err})).Errors() {
+			messages.Insert(e.Error())
+		}
+	}
+	return metav1.Condition{
+		Type:    string(workloadsv1alpha1.RoleBasedGroupSetOverridesApplied),
+		Status:  metav1.ConditionFalse,
+		Reason:  "InvalidOverride",
+		Message: strings.Join(sets.List(messages), "; "),
+	}
+}
+
+// validateOverrides checks the selectors of the overrides, and that their patches apply to the roles of the
+// template, regardless of the RBGs they select.
+func validateOverrides(rbgset *workloadsv1alpha1.RoleBasedGroupSet) error {
+	var errs []error
+	for i := range rbgset.Spec.Overrides {
+		override := &rbgset.Spec.Overrides[i]
+		if override.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(override.Selector); err != nil {
+				errs = append(errs, fmt.Errorf("invalid override %d: %w", i, err))
+			}
+		}
+		for _, roleOverride := range override.Roles {
+			found := false
+			for j := range rbgset.Spec.Template.Roles {
+				role := &rbgset.Spec.Template.Roles[j]
+				if role.Name != roleOverride.Name {
+					continue
+				}
+				found = true
+				if _, err := patchRoleSpec(*role.DeepCopy(), roleOverride.Patch); err != nil {
+					errs = append(errs, fmt.Errorf("invalid override %d for role %s: %w", i, roleOverride.Name, err))
+				}
+				break
+			}
+			if !found {
+				errs = append(errs, fmt.Errorf("invalid override %d: role %s not found in template", i, roleOverride.Name))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 // rolesEqual compares two role slices by sorting them by name first.
 func (r *RoleBasedGroupSetReconciler) rolesEqual(
 	roles1, roles2 []workloadsv1alpha1.RoleSpec,
@@ -370,14 +617,27 @@ func (r *RoleBasedGroupSetReconciler) rolesEqual(
 // needsUpdate checks if a child RBG needs to be updated based on changes in the parent RBGSet.
 func (r *RoleBasedGroupSetReconciler) needsUpdate(
 	rbgset *workloadsv1alpha1.RoleBasedGroupSet, rbg *workloadsv1alpha1.RoleBasedGroup,
-) bool {
-	// Check if the template spec has changed using order-insensitive comparison
-	if !r.rolesEqual(rbg.Spec.Roles, rbgset.Spec.Template.Roles) {
-		return true
+) (bool, error) {
+	// Check if the labels of the overrides need to be added
+	labeled := rbg.DeepCopy()
+	if err := applyOverrideLabels(rbgset, labeled); err != nil {
+		return false, err
+	}
+	if !reflect.DeepEqual(rbg.Labels, labeled.Labels) {
+		return true, nil
+	}
+
+	// Check if the template spec with overrides applied has changed using order-insensitive comparison
+	desiredRoles, err := rolesForRBG(rbgset, labeled)
+	if err != nil {
+		return false, err
+	}
+	if !r.rolesEqual(rbg.Spec.Roles, desiredRoles) {
+		return true, nil
 	}
 
 	// Check if annotations need to be propagated
-	return r.needsAnnotationUpdate(rbgset, rbg)
+	return r.needsAnnotationUpdate(rbgset, rbg), nil
 }
 
 // needsAnnotationUpdate checks if RBG annotations need to be updated to match RBGSet annotations.
@@ -425,8 +685,15 @@ func (r *RoleBasedGroupSetReconciler) updateExistingRBGs(
 					return err
 				}
 
-				// Update the spec from template
-				latestRBG.Spec.Roles = rbgset.Spec.Template.Roles
+				// Update the labels and the spec from template and overrides
+				if err := applyOverrideLabels(rbgset, latestRBG); err != nil {
+					return err
+				}
+				desiredRoles, err := rolesForRBG(rbgset, latestRBG)
+				if err != nil {
+					return err
+				}
+				latestRBG.Spec.Roles = desiredRoles
 
 				// Update annotations
 				r.updateRBGAnnotations(rbgset, latestRBG)
@@ -466,8 +733,24 @@ func (r *RoleBasedGroupSetReconciler) updateRBGAnnotations(
 	}
 }
 
-// newRBGForSet creates a new RoleBasedGroup object based on the set's template.
-func newRBGForSet(rbgset *workloadsv1alpha1.RoleBasedGroupSet, index int) *workloadsv1alpha1.RoleBasedGroup {
+// rbgSetPodSelector returns the label selector over all pods of all rbgs in the rbgset.
+// The rbgset name label is propagated from the rbgs down to their pods.
+func rbgSetPodSelector(rbgset *workloadsv1alpha1.RoleBasedGroupSet) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			workloadsv1alpha1.SetRBGSetNameLabelKey: rbgset.Name,
+		},
+	}
+}
+
+// isRBGSpecifiedDelete returns true if the rbg is labeled to be deleted by the rbgset.
+func isRBGSpecifiedDelete(rbg *workloadsv1alpha1.RoleBasedGroup) bool {
+	_, ok := rbg.Labels[workloadsv1alpha1.RBGSetSpecifiedDeleteLabelKey]
+	return ok
+}
+
+// newRBGForSet creates a new RoleBasedGroup object based on the set's template and overrides.
+func newRBGForSet(rbgset *workloadsv1alpha1.RoleBasedGroupSet, index int) (*workloadsv1alpha1.RoleBasedGroup, error) {
 	rbg := &workloadsv1alpha1.RoleBasedGroup{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: rbgset.Namespace,
@@ -490,7 +773,133 @@ func newRBGForSet(rbgset *workloadsv1alpha1.RoleBasedGroupSet, index int) *workl
 		rbg.Annotations[workloadsv1alpha1.ExclusiveKeyAnnotationKey] = exclusiveKey
 	}
 
-	return rbg
+	if err := applyOverrideLabels(rbgset, rbg); err != nil {
+		return nil, err
+	}
+	roles, err := rolesForRBG(rbgset, rbg)
+	if err != nil {
+		return nil, err
+	}
+	rbg.Spec.Roles = roles
+
+	return rbg, nil
+}
+
+// applyOverrideLabels adds the labels of all matching overrides to the rbg, in order,
+// so that an override can select the rbgs labeled by an earlier one.
+func applyOverrideLabels(rbgset *workloadsv1alpha1.RoleBasedGroupSet, rbg *workloadsv1alpha1.RoleBasedGroup) error {
+	for i := range rbgset.Spec.Overrides {
+		override := &rbgset.Spec.Overrides[i]
+		if len(override.Labels) == 0 {
+			continue
+		}
+		matched, err := overrideMatches(override, rbg)
+		if err != nil {
+			return fmt.Errorf("invalid override %d: %w", i, err)
+		}
+		if !matched {
+			continue
+		}
+		if rbg.Labels == nil {
+			rbg.Labels = make(map[string]string)
+		}
+		for k, v := range override.Labels {
+			rbg.Labels[k] = v
+		}
+	}
+	return nil
+}
+
+// rolesForRBG returns the desired roles of a child RBG, i.e. the set's template roles
+// with all matching overrides applied in order.
+func rolesForRBG(
+	rbgset *workloadsv1alpha1.RoleBasedGroupSet, rbg *workloadsv1alpha1.RoleBasedGroup,
+) ([]workloadsv1alpha1.RoleSpec, error) {
+	if len(rbgset.Spec.Overrides) == 0 {
+		return rbgset.Spec.Template.Roles, nil
+	}
+
+	roles := make([]workloadsv1alpha1.RoleSpec, len(rbgset.Spec.Template.Roles))
+	for i := range rbgset.Spec.Template.Roles {
+		rbgset.Spec.Template.Roles[i].DeepCopyInto(&roles[i])
+	}
+
+	for i := range rbgset.Spec.Overrides {
+		override := &rbgset.Spec.Overrides[i]
+		matched, err := overrideMatches(override, rbg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid override %d: %w", i, err)
+		}
+		if !matched {
+			continue
+		}
+		for _, roleOverride := range override.Roles {
+			found := false
+			for j := range roles {
+				if roles[j].Name != roleOverride.Name {
+					continue
+				}
+				found = true
+				patched, err := patchRoleSpec(roles[j], roleOverride.Patch)
+				if err != nil {
+					return nil, fmt.Errorf("invalid override %d for role %s: %w", i, roleOverride.Name, err)
+				}
+				roles[j] = patched
+				break
+			}
+			if !found {
+				return nil, fmt.Errorf("invalid override %d: role %s not found in template", i, roleOverride.Name)
+			}
+		}
+	}
+	return roles, nil
+}
+
+// overrideMatches checks whether the override selects the rbg by index range and label selector.
+func overrideMatches(
+	override *workloadsv1alpha1.RoleBasedGroupSetOverride, rbg *workloadsv1alpha1.RoleBasedGroup,
+) (bool, error) {
+	if override.IndexRange != nil {
+		index, err := strconv.Atoi(rbg.Labels[workloadsv1alpha1.SetRBGIndexLabelKey])
+		if err != nil {
+			return false, nil
+		}
+		start := override.IndexRange.Start
+		end := ptr.Deref(override.IndexRange.End, start)
+		if index < int(start) || index > int(end) {
+			return false, nil
+		}
+	}
+	if override.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(override.Selector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(rbg.Labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// patchRoleSpec applies a strategic merge patch to the role.
+func patchRoleSpec(role workloadsv1alpha1.RoleSpec, patch runtime.RawExtension) (workloadsv1alpha1.RoleSpec, error) {
+	if patch.Raw == nil {
+		return role, nil
+	}
+	roleBytes, err := json.Marshal(role)
+	if err != nil {
+		return role, err
+	}
+	modified, err := strategicpatch.StrategicMergePatch(roleBytes, patch.Raw, &workloadsv1alpha1.RoleSpec{})
+	if err != nil {
+		return role, err
+	}
+	newRole := workloadsv1alpha1.RoleSpec{}
+	if err = json.Unmarshal(modified, &newRole); err != nil {
+		return role, err
+	}
+	return newRole, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -19,10 +19,14 @@ package workloads
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
@@ -31,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 // TestRoleBasedGroupSetReconciler_scaleUp tests the scaleUp function.
@@ -86,7 +91,9 @@ func TestRoleBasedGroupSetReconciler_scaleUp(t *testing.T) {
 				// We generate this list based on the test case count.
 				var rbgsToCreate []*workloadsv1alpha1.RoleBasedGroup
 				for i := 0; i < tt.count; i++ {
-					rbgsToCreate = append(rbgsToCreate, newRBGForSet(rbgset, i))
+					rbg, err := newRBGForSet(rbgset, i)
+					assert.NoError(t, err)
+					rbgsToCreate = append(rbgsToCreate, rbg)
 				}
 
 				err := r.scaleUp(context.Background(), rbgset, rbgsToCreate)
@@ -110,6 +117,214 @@ func TestRoleBasedGroupSetReconciler_scaleUp(t *testing.T) {
 	}
 }
 
+// TestRoleBasedGroupSetReconciler_scaleUp_SlowStart tests that scaleUp stops creating RBGs after a failed batch.
+func TestRoleBasedGroupSetReconciler_scaleUp_SlowStart(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	rbgset := &workloadsv1alpha1.RoleBasedGroupSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rbgset",
+			Namespace: "default",
+			UID:       "test-uid",
+		},
+		Spec: workloadsv1alpha1.RoleBasedGroupSetSpec{
+			Template: workloadsv1alpha1.RoleBasedGroupSpec{
+				Roles: []workloadsv1alpha1.RoleSpec{{Name: "role-1"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		count            int
+		failAfter        int32
+		expectError      bool
+		expectCreateCall int32
+		expectCreated    int
+	}{
+		{
+			name:             "All batches succeed",
+			count:            7,
+			failAfter:        -1,
+			expectCreateCall: 7,
+			expectCreated:    7,
+		},
+		{
+			name:             "First batch fails",
+			count:            7,
+			failAfter:        0,
+			expectError:      true,
+			expectCreateCall: 1,
+			expectCreated:    0,
+		},
+		{
+			name:        "Third batch fails",
+			count:       10,
+			failAfter:   3,
+			expectError: true,
+			// batches: 1, 2, 4 -> the third batch is attempted entirely, the fourth is skipped
+			expectCreateCall: 7,
+			expectCreated:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var createCalls atomic.Int32
+				r := &RoleBasedGroupSetReconciler{
+					client: fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(
+						interceptor.Funcs{
+							Create: func(
+								ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption,
+							) error {
+								call := createCalls.Add(1)
+								if tt.failAfter >= 0 && call > tt.failAfter {
+									return fmt.Errorf("mock create failure")
+								}
+								return c.Create(ctx, obj, opts...)
+							},
+						},
+					).Build(),
+					scheme: scheme,
+				}
+
+				var rbgsToCreate []*workloadsv1alpha1.RoleBasedGroup
+				for i := 0; i < tt.count; i++ {
+					rbg, err := newRBGForSet(rbgset, i)
+					assert.NoError(t, err)
+					rbgsToCreate = append(rbgsToCreate, rbg)
+				}
+
+				err := r.scaleUp(context.Background(), rbgset, rbgsToCreate)
+				if tt.expectError {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, tt.expectCreateCall, createCalls.Load())
+
+				var rbglist workloadsv1alpha1.RoleBasedGroupList
+				err = r.client.List(
+					context.Background(), &rbglist, client.InNamespace(rbgset.Namespace),
+					client.MatchingLabels{workloadsv1alpha1.SetRBGSetNameLabelKey: rbgset.Name},
+				)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectCreated, len(rbglist.Items))
+			},
+		)
+	}
+}
+
+// TestRoleBasedGroupSetReconciler_limitScaleUp tests that scale-up is gated by scaleStrategy.maxUnavailable.
+func TestRoleBasedGroupSetReconciler_limitScaleUp(t *testing.T) {
+	newRBG := func(index int, ready bool) *workloadsv1alpha1.RoleBasedGroup {
+		status := metav1.ConditionFalse
+		if ready {
+			status = metav1.ConditionTrue
+		}
+		return &workloadsv1alpha1.RoleBasedGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("test-rbgset-%d", index)},
+			Status: workloadsv1alpha1.RoleBasedGroupStatus{
+				Conditions: []metav1.Condition{
+					{Type: string(workloadsv1alpha1.RoleBasedGroupReady), Status: status},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		maxUnavailable *intstr.IntOrString
+		replicas       int32
+		existing       map[int]*workloadsv1alpha1.RoleBasedGroup
+		toDelete       map[string]bool
+		toCreate       int
+		expectAllowed  int
+	}{
+		{
+			name:          "No scale strategy creates everything",
+			replicas:      10,
+			existing:      map[int]*workloadsv1alpha1.RoleBasedGroup{0: newRBG(0, false)},
+			toCreate:      9,
+			expectAllowed: 9,
+		},
+		{
+			name:           "Absolute maxUnavailable with all existing ready",
+			maxUnavailable: ptr.To(intstr.FromInt32(2)),
+			replicas:       10,
+			existing:       map[int]*workloadsv1alpha1.RoleBasedGroup{0: newRBG(0, true), 1: newRBG(1, true)},
+			toCreate:       8,
+			expectAllowed:  2,
+		},
+		{
+			name:           "Unready RBGs consume the budget",
+			maxUnavailable: ptr.To(intstr.FromInt32(2)),
+			replicas:       10,
+			existing:       map[int]*workloadsv1alpha1.RoleBasedGroup{0: newRBG(0, false), 1: newRBG(1, false)},
+			toCreate:       8,
+			expectAllowed:  0,
+		},
+		{
+			name:           "RBGs being deleted are ignored",
+			maxUnavailable: ptr.To(intstr.FromInt32(1)),
+			replicas:       2,
+			existing:       map[int]*workloadsv1alpha1.RoleBasedGroup{0: newRBG(0, true), 5: newRBG(5, false)},
+			toDelete:       map[string]bool{"test-rbgset-5": true},
+			toCreate:       1,
+			expectAllowed:  1,
+		},
+		{
+			name:           "Percentage is rounded up",
+			maxUnavailable: ptr.To(intstr.FromString("25%")),
+			replicas:       10,
+			existing:       map[int]*workloadsv1alpha1.RoleBasedGroup{},
+			toCreate:       10,
+			expectAllowed:  3,
+		},
+		{
+			name:           "Zero is treated as one",
+			maxUnavailable: ptr.To(intstr.FromInt32(0)),
+			replicas:       10,
+			existing:       map[int]*workloadsv1alpha1.RoleBasedGroup{},
+			toCreate:       10,
+			expectAllowed:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rbgset := &workloadsv1alpha1.RoleBasedGroupSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-rbgset", Namespace: "default"},
+					Spec:       workloadsv1alpha1.RoleBasedGroupSetSpec{Replicas: ptr.To(tt.replicas)},
+				}
+				if tt.maxUnavailable != nil {
+					rbgset.Spec.ScaleStrategy = &workloadsv1alpha1.RoleBasedGroupSetScaleStrategy{
+						MaxUnavailable: tt.maxUnavailable,
+					}
+				}
+				var rbgsToCreate []*workloadsv1alpha1.RoleBasedGroup
+				for i := 0; i < tt.toCreate; i++ {
+					rbg, err := newRBGForSet(rbgset, int(tt.replicas)-tt.toCreate+i)
+					assert.NoError(t, err)
+					rbgsToCreate = append(rbgsToCreate, rbg)
+				}
+
+				r := &RoleBasedGroupSetReconciler{}
+				allowed, err := r.limitScaleUp(rbgset, tt.existing, tt.toDelete, rbgsToCreate)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectAllowed, len(allowed))
+				// The lowest indices are always created first.
+				for i := range allowed {
+					assert.Equal(t, rbgsToCreate[i].Name, allowed[i].Name)
+				}
+			},
+		)
+	}
+}
+
 // TestRoleBasedGroupSetReconciler_scaleDown tests the scaleDown function.
 func TestRoleBasedGroupSetReconciler_scaleDown(t *testing.T) {
 	// Setup test scheme
@@ -230,6 +445,160 @@ func TestRoleBasedGroupSetReconciler_scaleDown(t *testing.T) {
 	}
 }
 
+// TestRoleBasedGroupSetReconciler_calculateScale tests victim selection and index allocation.
+func TestRoleBasedGroupSetReconciler_calculateScale(t *testing.T) {
+	template := workloadsv1alpha1.RoleBasedGroupSpec{
+		Roles: []workloadsv1alpha1.RoleSpec{{Name: "role-1"}},
+	}
+	oldTemplate := workloadsv1alpha1.RoleBasedGroupSpec{
+		Roles: []workloadsv1alpha1.RoleSpec{{Name: "old-role"}},
+	}
+	type rbgState struct {
+		ready       bool
+		outdated    bool
+		terminating bool
+		specified   bool
+	}
+	newRBG := func(index int, state rbgState) *workloadsv1alpha1.RoleBasedGroup {
+		rbg := &workloadsv1alpha1.RoleBasedGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("test-rbgset-%d", index),
+				Labels: map[string]string{workloadsv1alpha1.SetRBGIndexLabelKey: fmt.Sprintf("%d", index)},
+			},
+			Spec: template,
+		}
+		if state.outdated {
+			rbg.Spec = oldTemplate
+		}
+		if state.ready {
+			rbg.Status.Conditions = []metav1.Condition{
+				{Type: string(workloadsv1alpha1.RoleBasedGroupReady), Status: metav1.ConditionTrue},
+			}
+		}
+		if state.terminating {
+			rbg.DeletionTimestamp = ptr.To(metav1.Now())
+		}
+		if state.specified {
+			rbg.Labels[workloadsv1alpha1.RBGSetSpecifiedDeleteLabelKey] = "true"
+		}
+		return rbg
+	}
+
+	tests := []struct {
+		name          string
+		scaleStrategy *workloadsv1alpha1.RoleBasedGroupSetScaleStrategy
+		existing      map[int]rbgState
+		replicas      int
+		expectDeleted []string
+		expectCreated []string
+	}{
+		{
+			name:          "Default policy deletes highest index",
+			existing:      map[int]rbgState{0: {ready: true}, 1: {}, 2: {ready: true}, 3: {ready: true}},
+			replicas:      2,
+			expectDeleted: []string{"test-rbgset-3", "test-rbgset-2"},
+		},
+		{
+			name: "UnreadyFirst deletes unready RBGs first",
+			scaleStrategy: &workloadsv1alpha1.RoleBasedGroupSetScaleStrategy{
+				ScaleDownPolicy: workloadsv1alpha1.UnreadyFirstScaleDownPolicy,
+			},
+			existing:      map[int]rbgState{0: {ready: true}, 1: {}, 2: {ready: true}, 3: {ready: true}},
+			replicas:      2,
+			expectDeleted: []string{"test-rbgset-1", "test-rbgset-3"},
+		},
+		{
+			name: "OldRevisionFirst deletes outdated RBGs first",
+			scaleStrategy: &workloadsv1alpha1.RoleBasedGroupSetScaleStrategy{
+				ScaleDownPolicy: workloadsv1alpha1.OldRevisionFirstScaleDownPolicy,
+			},
+			existing:      map[int]rbgState{0: {outdated: true}, 1: {}, 2: {}},
+			replicas:      1,
+			expectDeleted: []string{"test-rbgset-0", "test-rbgset-2"},
+		},
+		{
+			name: "RBGsToDelete takes precedence over the policy",
+			scaleStrategy: &workloadsv1alpha1.RoleBasedGroupSetScaleStrategy{
+				ScaleDownPolicy: workloadsv1alpha1.UnreadyFirstScaleDownPolicy,
+				RBGsToDelete:    []string{"test-rbgset-0", "not-exist"},
+			},
+			existing:      map[int]rbgState{0: {ready: true}, 1: {}, 2: {ready: true}},
+			replicas:      1,
+			expectDeleted: []string{"test-rbgset-0", "test-rbgset-1"},
+		},
+		{
+			name:          "RBGsToDelete is ignored without scale down",
+			scaleStrategy: &workloadsv1alpha1.RoleBasedGroupSetScaleStrategy{RBGsToDelete: []string{"test-rbgset-0"}},
+			existing:      map[int]rbgState{0: {}, 1: {}},
+			replicas:      2,
+		},
+		{
+			name:          "Scale up fills gaps with the lowest free index",
+			existing:      map[int]rbgState{0: {}, 2: {}},
+			replicas:      4,
+			expectCreated: []string{"test-rbgset-1", "test-rbgset-3"},
+		},
+		{
+			name:     "Gaps are kept when replicas match",
+			existing: map[int]rbgState{0: {}, 2: {}},
+			replicas: 2,
+		},
+		{
+			name:          "Specified delete label replaces the RBG",
+			existing:      map[int]rbgState{0: {}, 1: {specified: true}},
+			replicas:      2,
+			expectDeleted: []string{"test-rbgset-1"},
+			expectCreated: []string{"test-rbgset-2"},
+		},
+		{
+			name:          "Terminating RBGs are not counted and their index is not reused",
+			existing:      map[int]rbgState{0: {terminating: true}, 1: {}},
+			replicas:      2,
+			expectCreated: []string{"test-rbgset-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rbgset := &workloadsv1alpha1.RoleBasedGroupSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-rbgset", Namespace: "default"},
+					Spec: workloadsv1alpha1.RoleBasedGroupSetSpec{
+						Replicas:      ptr.To(int32(tt.replicas)),
+						Template:      template,
+						ScaleStrategy: tt.scaleStrategy,
+					},
+				}
+				existing := make(map[int]*workloadsv1alpha1.RoleBasedGroup)
+				for index, state := range tt.existing {
+					existing[index] = newRBG(index, state)
+				}
+
+				r := &RoleBasedGroupSetReconciler{}
+				deleted, created, err := r.calculateScale(rbgset, existing, tt.replicas)
+				assert.NoError(t, err)
+
+				deletedNames := []string{}
+				for _, rbg := range deleted {
+					deletedNames = append(deletedNames, rbg.Name)
+				}
+				createdNames := []string{}
+				for _, rbg := range created {
+					createdNames = append(createdNames, rbg.Name)
+				}
+				if tt.expectDeleted == nil {
+					tt.expectDeleted = []string{}
+				}
+				if tt.expectCreated == nil {
+					tt.expectCreated = []string{}
+				}
+				assert.Equal(t, tt.expectDeleted, deletedNames)
+				assert.Equal(t, tt.expectCreated, createdNames)
+			},
+		)
+	}
+}
+
 // TestRoleBasedGroupSetReconciler_needsUpdate tests the needsUpdate method.
 func TestRoleBasedGroupSetReconciler_needsUpdate(t *testing.T) {
 	scheme := runtime.NewScheme()
@@ -312,13 +681,212 @@ func TestRoleBasedGroupSetReconciler_needsUpdate(t *testing.T) {
 		t.Run(
 			tt.name, func(t *testing.T) {
 				r := &RoleBasedGroupSetReconciler{}
-				result := r.needsUpdate(tt.rbgset, tt.rbg)
+				result, err := r.needsUpdate(tt.rbgset, tt.rbg)
+				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedUpdate, result)
 			},
 		)
 	}
 }
 
+// TestRolesForRBG tests that overrides are applied to the matching children only.
+func TestRolesForRBG(t *testing.T) {
+	zonePatch := func(zone string) runtime.RawExtension {
+		return runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(
+				`{"template":{"spec":{"nodeSelector":{"topology.kubernetes.io/zone":"%s"}}}}`, zone,
+			)),
+		}
+	}
+	template := workloadsv1alpha1.RoleBasedGroupSpec{
+		Roles: []workloadsv1alpha1.RoleSpec{
+			{
+				Name:     "prefill",
+				Replicas: ptr.To(int32(1)),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "prefill", Image: "prefill:v1"}},
+					},
+				},
+			},
+			{
+				Name:     "decode",
+				Replicas: ptr.To(int32(2)),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "decode", Image: "decode:v1"}},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		overrides     []workloadsv1alpha1.RoleBasedGroupSetOverride
+		index         int
+		rbgLabels     map[string]string
+		expectErr     bool
+		expectZones   map[string]string
+		expectImages  map[string]string
+		expectReplica map[string]int32
+	}{
+		{
+			name:         "No overrides",
+			index:        0,
+			expectZones:  map[string]string{"prefill": "", "decode": ""},
+			expectImages: map[string]string{"prefill": "prefill:v1", "decode": "decode:v1"},
+		},
+		{
+			name: "Index range matches",
+			overrides: []workloadsv1alpha1.RoleBasedGroupSetOverride{
+				{
+					IndexRange: &workloadsv1alpha1.RoleBasedGroupSetIndexRange{Start: 0},
+					Roles: []workloadsv1alpha1.RoleOverride{
+						{Name: "prefill", Patch: zonePatch("zone-a")},
+						{Name: "decode", Patch: zonePatch("zone-a")},
+					},
+				},
+				{
+					IndexRange: &workloadsv1alpha1.RoleBasedGroupSetIndexRange{Start: 1, End: ptr.To(int32(3))},
+					Roles: []workloadsv1alpha1.RoleOverride{
+						{Name: "prefill", Patch: zonePatch("zone-b")},
+					},
+				},
+			},
+			index:        2,
+			expectZones:  map[string]string{"prefill": "zone-b", "decode": ""},
+			expectImages: map[string]string{"prefill": "prefill:v1", "decode": "decode:v1"},
+		},
+		{
+			name: "Later overrides win and containers are merged by name",
+			overrides: []workloadsv1alpha1.RoleBasedGroupSetOverride{
+				{
+					Roles: []workloadsv1alpha1.RoleOverride{{Name: "decode", Patch: zonePatch("zone-a")}},
+				},
+				{
+					IndexRange: &workloadsv1alpha1.RoleBasedGroupSetIndexRange{Start: 1},
+					Roles: []workloadsv1alpha1.RoleOverride{
+						{Name: "decode", Patch: zonePatch("zone-b")},
+						{
+							Name: "decode",
+							Patch: runtime.RawExtension{
+								Raw: []byte(`{"replicas":4,"template":{"spec":{"containers":[{"name":"decode","image":"decode:h20"}]}}}`),
+							},
+						},
+					},
+				},
+			},
+			index:         1,
+			expectZones:   map[string]string{"prefill": "", "decode": "zone-b"},
+			expectImages:  map[string]string{"prefill": "prefill:v1", "decode": "decode:h20"},
+			expectReplica: map[string]int32{"decode": 4},
+		},
+		{
+			name: "Label selector matches",
+			overrides: []workloadsv1alpha1.RoleBasedGroupSetOverride{
+				{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"gpu": "h20"}},
+					Roles:    []workloadsv1alpha1.RoleOverride{{Name: "decode", Patch: zonePatch("zone-c")}},
+				},
+			},
+			index:        5,
+			rbgLabels:    map[string]string{"gpu": "h20"},
+			expectZones:  map[string]string{"prefill": "", "decode": "zone-c"},
+			expectImages: map[string]string{"prefill": "prefill:v1", "decode": "decode:v1"},
+		},
+		{
+			name: "Label selector matches labels added by an earlier override",
+			overrides: []workloadsv1alpha1.RoleBasedGroupSetOverride{
+				{
+					IndexRange: &workloadsv1alpha1.RoleBasedGroupSetIndexRange{Start: 2, End: ptr.To(int32(3))},
+					Labels:     map[string]string{"gpu": "h20"},
+				},
+				{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"gpu": "h20"}},
+					Roles:    []workloadsv1alpha1.RoleOverride{{Name: "decode", Patch: zonePatch("zone-c")}},
+				},
+			},
+			index:        3,
+			expectZones:  map[string]string{"prefill": "", "decode": "zone-c"},
+			expectImages: map[string]string{"prefill": "prefill:v1", "decode": "decode:v1"},
+		},
+		{
+			name: "Label selector does not match",
+			overrides: []workloadsv1alpha1.RoleBasedGroupSetOverride{
+				{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"gpu": "h20"}},
+					Roles:    []workloadsv1alpha1.RoleOverride{{Name: "decode", Patch: zonePatch("zone-c")}},
+				},
+			},
+			index:        5,
+			expectZones:  map[string]string{"prefill": "", "decode": ""},
+			expectImages: map[string]string{"prefill": "prefill:v1", "decode": "decode:v1"},
+		},
+		{
+			name: "Unknown role",
+			overrides: []workloadsv1alpha1.RoleBasedGroupSetOverride{
+				{Roles: []workloadsv1alpha1.RoleOverride{{Name: "router", Patch: zonePatch("zone-a")}}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Invalid patch",
+			overrides: []workloadsv1alpha1.RoleBasedGroupSetOverride{
+				{
+					Roles: []workloadsv1alpha1.RoleOverride{
+						{Name: "decode", Patch: runtime.RawExtension{Raw: []byte(`{"replicas":"two"}`)}},
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rbgset := &workloadsv1alpha1.RoleBasedGroupSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-rbgset", Namespace: "default"},
+					Spec: workloadsv1alpha1.RoleBasedGroupSetSpec{
+						Replicas:  ptr.To(int32(1)),
+						Template:  *template.DeepCopy(),
+						Overrides: tt.overrides,
+					},
+				}
+				rbg, err := newRBGForSet(rbgset, tt.index)
+				if tt.expectErr {
+					assert.Error(t, err)
+					return
+				}
+				assert.NoError(t, err)
+
+				for k, v := range tt.rbgLabels {
+					rbg.Labels[k] = v
+				}
+				roles, err := rolesForRBG(rbgset, rbg)
+				assert.NoError(t, err)
+				for _, role := range roles {
+					assert.Equal(t, tt.expectZones[role.Name], role.Template.Spec.NodeSelector["topology.kubernetes.io/zone"])
+					assert.Equal(t, tt.expectImages[role.Name], role.Template.Spec.Containers[0].Image)
+					if replicas, ok := tt.expectReplica[role.Name]; ok {
+						assert.Equal(t, replicas, *role.Replicas)
+					}
+				}
+
+				// The template of the set must never be modified by overrides.
+				assert.Equal(t, template, rbgset.Spec.Template)
+
+				// A child with overrides applied does not need an update.
+				rbg.Spec.Roles = roles
+				update, err := (&RoleBasedGroupSetReconciler{}).needsUpdate(rbgset, rbg)
+				assert.NoError(t, err)
+				assert.False(t, update)
+			},
+		)
+	}
+}
+
 // TestRoleBasedGroupSetReconciler_needsAnnotationUpdate tests the needsAnnotationUpdate method.
 func TestRoleBasedGroupSetReconciler_needsAnnotationUpdate(t *testing.T) {
 	tests := []struct {
@@ -483,6 +1051,90 @@ func TestRoleBasedGroupSetReconciler_Reconcile_OptimizedOrder(t *testing.T) {
 	}
 }
 
+// TestRoleBasedGroupSetReconciler_Reconcile_InvalidOverride tests that an invalid override only blocks the RBGs it
+// selects and is reported in the status of the set.
+func TestRoleBasedGroupSetReconciler_Reconcile_InvalidOverride(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	rbgset := &workloadsv1alpha1.RoleBasedGroupSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rbgset", Namespace: "default"},
+		Spec: workloadsv1alpha1.RoleBasedGroupSetSpec{
+			Replicas: ptr.To(int32(3)),
+			Template: workloadsv1alpha1.RoleBasedGroupSpec{
+				Roles: []workloadsv1alpha1.RoleSpec{{Name: "new-role"}},
+			},
+			Overrides: []workloadsv1alpha1.RoleBasedGroupSetOverride{
+				{
+					IndexRange: &workloadsv1alpha1.RoleBasedGroupSetIndexRange{Start: 1},
+					Roles:      []workloadsv1alpha1.RoleOverride{{Name: "router"}},
+				},
+			},
+		},
+	}
+	// The RBG at index 2 is outdated and unaffected by the override.
+	outdated := &workloadsv1alpha1.RoleBasedGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rbgset-2",
+			Namespace: "default",
+			Labels: map[string]string{
+				workloadsv1alpha1.SetRBGSetNameLabelKey: "test-rbgset",
+				workloadsv1alpha1.SetRBGIndexLabelKey:   "2",
+			},
+		},
+		Spec: workloadsv1alpha1.RoleBasedGroupSpec{
+			Roles: []workloadsv1alpha1.RoleSpec{{Name: "old-role"}},
+		},
+	}
+
+	r := &RoleBasedGroupSetReconciler{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(rbgset, outdated).
+			WithStatusSubresource(&workloadsv1alpha1.RoleBasedGroupSet{}).Build(),
+		scheme: scheme,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-rbgset"}}
+
+	listRBGs := func() map[string]string {
+		var rbgList workloadsv1alpha1.RoleBasedGroupList
+		assert.NoError(t, r.client.List(context.Background(), &rbgList, client.InNamespace("default")))
+		roles := map[string]string{}
+		for _, rbg := range rbgList.Items {
+			roles[rbg.Name] = rbg.Spec.Roles[0].Name
+		}
+		return roles
+	}
+	overridesApplied := func() *metav1.Condition {
+		latest := &workloadsv1alpha1.RoleBasedGroupSet{}
+		assert.NoError(t, r.client.Get(context.Background(), req.NamespacedName, latest))
+		return meta.FindStatusCondition(
+			latest.Status.Conditions, string(workloadsv1alpha1.RoleBasedGroupSetOverridesApplied),
+		)
+	}
+
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"test-rbgset-0": "new-role", "test-rbgset-2": "new-role"}, listRBGs())
+	condition := overridesApplied()
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "invalid override 0: role router not found in template", condition.Message)
+	}
+
+	// Once the override is fixed, the missing RBG is created.
+	latest := &workloadsv1alpha1.RoleBasedGroupSet{}
+	assert.NoError(t, r.client.Get(context.Background(), req.NamespacedName, latest))
+	latest.Spec.Overrides[0].Roles[0].Name = "new-role"
+	assert.NoError(t, r.client.Update(context.Background(), latest))
+
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Len(t, listRBGs(), 3)
+	condition = overridesApplied()
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	}
+}
+
 // TestRoleBasedGroupSetReconciler_Reconcile_StatusUpdate tests the status update logic within the Reconcile loop.
 func TestRoleBasedGroupSetReconciler_Reconcile_StatusUpdate(t *testing.T) {
 	// Setup test scheme
@@ -689,6 +1341,10 @@ func TestRoleBasedGroupSetReconciler_Reconcile_StatusUpdate(t *testing.T) {
 				// Verify status fields
 				assert.Equal(t, tt.expectReplicas, updatedRBGSet.Status.Replicas)
 				assert.Equal(t, tt.expectReadyReplicas, updatedRBGSet.Status.ReadyReplicas)
+				assert.Equal(
+					t, workloadsv1alpha1.SetRBGSetNameLabelKey+"="+tt.initialRBGSet.Name,
+					updatedRBGSet.Status.LabelSelector,
+				)
 
 				// Verify condition
 				assert.NotEmpty(t, updatedRBGSet.Status.Conditions, "Status conditions should not be empty")
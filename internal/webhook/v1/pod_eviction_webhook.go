@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/reconciler"
+)
+
+// podEvictionWebhookPath is the path of the webhook validating the evictions of pods.
+const podEvictionWebhookPath = "/validate--v1-pod-eviction"
+
+// admittedEvictionTTL is how long an instance whose eviction was admitted counts as disrupted while its pods still
+// look healthy, until the cache observes the eviction.
+const admittedEvictionTTL = 30 * time.Second
+
+// SetupPodEvictionWebhookWithManager registers the webhook for the evictions of Pods in the manager.
+func SetupPodEvictionWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(podEvictionWebhookPath, &webhook.Admission{
+		Handler: NewPodEvictionValidator(mgr.GetClient()),
+	})
+	return nil
+}
+
+// The webhook receives the evictions of all pods, since the objectSelector of a webhook doesn't apply to the pods of
+// the evictions. It ignores failures, so that the drains of the cluster don't depend on the controller; the
+// PodDisruptionBudgets of the roles still bound the evicted pods.
+// +kubebuilder:webhook:path=/validate--v1-pod-eviction,mutating=false,failurePolicy=ignore,sideEffects=NoneOnDryRun,groups="",resources=pods/eviction,verbs=create,versions=v1,name=vpodeviction-v1.rolebasedgroup.workloads.x-k8s.io,admissionReviewVersions=v1,timeoutSeconds=5
+
+// PodEvictionValidator enforces the disruption budgets of LeaderWorkerSet roles in instances: the eviction of a pod
+// of a healthy instance is denied once the budget of the role is spent, while the pods of an instance already
+// disrupted may always be evicted, so that a drain never breaks more instances than the budget allows.
+type PodEvictionValidator struct {
+	client client.Client
+
+	// mu serializes the evictions, so that the evictions of concurrent drains don't spend the same budget.
+	mu sync.Mutex
+	// admitted holds the instances whose evictions were admitted, by role, with the time of their admission.
+	admitted map[types.NamespacedName]map[string]time.Time
+}
+
+var _ admission.Handler = &PodEvictionValidator{}
+
+func NewPodEvictionValidator(client client.Client) *PodEvictionValidator {
+	return &PodEvictionValidator{client: client, admitted: map[types.NamespacedName]map[string]time.Time{}}
+}
+
+func (v *PodEvictionValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.SubResource != "eviction" {
+		return admission.Allowed("")
+	}
+	pod := &corev1.Pod{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.Name}, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	groupIndex, found := pod.Labels[lwsv1.GroupIndexLabelKey]
+	if !found || pod.Labels[workloadsv1alpha1.SetNameLabelKey] == "" {
+		return admission.Allowed("")
+	}
+	rbg := &workloadsv1alpha1.RoleBasedGroup{}
+	if err := v.client.Get(
+		ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[workloadsv1alpha1.SetNameLabelKey]}, rbg,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	role, err := rbg.GetRole(pod.Labels[workloadsv1alpha1.SetRoleLabelKey])
+	if err != nil || role.DisruptionBudget == nil ||
+		role.Workload.String() != workloadsv1alpha1.LeaderWorkerSetWorkloadType {
+		return admission.Allowed("")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	healthy, disruptionsAllowed, err := reconciler.RoleInstanceDisruptions(ctx, v.client, rbg, role)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !healthy.Has(groupIndex) {
+		return admission.Allowed("the instance is already disrupted")
+	}
+
+	now := time.Now()
+	v.expireAdmitted(now)
+	key := types.NamespacedName{Namespace: rbg.Namespace, Name: rbg.GetWorkloadName(role)}
+	admitted := v.admitted[key]
+	for index := range admitted {
+		// the instances observed as disrupted are already counted
+		if !healthy.Has(index) {
+			delete(admitted, index)
+		}
+	}
+	if _, found := admitted[groupIndex]; !found && disruptionsAllowed-len(admitted) <= 0 {
+		log.FromContext(ctx).V(1).Info("Deny eviction by the disruption budget of the role",
+			"pod", klog.KObj(pod), "role", role.Name, "instance", groupIndex)
+		// like the PodDisruptionBudgets, so that the drains retry the eviction
+		return admission.Response{AdmissionResponse: admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Code:   http.StatusTooManyRequests,
+				Reason: metav1.StatusReasonTooManyRequests,
+				Message: fmt.Sprintf(
+					"Cannot evict pod as it would violate the disruption budget of role %s of rbg %s, "+
+						"which allows disrupting no more instances", role.Name, rbg.Name,
+				),
+			},
+		}}
+	}
+	if req.DryRun == nil || !*req.DryRun {
+		if admitted == nil {
+			admitted = map[string]time.Time{}
+			v.admitted[key] = admitted
+		}
+		if _, found := admitted[groupIndex]; !found {
+			admitted[groupIndex] = now
+		}
+	}
+	return admission.Allowed("")
+}
+
+// expireAdmitted forgets the admitted evictions older than admittedEvictionTTL.
+func (v *PodEvictionValidator) expireAdmitted(now time.Time) {
+	for key, admitted := range v.admitted {
+		for index, admittedAt := range admitted {
+			if now.Sub(admittedAt) > admittedEvictionTTL {
+				delete(admitted, index)
+			}
+		}
+		if len(admitted) == 0 {
+			delete(v.admitted, key)
+		}
+	}
+}
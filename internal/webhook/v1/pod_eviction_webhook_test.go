@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestPodEvictionValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	decode := wrappers.BuildLwsRole("decode").WithReplicas(3).Obj()
+	decode.LeaderWorkerSet.Size = ptr.To[int32](2)
+	decode.DisruptionBudget = &workloadsv1alpha1.RoleDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{decode}).Obj()
+
+	pod := func(name, groupIndex string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+					workloadsv1alpha1.SetRoleLabelKey: "decode",
+					lwsv1.GroupIndexLabelKey:          groupIndex,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		rbg, other,
+		pod("test-rbg-decode-0", "0"), pod("test-rbg-decode-0-1", "0"),
+		pod("test-rbg-decode-1", "1"), pod("test-rbg-decode-1-1", "1"),
+		pod("test-rbg-decode-2", "2"), pod("test-rbg-decode-2-1", "2"),
+	).Build()
+	validator := NewPodEvictionValidator(c)
+	ctx := context.TODO()
+
+	evict := func(name string, dryRun bool) admission.Response {
+		return validator.Handle(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Name:        name,
+			Namespace:   "default",
+			SubResource: "eviction",
+			DryRun:      ptr.To(dryRun),
+		}})
+	}
+	expectDenied := func(resp admission.Response) {
+		t.Helper()
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, int32(http.StatusTooManyRequests), resp.Result.Code)
+	}
+
+	// the pods of other workloads are not checked
+	assert.True(t, evict("other", false).Allowed)
+
+	// a dry run doesn't spend the budget
+	assert.True(t, evict("test-rbg-decode-1", true).Allowed)
+
+	// the budget allows disrupting a single group, the rest of the group may be evicted as well
+	assert.True(t, evict("test-rbg-decode-0", false).Allowed)
+	expectDenied(evict("test-rbg-decode-1", false))
+	assert.True(t, evict("test-rbg-decode-0-1", false).Allowed)
+
+	// once the disrupted group is observed, its pods may still be evicted, but not those of the other groups
+	evicted := &corev1.Pod{}
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Name: "test-rbg-decode-0", Namespace: "default"}, evicted))
+	evicted.Status.Conditions = append(evicted.Status.Conditions,
+		corev1.PodCondition{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue})
+	assert.NoError(t, c.Status().Update(ctx, evicted))
+	assert.True(t, evict("test-rbg-decode-0-1", false).Allowed)
+	expectDenied(evict("test-rbg-decode-2-1", false))
+}
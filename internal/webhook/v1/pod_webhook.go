@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/rbgs/pkg/reconciler"
+)
+
+// SetupPodWebhookWithManager registers the webhook for Pods in the manager.
+func SetupPodWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1.Pod{}).WithDefaulter(&PodCustomDefaulter{}).Complete()
+}
+
+// The webhook only receives the pods selected by the objectSelector of the MutatingWebhookConfiguration,
+// i.e. the pods labeled with rolebasedgroup.workloads.x-k8s.io/role-instance-resource-claims.
+// +kubebuilder:webhook:path=/mutate--v1-pod,mutating=true,failurePolicy=fail,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=mpod-v1.rolebasedgroup.workloads.x-k8s.io,admissionReviewVersions=v1,reinvocationPolicy=IfNeeded
+
+// PodCustomDefaulter sets the ResourceClaims of the LeaderWorkerSet group of the pods of roles declaring
+// RoleInstance resource claims.
+type PodCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &PodCustomDefaulter{}
+
+func (d *PodCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return fmt.Errorf("expected a Pod object but got %T", obj)
+	}
+	return reconciler.SetRoleInstanceResourceClaims(pod)
+}
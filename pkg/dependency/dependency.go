@@ -2,15 +2,16 @@ package dependency
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
 	"sigs.k8s.io/rbgs/pkg/reconciler"
-	"sigs.k8s.io/rbgs/pkg/utils"
 )
 
 type DefaultDependencyManager struct {
@@ -33,24 +34,28 @@ func (m *DefaultDependencyManager) SortRoles(
 		return nil, nil
 	}
 
-	roleNameList := make([]string, 0)
-	for _, role := range rbg.Spec.Roles {
-		roleNameList = append(roleNameList, role.Name)
-	}
 	roleDependency := make(map[string][]string)
 
 	for _, role := range rbg.Spec.Roles {
-		if len(role.Dependencies) > 0 {
-			for _, d := range role.Dependencies {
-				if !utils.ContainsString(roleNameList, d) {
-					return nil, fmt.Errorf("role [%s] with dependency role [%s] not found in rbg", role.Name, d)
+		for _, dep := range role.GetDependencies() {
+			if err := validateDependency(&dep); err != nil {
+				return nil, fmt.Errorf("role [%s] with dependency role [%s] is invalid: %v", role.Name, dep.Role, err)
+			}
+			if dep.IsExternal() {
+				if err := validateExternalDependency(rbg, &dep); err != nil {
+					return nil, fmt.Errorf("role [%s] with dependency role [%s] is invalid: %v", role.Name, dep.Role, err)
 				}
+				continue
+			}
+			depRole, err := rbg.GetRole(dep.Role)
+			if err != nil {
+				return nil, fmt.Errorf("role [%s] with dependency role [%s] not found in rbg", role.Name, dep.Role)
+			}
+			if err := validateReadinessChecks(depRole, dep.Readiness); err != nil {
+				return nil, fmt.Errorf("role [%s] with dependency role [%s] is invalid: %v", role.Name, dep.Role, err)
 			}
-
-			roleDependency[role.Name] = role.Dependencies
-		} else {
-			roleDependency[role.Name] = []string{}
 		}
+		roleDependency[role.Name] = role.GetDependencyNames()
 	}
 
 	roleOrder, err := dependencyOrder(ctx, roleDependency)
@@ -78,26 +83,68 @@ func (m *DefaultDependencyManager) SortRoles(
 func (m *DefaultDependencyManager) CheckDependencyReady(
 	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec,
 ) (bool, error) {
+	blocking, err := m.BlockingDependencies(ctx, rbg, role)
+	if err != nil {
+		return false, err
+	}
+	return len(blocking) == 0, nil
+}
 
-	for _, dep := range role.Dependencies {
-		depRole, err := rbg.GetRole(dep)
-		if err != nil {
-			return false, err
+// BlockingDependencies returns the names of the dependencies of the role which are not ready yet, see dependencyName.
+// A dependency whose workload is not created yet is not ready, a missing role of another rbg is reported as not found.
+func (m *DefaultDependencyManager) BlockingDependencies(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec,
+) ([]string, error) {
+	dependencies := role.GetDependencies()
+	for i := range dependencies {
+		if dependencies[i].IsExternal() {
+			if err := m.checkDependencyCycle(ctx, rbg, role); err != nil {
+				return nil, err
+			}
+			break
 		}
-		r, err := reconciler.NewWorkloadReconciler(depRole.Workload, m.scheme, m.client)
-		if err != nil {
-			return false, err
+	}
+
+	var blocking []string
+	for i := range dependencies {
+		dep := &dependencies[i]
+		ready, err := m.checkDependencyReady(ctx, rbg, dep)
+		if errors.Is(err, errDependencyNotFound) {
+			blocking = append(blocking, fmt.Sprintf("%s (not found)", dependencyName(rbg, dep)))
+			continue
 		}
-		ready, err := r.CheckWorkloadReady(ctx, rbg, depRole)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 		if !ready {
-			return false, nil
+			blocking = append(blocking, dependencyName(rbg, dep))
 		}
 	}
+	return blocking, nil
+}
 
-	return true, nil
+func (m *DefaultDependencyManager) checkDependencyReady(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, dep *workloadsv1alpha.RoleDependency,
+) (bool, error) {
+	if dep.IsExternal() {
+		return m.checkExternalDependencyReady(ctx, rbg, dep)
+	}
+	depRole, err := rbg.GetRole(dep.Role)
+	if err != nil {
+		return false, err
+	}
+	if dep.Readiness != nil {
+		return m.checkReadiness(ctx, rbg, depRole, dep.Readiness)
+	}
+	r, err := reconciler.NewWorkloadReconciler(depRole.Workload, m.scheme, m.client)
+	if err != nil {
+		return false, err
+	}
+	ready, err := r.CheckWorkloadReady(ctx, rbg, depRole)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return ready, err
 }
 
 type roleWithOrder struct {
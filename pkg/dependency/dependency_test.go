@@ -315,3 +315,66 @@ func TestDefaultDependencyManager_CheckDependencyReady(t *testing.T) {
 		)
 	}
 }
+
+func TestDefaultDependencyManager_BlockingDependencies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha.AddToScheme(scheme)
+
+	newRole := func(name string, dependencies ...string) workloadsv1alpha.RoleSpec {
+		role := workloadsv1alpha.RoleSpec{
+			Name: name,
+			Workload: workloadsv1alpha.WorkloadSpec{
+				APIVersion: "apps/v1",
+				Kind:       "StatefulSet",
+			},
+			Replicas: ptr.To(int32(2)),
+		}
+		role.Dependencies = dependencies
+		return role
+	}
+	rbg := &workloadsv1alpha.RoleBasedGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rbg",
+			Namespace: "default",
+		},
+		Spec: workloadsv1alpha.RoleBasedGroupSpec{
+			Roles: []workloadsv1alpha.RoleSpec{
+				newRole("router", "prefill", "decode", "cache"),
+				newRole("prefill"),
+				newRole("decode"),
+				newRole("cache"),
+			},
+		},
+	}
+	newSts := func(name string, readyReplicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(int32(2)),
+			},
+			Status: appsv1.StatefulSetStatus{
+				ReadyReplicas: readyReplicas,
+				Replicas:      2,
+			},
+		}
+	}
+
+	// the workload of cache is not created yet
+	client := fake.NewClientBuilder().WithObjects(
+		newSts("test-rbg-prefill", 2),
+		newSts("test-rbg-decode", 1),
+	).Build()
+	dependencyManager := NewDefaultDependencyManager(scheme, client)
+
+	ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+	blocking, err := dependencyManager.BlockingDependencies(ctx, rbg, &rbg.Spec.Roles[0])
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"decode", "cache"}, blocking)
+
+	blocking, err = dependencyManager.BlockingDependencies(ctx, rbg, &rbg.Spec.Roles[1])
+	assert.NoError(t, err)
+	assert.Empty(t, blocking)
+}
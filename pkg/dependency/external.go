@@ -0,0 +1,140 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// errDependencyNotFound is returned if the rbg or the role of a dependency on another rbg doesn't exist.
+var errDependencyNotFound = errors.New("not found")
+
+// dependencyNode is a role in the graph of the dependencies across rbgs.
+type dependencyNode struct {
+	rbg  types.NamespacedName
+	role string
+}
+
+func (n dependencyNode) String() string {
+	return fmt.Sprintf("%s/%s", n.rbg, n.role)
+}
+
+// dependencyName returns the name of a dependency reported to the user, i.e. the name of the role for roles of the
+// same rbg, and <namespace>/<rbg>/<role> for roles of other rbgs.
+func dependencyName(rbg *workloadsv1alpha.RoleBasedGroup, dep *workloadsv1alpha.RoleDependency) string {
+	if !dep.IsExternal() {
+		return dep.Role
+	}
+	return dependencyNode{rbg: rbg.GetDependencyRBG(dep), role: dep.Role}.String()
+}
+
+// validateExternalDependency returns an error if a dependency on a role of another rbg is invalid. The HTTP and gRPC
+// checks are rejected for rbgs of other namespaces, so that the controller never probes the services of a namespace
+// on behalf of another one.
+func validateExternalDependency(rbg *workloadsv1alpha.RoleBasedGroup, dep *workloadsv1alpha.RoleDependency) error {
+	if rbg.GetDependencyRBG(dep).Namespace == rbg.Namespace || dep.Readiness == nil {
+		return nil
+	}
+	if dep.Readiness.HTTPGet != nil || dep.Readiness.GRPC != nil {
+		return fmt.Errorf("httpGet and grpc checks are not supported for roles of rbgs in other namespaces")
+	}
+	return nil
+}
+
+// checkExternalDependencyReady returns true if a role of another rbg is ready. Without a readiness condition, the
+// role is ready once its ready replicas in the status of its rbg reach the replicas of the role. A rbg of another
+// namespace not allowing the namespace of the dependent rbg is reported as not found, revealing nothing about it.
+func (m *DefaultDependencyManager) checkExternalDependencyReady(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, dep *workloadsv1alpha.RoleDependency,
+) (bool, error) {
+	if err := validateExternalDependency(rbg, dep); err != nil {
+		return false, err
+	}
+	target := &workloadsv1alpha.RoleBasedGroup{}
+	if err := m.client.Get(ctx, rbg.GetDependencyRBG(dep), target); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, errDependencyNotFound
+		}
+		return false, err
+	}
+	if !target.AllowsDependentNamespace(rbg.Namespace) {
+		return false, errDependencyNotFound
+	}
+	depRole, err := target.GetRole(dep.Role)
+	if err != nil {
+		return false, errDependencyNotFound
+	}
+	if target.DeletionTimestamp != nil {
+		return false, nil
+	}
+	if dep.Readiness != nil {
+		if err := validateReadinessChecks(depRole, dep.Readiness); err != nil {
+			return false, err
+		}
+		return m.checkReadiness(ctx, target, depRole, dep.Readiness)
+	}
+	status, found := target.GetRoleStatus(dep.Role)
+	return found && status.ReadyReplicas >= ptr.Deref(depRole.Replicas, 1), nil
+}
+
+// checkDependencyCycle returns an error if the role depends on itself through roles of other rbgs. Cycles within
+// the rbg are rejected by SortRoles. Missing rbgs and rbgs not allowing the namespace of the rbg depending on them are
+// skipped, they are reported as missing dependencies.
+func (m *DefaultDependencyManager) checkDependencyCycle(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec,
+) error {
+	start := dependencyNode{rbg: types.NamespacedName{Namespace: rbg.Namespace, Name: rbg.Name}, role: role.Name}
+	rbgs := map[types.NamespacedName]*workloadsv1alpha.RoleBasedGroup{start.rbg: rbg}
+	visited := map[dependencyNode]bool{}
+
+	var visit func(node dependencyNode, path []dependencyNode) error
+	visit = func(node dependencyNode, path []dependencyNode) error {
+		path = append(path, node)
+		if len(path) > 1 && node == start {
+			names := make([]string, 0, len(path))
+			for _, n := range path {
+				names = append(names, n.String())
+			}
+			return fmt.Errorf("dependency cycle across rbgs: %s", strings.Join(names, " -> "))
+		}
+		if visited[node] {
+			return nil
+		}
+
+		current, ok := rbgs[node.rbg]
+		if !ok {
+			current = &workloadsv1alpha.RoleBasedGroup{}
+			if err := m.client.Get(ctx, node.rbg, current); err != nil {
+				if apierrors.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			rbgs[node.rbg] = current
+		}
+		if len(path) > 1 && !current.AllowsDependentNamespace(path[len(path)-2].rbg.Namespace) {
+			return nil
+		}
+		visited[node] = true
+		currentRole, err := current.GetRole(node.role)
+		if err != nil {
+			return nil
+		}
+		dependencies := currentRole.GetDependencies()
+		for i := range dependencies {
+			dep := &dependencies[i]
+			next := dependencyNode{rbg: current.GetDependencyRBG(dep), role: dep.Role}
+			if err := visit(next, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit(start, nil)
+}
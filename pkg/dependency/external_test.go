@@ -0,0 +1,134 @@
+package dependency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestBlockingDependencies_External(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha.AddToScheme(scheme)
+
+	allowDefault := map[string]string{workloadsv1alpha.AllowedDependentNamespacesAnnotationKey: "other, default"}
+	kvCache := func(readyReplicas int32) *workloadsv1alpha.RoleBasedGroup {
+		return wrappers.BuildBasicRoleBasedGroup("kv-cache", "shared").
+			WithAnnotations(allowDefault).
+			WithRoles([]workloadsv1alpha.RoleSpec{
+				wrappers.BuildBasicRole("cache").WithReplicas(2).Obj(),
+			}).
+			WithStatus(workloadsv1alpha.RoleBasedGroupStatus{
+				RoleStatuses: []workloadsv1alpha.RoleStatus{
+					{Name: "cache", Replicas: 2, ReadyReplicas: readyReplicas},
+				},
+			}).Obj()
+	}
+	model := func(dependency workloadsv1alpha.RoleDependency) *workloadsv1alpha.RoleBasedGroup {
+		return wrappers.BuildBasicRoleBasedGroup("model", "default").
+			WithRoles([]workloadsv1alpha.RoleSpec{
+				wrappers.BuildBasicRole("router").WithDependency(dependency).Obj(),
+			}).Obj()
+	}
+
+	tests := []struct {
+		name         string
+		rbg          *workloadsv1alpha.RoleBasedGroup
+		objects      []client.Object
+		wantBlocking []string
+		wantErr      bool
+	}{
+		{
+			name:         "role of another rbg ready",
+			rbg:          model(workloadsv1alpha.RoleDependency{Role: "cache", RBG: "kv-cache", Namespace: "shared"}),
+			objects:      []client.Object{kvCache(2)},
+			wantBlocking: nil,
+		},
+		{
+			name:         "role of another rbg not ready",
+			rbg:          model(workloadsv1alpha.RoleDependency{Role: "cache", RBG: "kv-cache", Namespace: "shared"}),
+			objects:      []client.Object{kvCache(1)},
+			wantBlocking: []string{"shared/kv-cache/cache"},
+		},
+		{
+			name:         "missing rbg",
+			rbg:          model(workloadsv1alpha.RoleDependency{Role: "cache", RBG: "kv-cache"}),
+			objects:      []client.Object{kvCache(2)},
+			wantBlocking: []string{"default/kv-cache/cache (not found)"},
+		},
+		{
+			name:         "missing role of another rbg",
+			rbg:          model(workloadsv1alpha.RoleDependency{Role: "store", RBG: "kv-cache", Namespace: "shared"}),
+			objects:      []client.Object{kvCache(2)},
+			wantBlocking: []string{"shared/kv-cache/store (not found)"},
+		},
+		{
+			name: "rbg of another namespace not allowing the namespace",
+			rbg:  model(workloadsv1alpha.RoleDependency{Role: "cache", RBG: "kv-cache", Namespace: "shared"}),
+			objects: []client.Object{
+				wrappers.BuildBasicRoleBasedGroup("kv-cache", "shared").
+					WithRoles([]workloadsv1alpha.RoleSpec{wrappers.BuildBasicRole("cache").Obj()}).Obj(),
+			},
+			wantBlocking: []string{"shared/kv-cache/cache (not found)"},
+		},
+		{
+			name: "http check of a role of another namespace",
+			rbg: model(workloadsv1alpha.RoleDependency{
+				Role: "cache", RBG: "kv-cache", Namespace: "shared",
+				Readiness: &workloadsv1alpha.DependencyReadiness{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8000)},
+				},
+			}),
+			objects: []client.Object{kvCache(2)},
+			wantErr: true,
+		},
+		{
+			name: "cycle across rbgs",
+			rbg:  model(workloadsv1alpha.RoleDependency{Role: "cache", RBG: "kv-cache", Namespace: "shared"}),
+			objects: []client.Object{
+				wrappers.BuildBasicRoleBasedGroup("kv-cache", "shared").
+					WithAnnotations(allowDefault).
+					WithRoles([]workloadsv1alpha.RoleSpec{
+						wrappers.BuildBasicRole("cache").
+							WithDependency(workloadsv1alpha.RoleDependency{Role: "router", RBG: "model", Namespace: "default"}).
+							Obj(),
+					}).Obj(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "cycle through a rbg not allowing the namespace",
+			rbg:  model(workloadsv1alpha.RoleDependency{Role: "cache", RBG: "kv-cache", Namespace: "shared"}),
+			objects: []client.Object{
+				wrappers.BuildBasicRoleBasedGroup("kv-cache", "shared").
+					WithRoles([]workloadsv1alpha.RoleSpec{
+						wrappers.BuildBasicRole("cache").
+							WithDependency(workloadsv1alpha.RoleDependency{Role: "router", RBG: "model", Namespace: "default"}).
+							Obj(),
+					}).Obj(),
+			},
+			wantBlocking: []string{"shared/kv-cache/cache (not found)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tt.objects, tt.rbg)...).Build()
+			dependencyManager := NewDefaultDependencyManager(scheme, c)
+
+			ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+			blocking, err := dependencyManager.BlockingDependencies(ctx, tt.rbg, &tt.rbg.Spec.Roles[0])
+			assert.Equal(t, tt.wantErr, err != nil, "error: %v", err)
+			assert.Equal(t, tt.wantBlocking, blocking)
+		})
+	}
+}
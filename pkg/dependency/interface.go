@@ -11,4 +11,7 @@ type DependencyManager interface {
 	CheckDependencyReady(
 		ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
 	) (bool, error)
+	BlockingDependencies(
+		ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+	) ([]string, error)
 }
@@ -0,0 +1,295 @@
+package dependency
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/utils"
+)
+
+// defaultReadinessCheckTimeout is the timeout of the HTTP and gRPC readiness checks without timeoutSeconds.
+const defaultReadinessCheckTimeout = time.Second
+
+// readinessHTTPClient is shared by all HTTP readiness checks, so that their idle connections are reused.
+// Like the probes of the kubelet, the certificates of the dependency are not verified. Redirects are not followed,
+// so that a check never leaves the service of the role; a redirect response counts as successful.
+var readinessHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		TLSClientConfig:     &tls.Config{InsecureSkipVerify: true},
+		MaxIdleConnsPerHost: 1,
+		IdleConnTimeout:     time.Minute,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// validateDependency returns an error if a dependency is invalid, beyond the validation of the CRD.
+func validateDependency(dep *workloadsv1alpha.RoleDependency) error {
+	if dep.Role == "" {
+		return fmt.Errorf("role must be set")
+	}
+	if dep.Namespace != "" && !dep.IsExternal() {
+		return fmt.Errorf("namespace is only valid with rbg")
+	}
+	readiness := dep.Readiness
+	if readiness == nil {
+		return nil
+	}
+	if readiness.MinReady != nil {
+		minReady, err := intstr.GetScaledValueFromIntOrPercent(readiness.MinReady, 100, true)
+		if err != nil {
+			return fmt.Errorf("invalid minReady: %v", err)
+		}
+		if minReady < 0 {
+			return fmt.Errorf("minReady must not be negative")
+		}
+	}
+	if readiness.MinReadySeconds < 0 {
+		return fmt.Errorf("minReadySeconds must not be negative")
+	}
+	if readiness.TimeoutSeconds < 0 {
+		return fmt.Errorf("timeoutSeconds must not be negative")
+	}
+	if action := readiness.HTTPGet; action != nil {
+		if action.Host != "" {
+			return fmt.Errorf("httpGet.host is not supported, the check targets the service of the role")
+		}
+		if action.Scheme != "" && action.Scheme != corev1.URISchemeHTTP && action.Scheme != corev1.URISchemeHTTPS {
+			return fmt.Errorf("unsupported httpGet.scheme %s", action.Scheme)
+		}
+		if err := validatePort(action.Port); err != nil {
+			return fmt.Errorf("invalid httpGet.port: %v", err)
+		}
+	}
+	if action := readiness.GRPC; action != nil {
+		if err := validatePort(intstr.FromInt32(action.Port)); err != nil {
+			return fmt.Errorf("invalid grpc.port: %v", err)
+		}
+	}
+	return nil
+}
+
+// validateReadinessChecks returns an error if the HTTP or gRPC check of a readiness condition can't reach the role
+// depended on, since only StatefulSet and LeaderWorkerSet roles have a service addressing their pods.
+func validateReadinessChecks(depRole *workloadsv1alpha.RoleSpec, readiness *workloadsv1alpha.DependencyReadiness) error {
+	if readiness == nil || (readiness.HTTPGet == nil && readiness.GRPC == nil) {
+		return nil
+	}
+	if !hasService(depRole) {
+		return fmt.Errorf("httpGet and grpc checks need a service, which role %s of workload %s has not",
+			depRole.Name, depRole.Workload.String())
+	}
+	return nil
+}
+
+func hasService(role *workloadsv1alpha.RoleSpec) bool {
+	switch role.Workload.String() {
+	case workloadsv1alpha.StatefulSetWorkloadType, workloadsv1alpha.LeaderWorkerSetWorkloadType:
+		return true
+	}
+	return false
+}
+
+func validatePort(port intstr.IntOrString) error {
+	var errs []string
+	if port.Type == intstr.Int {
+		errs = validation.IsValidPortNum(port.IntValue())
+	} else {
+		errs = validation.IsValidPortName(port.StrVal)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", errs[0])
+	}
+	return nil
+}
+
+// checkReadiness returns true if the readiness condition of a dependency holds for the role depended on.
+func (m *DefaultDependencyManager) checkReadiness(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup,
+	depRole *workloadsv1alpha.RoleSpec, readiness *workloadsv1alpha.DependencyReadiness,
+) (bool, error) {
+	logger := log.FromContext(ctx).WithValues("dependency", depRole.Name)
+
+	pods := &corev1.PodList{}
+	if err := m.client.List(
+		ctx, pods, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{
+			workloadsv1alpha.SetNameLabelKey: rbg.Name,
+			workloadsv1alpha.SetRoleLabelKey: depRole.Name,
+		},
+	); err != nil {
+		return false, err
+	}
+
+	total := rbg.GetRolePodGroupSize(depRole)
+	minReady := total
+	if readiness.MinReady != nil {
+		var err error
+		minReady, err = intstr.GetScaledValueFromIntOrPercent(readiness.MinReady, total, true)
+		if err != nil {
+			return false, fmt.Errorf("invalid minReady of dependency %s: %v", depRole.Name, err)
+		}
+	}
+	ready := 0
+	now := metav1.Now()
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp == nil && podutil.IsPodAvailable(pod, readiness.MinReadySeconds, now) {
+			ready++
+		}
+	}
+	if ready < minReady {
+		logger.V(1).Info("Dependency not ready", "readyPods", ready, "minReady", minReady)
+		return false, nil
+	}
+
+	timeout := defaultReadinessCheckTimeout
+	if readiness.TimeoutSeconds > 0 {
+		timeout = time.Duration(readiness.TimeoutSeconds) * time.Second
+	}
+	if readiness.HTTPGet != nil {
+		if err := m.checkHTTPReadiness(ctx, rbg, depRole, readiness.HTTPGet, timeout); err != nil {
+			logger.V(1).Info("HTTP readiness check of dependency failed", "error", err.Error())
+			return false, nil
+		}
+	}
+	if readiness.GRPC != nil {
+		if err := m.checkGRPCReadiness(ctx, rbg, depRole, readiness.GRPC, timeout); err != nil {
+			logger.V(1).Info("gRPC readiness check of dependency failed", "error", err.Error())
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *DefaultDependencyManager) checkHTTPReadiness(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, depRole *workloadsv1alpha.RoleSpec,
+	action *corev1.HTTPGetAction, timeout time.Duration,
+) error {
+	// the host is rejected by validateDependency, the controller only sends requests to the service of the role
+	if action.Host != "" {
+		return fmt.Errorf("httpGet.host is not supported")
+	}
+	host, err := m.serviceHost(ctx, rbg, depRole)
+	if err != nil {
+		return err
+	}
+	port, err := resolvePort(depRole, action.Port)
+	if err != nil {
+		return err
+	}
+	scheme := corev1.URISchemeHTTP
+	if action.Scheme != "" {
+		scheme = action.Scheme
+	}
+	target := &url.URL{
+		Scheme: string(scheme),
+		Host:   net.JoinHostPort(host, strconv.Itoa(port)),
+		Path:   action.Path,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return err
+	}
+	for _, header := range action.HTTPHeaders {
+		request.Header.Add(header.Name, header.Value)
+	}
+	response, err := readinessHTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("GET %s returned status code %d", target.String(), response.StatusCode)
+	}
+	return nil
+}
+
+func (m *DefaultDependencyManager) checkGRPCReadiness(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, depRole *workloadsv1alpha.RoleSpec,
+	action *corev1.GRPCAction, timeout time.Duration,
+) error {
+	host, err := m.serviceHost(ctx, rbg, depRole)
+	if err != nil {
+		return err
+	}
+	conn, err := grpc.NewClient(
+		net.JoinHostPort(host, strconv.Itoa(int(action.Port))),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	response, err := healthpb.NewHealthClient(conn).Check(
+		ctx, &healthpb.HealthCheckRequest{Service: ptr.Deref(action.Service, "")},
+	)
+	if err != nil {
+		return err
+	}
+	if response.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("health check returned %s", response.Status)
+	}
+	return nil
+}
+
+// serviceHost returns the DNS name of the service of a role.
+func (m *DefaultDependencyManager) serviceHost(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec,
+) (string, error) {
+	var serviceName string
+	switch role.Workload.String() {
+	case workloadsv1alpha.StatefulSetWorkloadType:
+		var err error
+		if serviceName, err = utils.GetCompatibleHeadlessServiceName(ctx, m.client, rbg, role); err != nil {
+			return "", err
+		}
+	case workloadsv1alpha.LeaderWorkerSetWorkloadType:
+		// the headless service of a lws is named after the lws
+		serviceName = rbg.GetWorkloadName(role)
+	default:
+		return "", fmt.Errorf("role %s of workload %s has no service for readiness checks",
+			role.Name, role.Workload.String())
+	}
+	return fmt.Sprintf("%s.%s.svc", serviceName, rbg.Namespace), nil
+}
+
+// resolvePort returns the number of a port, looking named ports up in the containers of the role.
+func resolvePort(role *workloadsv1alpha.RoleSpec, port intstr.IntOrString) (int, error) {
+	if port.Type == intstr.Int {
+		return port.IntValue(), nil
+	}
+	for _, container := range role.Template.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("port %s not found in the containers of role %s", port.StrVal, role.Name)
+}
@@ -0,0 +1,284 @@
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func buildReadyPod(name string, readySince time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				workloadsv1alpha.SetNameLabelKey: "test-rbg",
+				workloadsv1alpha.SetRoleLabelKey: "decode",
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(readySince)},
+			},
+		},
+	}
+}
+
+func TestCheckDependencyReady_Readiness(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha.AddToScheme(scheme)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverURL.Port())
+
+	// the service of the role resolves to the test server
+	defaultHTTPClient := readinessHTTPClient
+	defer func() { readinessHTTPClient = defaultHTTPClient }()
+	readinessHTTPClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				if addr != net.JoinHostPort("s-test-rbg-decode.default.svc", serverURL.Port()) {
+					return nil, fmt.Errorf("unexpected address %s", addr)
+				}
+				return (&net.Dialer{}).DialContext(ctx, network, serverURL.Host)
+			},
+		},
+	}
+
+	// three of four pods ready, one of them only since a moment
+	pods := []client.Object{
+		buildReadyPod("decode-0", time.Now().Add(-time.Minute)),
+		buildReadyPod("decode-1", time.Now().Add(-time.Minute)),
+		buildReadyPod("decode-2", time.Now()),
+		func() *corev1.Pod {
+			pod := buildReadyPod("decode-3", time.Now())
+			pod.Status.Conditions[0].Status = corev1.ConditionFalse
+			return pod
+		}(),
+	}
+
+	tests := []struct {
+		name        string
+		readiness   workloadsv1alpha.DependencyReadiness
+		expectReady bool
+	}{
+		{
+			name:        "all pods by default",
+			readiness:   workloadsv1alpha.DependencyReadiness{},
+			expectReady: false,
+		},
+		{
+			name:        "min ready count",
+			readiness:   workloadsv1alpha.DependencyReadiness{MinReady: ptr.To(intstr.FromInt32(3))},
+			expectReady: true,
+		},
+		{
+			name:        "min ready percentage",
+			readiness:   workloadsv1alpha.DependencyReadiness{MinReady: ptr.To(intstr.FromString("75%"))},
+			expectReady: true,
+		},
+		{
+			name: "pods not ready for min ready seconds",
+			readiness: workloadsv1alpha.DependencyReadiness{
+				MinReady:        ptr.To(intstr.FromString("75%")),
+				MinReadySeconds: 30,
+			},
+			expectReady: false,
+		},
+		{
+			name: "http check succeeds",
+			readiness: workloadsv1alpha.DependencyReadiness{
+				MinReady: ptr.To(intstr.FromInt32(1)),
+				HTTPGet: &corev1.HTTPGetAction{
+					Port: intstr.FromInt(serverPort),
+					Path: "/health",
+				},
+			},
+			expectReady: true,
+		},
+		{
+			name: "http check fails",
+			readiness: workloadsv1alpha.DependencyReadiness{
+				MinReady: ptr.To(intstr.FromInt32(1)),
+				HTTPGet: &corev1.HTTPGetAction{
+					Port: intstr.FromInt(serverPort),
+					Path: "/not-ready",
+				},
+			},
+			expectReady: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+					WithRoles([]workloadsv1alpha.RoleSpec{
+						wrappers.BuildBasicRole("decode").WithReplicas(4).Obj(),
+						wrappers.BuildBasicRole("router").
+							WithDependency(workloadsv1alpha.RoleDependency{Role: "decode", Readiness: &tt.readiness}).Obj(),
+					}).Obj()
+				dependencyManager := NewDefaultDependencyManager(
+					scheme, fake.NewClientBuilder().WithScheme(scheme).WithObjects(pods...).Build(),
+				)
+
+				ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+				ready, err := dependencyManager.CheckDependencyReady(ctx, rbg, &rbg.Spec.Roles[1])
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectReady, ready, fmt.Sprintf("readiness %+v", tt.readiness))
+			},
+		)
+	}
+}
+
+func TestValidateDependency(t *testing.T) {
+	tests := []struct {
+		name      string
+		dep       workloadsv1alpha.RoleDependency
+		expectErr bool
+	}{
+		{
+			name: "role name",
+			dep:  workloadsv1alpha.RoleDependency{Role: "decode"},
+		},
+		{
+			name:      "missing role name",
+			dep:       workloadsv1alpha.RoleDependency{},
+			expectErr: true,
+		},
+		{
+			name:      "namespace without rbg",
+			dep:       workloadsv1alpha.RoleDependency{Role: "decode", Namespace: "shared"},
+			expectErr: true,
+		},
+		{
+			name: "valid readiness",
+			dep: workloadsv1alpha.RoleDependency{Role: "decode", Readiness: &workloadsv1alpha.DependencyReadiness{
+				MinReady: ptr.To(intstr.FromString("90%")),
+				HTTPGet:  &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromString("http")},
+				GRPC:     &corev1.GRPCAction{Port: 9000},
+			}},
+		},
+		{
+			name: "invalid min ready percentage",
+			dep: workloadsv1alpha.RoleDependency{Role: "decode", Readiness: &workloadsv1alpha.DependencyReadiness{
+				MinReady: ptr.To(intstr.FromString("ninety")),
+			}},
+			expectErr: true,
+		},
+		{
+			name: "http host",
+			dep: workloadsv1alpha.RoleDependency{Role: "decode", Readiness: &workloadsv1alpha.DependencyReadiness{
+				HTTPGet: &corev1.HTTPGetAction{Host: "169.254.169.254", Port: intstr.FromInt32(80)},
+			}},
+			expectErr: true,
+		},
+		{
+			name: "invalid http port",
+			dep: workloadsv1alpha.RoleDependency{Role: "decode", Readiness: &workloadsv1alpha.DependencyReadiness{
+				HTTPGet: &corev1.HTTPGetAction{Port: intstr.FromInt32(70000)},
+			}},
+			expectErr: true,
+		},
+		{
+			name: "invalid grpc port",
+			dep: workloadsv1alpha.RoleDependency{Role: "decode", Readiness: &workloadsv1alpha.DependencyReadiness{
+				GRPC: &corev1.GRPCAction{Port: 0},
+			}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				err := validateDependency(&tt.dep)
+				assert.Equal(t, tt.expectErr, err != nil, fmt.Sprintf("error %v", err))
+			},
+		)
+	}
+}
+
+func TestSortRoles_ReadinessCheckWithoutService(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha.AddToScheme(scheme)
+	httpGet := &workloadsv1alpha.DependencyReadiness{
+		HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8000)},
+	}
+
+	tests := []struct {
+		name      string
+		workload  string
+		readiness *workloadsv1alpha.DependencyReadiness
+		expectErr bool
+	}{
+		{
+			name:      "http check of a statefulset",
+			workload:  workloadsv1alpha.StatefulSetWorkloadType,
+			readiness: httpGet,
+		},
+		{
+			name:      "http check of a deployment",
+			workload:  workloadsv1alpha.DeploymentWorkloadType,
+			readiness: httpGet,
+			expectErr: true,
+		},
+		{
+			name:     "grpc check of a deployment",
+			workload: workloadsv1alpha.DeploymentWorkloadType,
+			readiness: &workloadsv1alpha.DependencyReadiness{
+				GRPC: &corev1.GRPCAction{Port: 9000},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "pod readiness of a deployment",
+			workload:  workloadsv1alpha.DeploymentWorkloadType,
+			readiness: &workloadsv1alpha.DependencyReadiness{MinReady: ptr.To(intstr.FromInt32(1))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+					WithRoles([]workloadsv1alpha.RoleSpec{
+						wrappers.BuildBasicRole("decode").WithWorkload(tt.workload).Obj(),
+						wrappers.BuildBasicRole("router").
+							WithDependency(workloadsv1alpha.RoleDependency{Role: "decode", Readiness: tt.readiness}).Obj(),
+					}).Obj()
+				dependencyManager := NewDefaultDependencyManager(scheme, fake.NewClientBuilder().WithScheme(scheme).Build())
+
+				_, err := dependencyManager.SortRoles(context.TODO(), rbg)
+				assert.Equal(t, tt.expectErr, err != nil, fmt.Sprintf("error %v", err))
+			},
+		)
+	}
+}
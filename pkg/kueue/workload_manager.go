@@ -0,0 +1,341 @@
+package kueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/utils"
+)
+
+const (
+	// QueueNameLabelKey is the label used to submit a RoleBasedGroup to a Kueue LocalQueue.
+	QueueNameLabelKey = "kueue.x-k8s.io/queue-name"
+
+	// WorkloadCrdName is the Kueue Workload CRD Name
+	WorkloadCrdName = "workloads.kueue.x-k8s.io"
+
+	// WorkloadAdmittedCondition is the Workload condition set by Kueue once the Workload is admitted.
+	WorkloadAdmittedCondition = "Admitted"
+
+	// WorkloadEvictedCondition is the Workload condition set by Kueue once the Workload is evicted, e.g. on preemption.
+	WorkloadEvictedCondition = "Evicted"
+)
+
+var (
+	WorkloadGVK       = schema.GroupVersionKind{Group: "kueue.x-k8s.io", Version: "v1beta1", Kind: "Workload"}
+	ResourceFlavorGVK = schema.GroupVersionKind{Group: "kueue.x-k8s.io", Version: "v1beta1", Kind: "ResourceFlavor"}
+)
+
+// QueueName returns the Kueue LocalQueue of the rbg, if the rbg is managed by Kueue.
+func QueueName(rbg *workloadsv1alpha.RoleBasedGroup) (string, bool) {
+	queueName, found := rbg.Labels[QueueNameLabelKey]
+	return queueName, found && queueName != ""
+}
+
+// GetWorkloadName returns the name of the Kueue Workload representing the rbg.
+func GetWorkloadName(rbg *workloadsv1alpha.RoleBasedGroup) string {
+	return fmt.Sprintf("rbg-%s", rbg.Name)
+}
+
+// Admission is the outcome of the Kueue admission of a rbg.
+type Admission struct {
+	// Admitted is true once Kueue admitted the Workload of the rbg.
+	Admitted bool
+	// Evicted is true if Kueue evicted the Workload of the rbg and did not admit it again yet, and no outdated
+	// Workload of the rbg is still admitted.
+	Evicted bool
+	// NodeSelectors holds the node labels of the flavors assigned to each role, keyed by role name.
+	NodeSelectors map[string]map[string]string
+}
+
+type WorkloadManager struct {
+	client client.Client
+}
+
+func NewWorkloadManager(client client.Client) *WorkloadManager {
+	return &WorkloadManager{client: client}
+}
+
+// Reconcile makes sure the rbg is represented by a Kueue Workload with one PodSet per role,
+// and returns whether the Workload is admitted. The outdated Workloads of a resized rbg are kept until the new
+// Workload is admitted, so that the running pods are always accounted for in the quota.
+func (m *WorkloadManager) Reconcile(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, runtimeController *builder.TypedBuilder[reconcile.Request], watchedWorkload *sync.Map, apiReader client.Reader) (*Admission, error) {
+	logger := log.FromContext(ctx)
+	queueName, _ := QueueName(rbg)
+
+	// check and load kueue workload CRD
+	if _, workloadExist := watchedWorkload.Load(WorkloadCrdName); !workloadExist {
+		if err := utils.CheckCrdExists(apiReader, WorkloadCrdName); err != nil {
+			return nil, fmt.Errorf("kueue %s not ready", WorkloadCrdName)
+		}
+		watchedWorkload.LoadOrStore(WorkloadCrdName, struct{}{})
+		runtimeController.Owns(NewWorkload())
+	}
+
+	podSets, err := desiredPodSets(rbg)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := m.listWorkloads(ctx, rbg)
+	if err != nil {
+		logger.Error(err, "list kueue workloads error")
+		return nil, err
+	}
+	var workload *unstructured.Unstructured
+	var outdated []*unstructured.Unstructured
+	for _, w := range workloads {
+		if workload == nil && workloadMatches(w, queueName, podSets) {
+			workload = w
+			continue
+		}
+		outdated = append(outdated, w)
+	}
+
+	if workload == nil {
+		// The podSets of a workload which reserved quota are immutable in Kueue, so a resized rbg is represented
+		// by a new workload, named after its podSets, and waits for a new admission.
+		name := GetWorkloadName(rbg)
+		if len(workloads) > 0 {
+			name = fmt.Sprintf("%s-%s", name, workloadSpecHash(queueName, podSets))
+		}
+		workload = NewWorkload()
+		workload.SetName(name)
+		workload.SetNamespace(rbg.Namespace)
+		workload.SetLabels(map[string]string{workloadsv1alpha.SetNameLabelKey: rbg.Name})
+		workload.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())})
+		if err := setWorkloadSpec(workload, queueName, podSets); err != nil {
+			return nil, err
+		}
+		if err := m.client.Create(ctx, workload); err != nil {
+			logger.Error(err, "create kueue workload error")
+			return nil, err
+		}
+		logger.Info("created kueue workload, waiting for admission", "workload", workload.GetName())
+	}
+
+	if isConditionTrue(workload, WorkloadAdmittedCondition) {
+		// The admitted workload replaces the outdated ones, whose quota is released
+		for _, w := range outdated {
+			if err := m.deleteWorkload(ctx, w); err != nil {
+				return nil, err
+			}
+		}
+		nodeSelectors, err := m.assignedNodeSelectors(ctx, workload)
+		if err != nil {
+			return nil, err
+		}
+		return &Admission{Admitted: true, NodeSelectors: nodeSelectors}, nil
+	}
+
+	// An outdated workload which is still admitted holds the quota of the running pods until the new workload is
+	// admitted. The other outdated workloads hold no quota and would only compete for it.
+	admission := &Admission{Evicted: isConditionTrue(workload, WorkloadEvictedCondition)}
+	holdsQuota := false
+	for _, w := range outdated {
+		if isConditionTrue(w, WorkloadAdmittedCondition) {
+			holdsQuota = true
+			continue
+		}
+		admission.Evicted = admission.Evicted || isConditionTrue(w, WorkloadEvictedCondition)
+		if err := m.deleteWorkload(ctx, w); err != nil {
+			return nil, err
+		}
+	}
+	if holdsQuota {
+		admission.Evicted = false
+	}
+	return admission, nil
+}
+
+// listWorkloads returns the Kueue Workloads controlled by the rbg.
+func (m *WorkloadManager) listWorkloads(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup,
+) ([]*unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(WorkloadGVK.GroupVersion().WithKind(WorkloadGVK.Kind + "List"))
+	if err := m.client.List(
+		ctx, list, client.InNamespace(rbg.Namespace), client.MatchingLabels{workloadsv1alpha.SetNameLabelKey: rbg.Name},
+	); err != nil {
+		return nil, err
+	}
+	workloads := make([]*unstructured.Unstructured, 0, len(list.Items))
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], rbg) {
+			workloads = append(workloads, &list.Items[i])
+		}
+	}
+	return workloads, nil
+}
+
+// Release deletes the Kueue Workloads of the rbg, releasing its quota, e.g. once a suspended rbg has no pods left.
+// Reconcile creates a new Workload, which is admitted before the roles are restored.
+func (m *WorkloadManager) Release(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup) error {
+	workloads, err := m.listWorkloads(ctx, rbg)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	for _, workload := range workloads {
+		if err := m.deleteWorkload(ctx, workload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *WorkloadManager) deleteWorkload(ctx context.Context, workload *unstructured.Unstructured) error {
+	log.FromContext(ctx).Info("delete kueue workload", "workload", workload.GetName())
+	if err := m.client.Delete(ctx, workload); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// workloadMatches returns true if the workload requests the queue and podSets of the rbg.
+func workloadMatches(workload *unstructured.Unstructured, queueName string, podSets []interface{}) bool {
+	currentPodSets, _, _ := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	currentQueueName, _, _ := unstructured.NestedString(workload.Object, "spec", "queueName")
+	return currentQueueName == queueName && reflect.DeepEqual(podSetCounts(currentPodSets), podSetCounts(podSets))
+}
+
+// workloadSpecHash returns a short hash of the queue and podSet counts of a workload.
+func workloadSpecHash(queueName string, podSets []interface{}) string {
+	hf := fnv.New32a()
+	data, _ := json.Marshal(podSetCounts(podSets))
+	hf.Write([]byte(queueName))
+	hf.Write(data)
+	return rand.SafeEncodeString(fmt.Sprint(hf.Sum32()))
+}
+
+// InjectNodeSelectors merges the node labels of the assigned flavors into the role templates.
+func InjectNodeSelectors(rbg *workloadsv1alpha.RoleBasedGroup, nodeSelectors map[string]map[string]string) {
+	for i := range rbg.Spec.Roles {
+		role := &rbg.Spec.Roles[i]
+		if len(nodeSelectors[role.Name]) == 0 {
+			continue
+		}
+		if role.Template.Spec.NodeSelector == nil {
+			role.Template.Spec.NodeSelector = map[string]string{}
+		}
+		for k, v := range nodeSelectors[role.Name] {
+			role.Template.Spec.NodeSelector[k] = v
+		}
+	}
+}
+
+func NewWorkload() *unstructured.Unstructured {
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(WorkloadGVK)
+	return workload
+}
+
+// desiredPodSets returns one PodSet per role, whose count is the number of pods of the role.
+func desiredPodSets(rbg *workloadsv1alpha.RoleBasedGroup) ([]interface{}, error) {
+	podSets := make([]interface{}, 0, len(rbg.Spec.Roles))
+	for i := range rbg.Spec.Roles {
+		role := &rbg.Spec.Roles[i]
+		template, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.PodTemplateSpec{Spec: role.Template.Spec})
+		if err != nil {
+			return nil, err
+		}
+		podSets = append(podSets, map[string]interface{}{
+			"name":     role.Name,
+			"count":    int64(rbg.GetRolePodGroupSize(role)),
+			"template": template,
+		})
+	}
+	return podSets, nil
+}
+
+// podSetCounts returns the pod count of each podSet keyed by name. Only names and counts are compared,
+// since Kueue defaults the podSet templates.
+func podSetCounts(podSets []interface{}) map[string]int64 {
+	counts := make(map[string]int64, len(podSets))
+	for _, p := range podSets {
+		podSet, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(podSet, "name")
+		count, _, _ := unstructured.NestedInt64(podSet, "count")
+		counts[name] = count
+	}
+	return counts
+}
+
+func setWorkloadSpec(workload *unstructured.Unstructured, queueName string, podSets []interface{}) error {
+	if err := unstructured.SetNestedField(workload.Object, queueName, "spec", "queueName"); err != nil {
+		return err
+	}
+	return unstructured.SetNestedSlice(workload.Object, podSets, "spec", "podSets")
+}
+
+func isConditionTrue(workload *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
+
+// assignedNodeSelectors collects the node labels of the flavors assigned to each podSet of an admitted workload.
+func (m *WorkloadManager) assignedNodeSelectors(ctx context.Context, workload *unstructured.Unstructured) (map[string]map[string]string, error) {
+	assignments, _, _ := unstructured.NestedSlice(workload.Object, "status", "admission", "podSetAssignments")
+	nodeSelectors := make(map[string]map[string]string)
+	for _, a := range assignments {
+		assignment, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		podSetName, _, _ := unstructured.NestedString(assignment, "name")
+		flavors, _, _ := unstructured.NestedStringMap(assignment, "flavors")
+		for _, flavorName := range flavors {
+			flavor := &unstructured.Unstructured{}
+			flavor.SetGroupVersionKind(ResourceFlavorGVK)
+			if err := m.client.Get(ctx, types.NamespacedName{Name: flavorName}, flavor); err != nil {
+				if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+					continue
+				}
+				return nil, err
+			}
+			nodeLabels, _, _ := unstructured.NestedStringMap(flavor.Object, "spec", "nodeLabels")
+			if len(nodeLabels) == 0 {
+				continue
+			}
+			if nodeSelectors[podSetName] == nil {
+				nodeSelectors[podSetName] = map[string]string{}
+			}
+			for k, v := range nodeLabels {
+				nodeSelectors[podSetName][k] = v
+			}
+		}
+	}
+	return nodeSelectors, nil
+}
@@ -0,0 +1,228 @@
+package kueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func buildQueuedRBG() *workloadsv1alpha.RoleBasedGroup {
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha.RoleSpec{
+			wrappers.BuildBasicRole("prefill").WithReplicas(2).Obj(),
+			wrappers.BuildLwsRole("decode").WithReplicas(2).Obj(),
+		}).Obj()
+	rbg.Labels[QueueNameLabelKey] = "gpu-queue"
+	return rbg
+}
+
+func buildWorkload(rbg *workloadsv1alpha.RoleBasedGroup, counts map[string]int64, admitted bool) *unstructured.Unstructured {
+	workload := NewWorkload()
+	workload.SetName(GetWorkloadName(rbg))
+	workload.SetNamespace(rbg.Namespace)
+	workload.SetLabels(map[string]string{workloadsv1alpha.SetNameLabelKey: rbg.Name})
+	workload.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())})
+
+	podSets := make([]interface{}, 0, len(counts))
+	assignments := make([]interface{}, 0, len(counts))
+	for name, count := range counts {
+		podSets = append(podSets, map[string]interface{}{"name": name, "count": count})
+		assignments = append(assignments, map[string]interface{}{
+			"name":    name,
+			"flavors": map[string]interface{}{"nvidia.com/gpu": name + "-flavor"},
+		})
+	}
+	_ = setWorkloadSpec(workload, "gpu-queue", podSets)
+
+	if admitted {
+		_ = unstructured.SetNestedSlice(workload.Object, assignments, "status", "admission", "podSetAssignments")
+		_ = unstructured.SetNestedSlice(workload.Object, []interface{}{
+			map[string]interface{}{"type": WorkloadAdmittedCondition, "status": "True"},
+		}, "status", "conditions")
+	}
+	return workload
+}
+
+func buildResourceFlavor(name string, nodeLabels map[string]interface{}) *unstructured.Unstructured {
+	flavor := &unstructured.Unstructured{}
+	flavor.SetGroupVersionKind(ResourceFlavorGVK)
+	flavor.SetName(name)
+	_ = unstructured.SetNestedMap(flavor.Object, nodeLabels, "spec", "nodeLabels")
+	return flavor
+}
+
+func TestWorkloadManager_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	workloadCrd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: WorkloadCrdName},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+			},
+		},
+	}
+	rbg := buildQueuedRBG()
+
+	tests := []struct {
+		name                string
+		objs                []client.Object
+		withoutCrd          bool
+		expectErr           bool
+		expectAdmitted      bool
+		expectEvicted       bool
+		expectNodeSelectors map[string]map[string]string
+		// expectCounts are the podSet counts of the remaining workloads
+		expectCounts []map[string]int64
+	}{
+		{
+			name:         "create workload with one podSet per role",
+			expectCounts: []map[string]int64{{"prefill": 2, "decode": 4}},
+		},
+		{
+			name: "workload is not admitted yet",
+			objs: []client.Object{
+				buildWorkload(rbg, map[string]int64{"prefill": 2, "decode": 4}, false),
+			},
+			expectCounts: []map[string]int64{{"prefill": 2, "decode": 4}},
+		},
+		{
+			name: "workload is admitted with flavors",
+			objs: []client.Object{
+				buildWorkload(rbg, map[string]int64{"prefill": 2, "decode": 4}, true),
+				buildResourceFlavor("prefill-flavor", map[string]interface{}{"gpu-type": "a10"}),
+				buildResourceFlavor("decode-flavor", map[string]interface{}{"gpu-type": "h800", "zone": "a"}),
+			},
+			expectAdmitted: true,
+			expectNodeSelectors: map[string]map[string]string{
+				"prefill": {"gpu-type": "a10"},
+				"decode":  {"gpu-type": "h800", "zone": "a"},
+			},
+			expectCounts: []map[string]int64{{"prefill": 2, "decode": 4}},
+		},
+		{
+			name: "workload is evicted",
+			objs: []client.Object{
+				func() client.Object {
+					workload := buildWorkload(rbg, map[string]int64{"prefill": 2, "decode": 4}, false)
+					_ = unstructured.SetNestedSlice(workload.Object, []interface{}{
+						map[string]interface{}{"type": WorkloadAdmittedCondition, "status": "False"},
+						map[string]interface{}{"type": WorkloadEvictedCondition, "status": "True", "reason": "Preempted"},
+					}, "status", "conditions")
+					return workload
+				}(),
+			},
+			expectEvicted: true,
+			expectCounts:  []map[string]int64{{"prefill": 2, "decode": 4}},
+		},
+		{
+			name: "admitted outdated workload is kept until the resized workload is admitted",
+			objs: []client.Object{
+				buildWorkload(rbg, map[string]int64{"prefill": 1, "decode": 4}, true),
+			},
+			expectCounts: []map[string]int64{{"prefill": 1, "decode": 4}, {"prefill": 2, "decode": 4}},
+		},
+		{
+			name: "outdated workload is deleted once the resized workload is admitted",
+			objs: []client.Object{
+				buildWorkload(rbg, map[string]int64{"prefill": 1, "decode": 4}, true),
+				func() client.Object {
+					workload := buildWorkload(rbg, map[string]int64{"prefill": 2, "decode": 4}, true)
+					workload.SetName(GetWorkloadName(rbg) + "-resized")
+					return workload
+				}(),
+			},
+			expectAdmitted:      true,
+			expectNodeSelectors: map[string]map[string]string{},
+			expectCounts:        []map[string]int64{{"prefill": 2, "decode": 4}},
+		},
+		{
+			name: "evicted outdated workload is deleted",
+			objs: []client.Object{
+				func() client.Object {
+					workload := buildWorkload(rbg, map[string]int64{"prefill": 1, "decode": 4}, false)
+					_ = unstructured.SetNestedSlice(workload.Object, []interface{}{
+						map[string]interface{}{"type": WorkloadAdmittedCondition, "status": "False"},
+						map[string]interface{}{"type": WorkloadEvictedCondition, "status": "True", "reason": "Preempted"},
+					}, "status", "conditions")
+					return workload
+				}(),
+			},
+			expectEvicted: true,
+			expectCounts:  []map[string]int64{{"prefill": 2, "decode": 4}},
+		},
+		{
+			name:       "kueue is not installed",
+			withoutCrd: true,
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objs...).Build()
+				apiReaderBuilder := fake.NewClientBuilder().WithScheme(scheme)
+				if !tt.withoutCrd {
+					apiReaderBuilder.WithObjects(workloadCrd)
+				}
+				runtimeController := builder.TypedBuilder[reconcile.Request]{}
+				watchedWorkload := sync.Map{}
+				ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+
+				admission, err := NewWorkloadManager(fakeClient).Reconcile(
+					ctx, rbg, &runtimeController, &watchedWorkload, apiReaderBuilder.Build(),
+				)
+				if tt.expectErr {
+					assert.Error(t, err)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectAdmitted, admission.Admitted)
+				assert.Equal(t, tt.expectEvicted, admission.Evicted)
+				assert.Equal(t, tt.expectNodeSelectors, admission.NodeSelectors)
+
+				list := &unstructured.UnstructuredList{}
+				list.SetGroupVersionKind(WorkloadGVK.GroupVersion().WithKind("WorkloadList"))
+				assert.NoError(t, fakeClient.List(ctx, list, client.InNamespace(rbg.Namespace)))
+				var counts []map[string]int64
+				for _, workload := range list.Items {
+					podSets, _, _ := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+					counts = append(counts, podSetCounts(podSets))
+					queueName, _, _ := unstructured.NestedString(workload.Object, "spec", "queueName")
+					assert.Equal(t, "gpu-queue", queueName)
+				}
+				assert.ElementsMatch(t, tt.expectCounts, counts)
+			},
+		)
+	}
+}
+
+func TestInjectNodeSelectors(t *testing.T) {
+	rbg := buildQueuedRBG()
+	rbg.Spec.Roles[0].Template.Spec.NodeSelector = map[string]string{"pool": "inference"}
+
+	InjectNodeSelectors(rbg, map[string]map[string]string{
+		"prefill": {"gpu-type": "a10"},
+		"decode":  {"gpu-type": "h800"},
+	})
+
+	assert.Equal(t, map[string]string{"pool": "inference", "gpu-type": "a10"}, rbg.Spec.Roles[0].Template.Spec.NodeSelector)
+	assert.Equal(t, map[string]string{"gpu-type": "h800"}, rbg.Spec.Roles[1].Template.Spec.NodeSelector)
+}
@@ -0,0 +1,462 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"time"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	kruiseappsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/utils"
+)
+
+func init() {
+	utilruntime.Must(RegisterWorkloadPlugin(WorkloadPlugin{
+		GroupVersionKind: kruiseappsv1beta1.GroupVersion.WithKind("StatefulSet"),
+		CRDName:          utils.AdvancedStatefulSetCrdName,
+		NewObject:        func() client.Object { return &kruiseappsv1beta1.StatefulSet{} },
+		NewReconciler: func(scheme *runtime.Scheme, client client.Client) WorkloadReconciler {
+			return NewAdvancedStatefulSetReconciler(scheme, client)
+		},
+		Equal: func(obj1, obj2 client.Object) (bool, error) {
+			o1, ok1 := obj1.(*kruiseappsv1beta1.StatefulSet)
+			o2, ok2 := obj2.(*kruiseappsv1beta1.StatefulSet)
+			if !ok1 || !ok2 {
+				return false, fmt.Errorf("not an advanced statefulset")
+			}
+			return semanticallyEqualAdvancedStatefulSet(o1, o2, true)
+		},
+	}))
+}
+
+// AdvancedStatefulSetReconciler reconciles the OpenKruise Advanced StatefulSets of roles, which update the pods in
+// place when only their images or metadata change, keeping them on their nodes.
+type AdvancedStatefulSetReconciler struct {
+	scheme *runtime.Scheme
+	client client.Client
+}
+
+var _ WorkloadReconciler = &AdvancedStatefulSetReconciler{}
+
+func NewAdvancedStatefulSetReconciler(scheme *runtime.Scheme, client client.Client) *AdvancedStatefulSetReconciler {
+	return &AdvancedStatefulSetReconciler{
+		scheme: scheme,
+		client: client,
+	}
+}
+
+func (r *AdvancedStatefulSetReconciler) Reconciler(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+	revisionKey string,
+) error {
+	if err := r.reconcileStatefulSet(ctx, rbg, role, revisionKey); err != nil {
+		return err
+	}
+
+	sts := &kruiseappsv1beta1.StatefulSet{}
+	if err := r.client.Get(
+		ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, sts,
+	); err != nil {
+		return fmt.Errorf("get advanced sts error, skip reconcile svc. error:  %s", err.Error())
+	}
+	sts.SetGroupVersionKind(kruiseappsv1beta1.GroupVersion.WithKind("StatefulSet"))
+	return NewStatefulSetReconciler(r.scheme, r.client).reconcileHeadlessServiceOf(ctx, rbg, role, sts)
+}
+
+func (r *AdvancedStatefulSetReconciler) reconcileStatefulSet(
+	ctx context.Context,
+	rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+	revisionKey string,
+) error {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("start to reconciling advanced sts workload")
+
+	rollingStrategy, err := validateRolloutStrategy(role.RolloutStrategy, int(*role.Replicas))
+	if err != nil {
+		logger.Error(err, "Invalid rollout strategy")
+		return err
+	}
+	role.RolloutStrategy = rollingStrategy
+
+	oldSts := &kruiseappsv1beta1.StatefulSet{}
+	err = r.client.Get(ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, oldSts)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	newSts, err := r.constructStatefulSet(ctx, rbg, role, oldSts, revisionKey)
+	if err != nil {
+		logger.Error(err, "Failed to construct advanced statefulset")
+		return err
+	}
+
+	// the err value was used to pass the differences between the old and new objects,
+	// not to indicate an actual processing error.
+	semanticallyEqual, err := semanticallyEqualAdvancedStatefulSet(oldSts, newSts, false)
+	if err != nil {
+		logger.Info(fmt.Sprintf("advanced sts not equal, diff: %s", err.Error()))
+	}
+
+	roleHashKey := fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, role.Name)
+	revisionHashEqual := newSts.Labels[roleHashKey] == oldSts.Labels[roleHashKey]
+	if !revisionHashEqual {
+		logger.Info(
+			fmt.Sprintf(
+				"advanced sts hash not equal, old: %s, new: %s",
+				oldSts.Labels[roleHashKey], newSts.Labels[roleHashKey],
+			),
+		)
+	}
+
+	replicas, partition, err := advancedStatefulSetRollout(role, oldSts, !semanticallyEqual || !revisionHashEqual)
+	if err != nil {
+		return err
+	}
+	newSts.Spec.Replicas = ptr.To(replicas)
+	newSts.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To(partition)
+
+	if semanticallyEqual && revisionHashEqual && *oldSts.Spec.Replicas == replicas &&
+		reflect.DeepEqual(oldSts.Spec.UpdateStrategy, newSts.Spec.UpdateStrategy) {
+		logger.Info("advanced sts equal, skip reconcile")
+		return nil
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newSts)
+	if err != nil {
+		logger.Error(err, "Converting advanced sts to json.")
+		return err
+	}
+	// the status is not owned by the rbg controller
+	delete(obj, "status")
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	if err := utils.PatchObjectApplyConfiguration(
+		ctx, r.client, &unstructured.Unstructured{Object: obj}, utils.PatchSpec,
+	); err != nil {
+		logger.Error(err, "Failed to patch advanced statefulset")
+		return err
+	}
+	return nil
+}
+
+// advancedStatefulSetRollout returns the replicas and partition of the Advanced StatefulSet of a role.
+// Advanced StatefulSets update the pods from the highest ordinal down to the partition, at most maxUnavailable at
+// once, but have no maxSurge. It is emulated by scaling the workload up by maxSurge pods of the new revision while
+// a rolling update is in progress, and holding the update of the existing pods until these are ready.
+// The surge pods are removed once all pods are updated, so with a partition they remain until it is reset to 0.
+func advancedStatefulSetRollout(
+	role *workloadsv1alpha1.RoleSpec, sts *kruiseappsv1beta1.StatefulSet, stsUpdated bool,
+) (replicas int32, partition int32, err error) {
+	replicas = *role.Replicas
+	partition = *role.RolloutStrategy.RollingUpdate.Partition
+	if sts.UID == "" {
+		return replicas, partition, nil
+	}
+
+	maxSurge, err := intstr.GetScaledValueFromIntOrPercent(
+		&role.RolloutStrategy.RollingUpdate.MaxSurge, int(replicas), true,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	// No need to burst more than the replicas.
+	surge := int32(min(maxSurge, int(replicas)))
+	if surge == 0 {
+		return replicas, partition, nil
+	}
+
+	statusOutdated := sts.Status.ObservedGeneration < sts.Generation
+	rolling := stsUpdated || sts.Status.CurrentRevision != sts.Status.UpdateRevision ||
+		// keep the surge pods until the status reflects the last update
+		(statusOutdated && *sts.Spec.Replicas > replicas)
+	if !rolling {
+		return replicas, partition, nil
+	}
+
+	// the surge pods are of the new revision, they are the only updated pods until they are ready
+	if stsUpdated || statusOutdated || sts.Status.UpdatedReadyReplicas < surge {
+		partition = max(partition, replicas)
+	}
+	return replicas + surge, partition, nil
+}
+
+func (r *AdvancedStatefulSetReconciler) constructStatefulSet(
+	ctx context.Context,
+	rbg *workloadsv1alpha1.RoleBasedGroup,
+	role *workloadsv1alpha1.RoleSpec,
+	oldSts *kruiseappsv1beta1.StatefulSet,
+	revisionKey string,
+) (*kruiseappsv1beta1.StatefulSet, error) {
+	matchLabels := rbg.GetCommonLabelsFromRole(role)
+	if oldSts.UID != "" {
+		// do not update selector when workload exists
+		matchLabels = oldSts.Spec.Selector.MatchLabels
+	}
+
+	podReconciler := NewPodReconciler(r.scheme, r.client)
+	podTemplateApplyConfiguration, err := podReconciler.ConstructPodTemplateSpecApplyConfiguration(
+		ctx, rbg, role, maps.Clone(matchLabels),
+	)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(podTemplateApplyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	podTemplate := corev1.PodTemplateSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &podTemplate); err != nil {
+		return nil, err
+	}
+	setInPlaceUpdateReadinessGate(&podTemplate)
+
+	stsLabel := maps.Clone(matchLabels)
+	stsLabel[fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, role.Name)] = revisionKey
+
+	maxUnavailable := role.RolloutStrategy.RollingUpdate.MaxUnavailable
+	if unavailable, _ := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(*role.Replicas), false); unavailable == 0 {
+		// Kruise requires a positive maxUnavailable, the surge pods are ready before any pod is updated
+		maxUnavailable = intstr.FromInt32(1)
+	}
+	sts := &kruiseappsv1beta1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kruiseappsv1beta1.GroupVersion.String(),
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        rbg.GetWorkloadName(role),
+			Namespace:   rbg.Namespace,
+			Labels:      stsLabel,
+			Annotations: rbg.GetCommonAnnotationsFromRole(role),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         rbg.APIVersion,
+					Kind:               rbg.Kind,
+					Name:               rbg.Name,
+					UID:                rbg.GetUID(),
+					BlockOwnerDeletion: ptr.To(true),
+					Controller:         ptr.To(true),
+				},
+			},
+		},
+		Spec: kruiseappsv1beta1.StatefulSetSpec{
+			ServiceName:         rbg.GetWorkloadName(role),
+			Replicas:            ptr.To(*role.Replicas),
+			Template:            podTemplate,
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			Selector:            &metav1.LabelSelector{MatchLabels: matchLabels},
+			UpdateStrategy: kruiseappsv1beta1.StatefulSetUpdateStrategy{
+				Type: appsv1.StatefulSetUpdateStrategyType(role.RolloutStrategy.Type),
+				RollingUpdate: &kruiseappsv1beta1.RollingUpdateStatefulSetStrategy{
+					Partition:       ptr.To(*role.RolloutStrategy.RollingUpdate.Partition),
+					MaxUnavailable:  &maxUnavailable,
+					PodUpdatePolicy: kruiseappsv1beta1.InPlaceIfPossiblePodUpdateStrategyType,
+				},
+			},
+		},
+	}
+	return sts, nil
+}
+
+// setInPlaceUpdateReadinessGate adds the InPlaceUpdateReady readiness gate Advanced StatefulSets require to update
+// pods in place, keeping the pods not ready while they are updated.
+func setInPlaceUpdateReadinessGate(pod *corev1.PodTemplateSpec) {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == appspub.InPlaceUpdateReady {
+			return
+		}
+	}
+	pod.Spec.ReadinessGates = append(
+		pod.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: appspub.InPlaceUpdateReady},
+	)
+}
+
+// ConstructRoleStatus builds the status of the role from the pod status Kruise reports. Pods being updated in place
+// are not ready until their InPlaceUpdateReady condition is restored.
+func (r *AdvancedStatefulSetReconciler) ConstructRoleStatus(
+	ctx context.Context,
+	rbg *workloadsv1alpha1.RoleBasedGroup,
+	role *workloadsv1alpha1.RoleSpec,
+) (workloadsv1alpha1.RoleStatus, bool, error) {
+	updateStatus := false
+	sts := &kruiseappsv1beta1.StatefulSet{}
+	if err := r.client.Get(
+		ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, sts,
+	); err != nil {
+		return workloadsv1alpha1.RoleStatus{}, updateStatus, err
+	}
+
+	currentReplicas := *sts.Spec.Replicas
+	currentReady := sts.Status.ReadyReplicas
+	status, found := rbg.GetRoleStatus(role.Name)
+	if !found || status.Replicas != currentReplicas || status.ReadyReplicas != currentReady {
+		status = workloadsv1alpha1.RoleStatus{
+			Name:          role.Name,
+			Replicas:      currentReplicas,
+			ReadyReplicas: currentReady,
+		}
+		updateStatus = true
+	}
+	return status, updateStatus, nil
+}
+
+func (r *AdvancedStatefulSetReconciler) CheckWorkloadReady(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) (bool, error) {
+	sts := &kruiseappsv1beta1.StatefulSet{}
+	if err := r.client.Get(
+		ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, sts,
+	); err != nil {
+		return false, err
+	}
+	return sts.Status.ReadyReplicas == *sts.Spec.Replicas, nil
+}
+
+func (r *AdvancedStatefulSetReconciler) CleanupOrphanedWorkloads(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+) error {
+	logger := log.FromContext(ctx)
+	// list advanced sts managed by rbg
+	stsList := &kruiseappsv1beta1.StatefulSetList{}
+	if err := r.client.List(
+		ctx, stsList, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels(
+			map[string]string{
+				workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			},
+		),
+	); err != nil {
+		return err
+	}
+
+	for i := range stsList.Items {
+		sts := &stsList.Items[i]
+		if !metav1.IsControlledBy(sts, rbg) {
+			continue
+		}
+		found := false
+		for _, role := range rbg.Spec.Roles {
+			if role.Workload.String() == workloadsv1alpha1.AdvancedStatefulSetWorkloadType &&
+				rbg.GetWorkloadName(&role) == sts.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			if err := r.client.Delete(ctx, sts); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("delete advanced sts %s error: %s", sts.Name, err.Error())
+			}
+			// The deletion of headless services depends on its own reference
+			logger.Info("delete advanced sts", "sts", sts.Name)
+		}
+	}
+	return nil
+}
+
+func (r *AdvancedStatefulSetReconciler) RecreateWorkload(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) error {
+	logger := log.FromContext(ctx)
+	if rbg == nil || role == nil {
+		return nil
+	}
+
+	stsName := rbg.GetWorkloadName(role)
+	var sts kruiseappsv1beta1.StatefulSet
+	err := r.client.Get(ctx, types.NamespacedName{Name: stsName, Namespace: rbg.Namespace}, &sts)
+	// if sts is not found, skip delete sts
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Recreate advanced sts workload, delete sts %s", stsName))
+	if err := r.client.Delete(ctx, &sts); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// wait new sts create
+	var retErr error
+	err = wait.PollUntilContextTimeout(
+		ctx, 5*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+			var newSts kruiseappsv1beta1.StatefulSet
+			retErr = r.client.Get(ctx, types.NamespacedName{Name: stsName, Namespace: rbg.Namespace}, &newSts)
+			if retErr != nil {
+				if apierrors.IsNotFound(retErr) {
+					return false, nil
+				}
+				return false, retErr
+			}
+			return newSts.UID != sts.UID, nil
+		},
+	)
+
+	if err != nil {
+		logger.Error(retErr, "wait new advanced sts creating error")
+		return retErr
+	}
+
+	return nil
+}
+
+func semanticallyEqualAdvancedStatefulSet(
+	oldSts, newSts *kruiseappsv1beta1.StatefulSet, checkStatus bool,
+) (bool, error) {
+	if oldSts == nil || oldSts.UID == "" {
+		return false, errors.New("old advanced sts not exist")
+	}
+	if newSts == nil {
+		return false, fmt.Errorf("new advanced sts is nil")
+	}
+
+	if equal, err := objectMetaEqual(oldSts.ObjectMeta, newSts.ObjectMeta); !equal {
+		return false, fmt.Errorf("objectMeta not equal: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(oldSts.Spec.Selector, newSts.Spec.Selector) {
+		return false, fmt.Errorf(
+			"selector not equal, old: %v, new: %v", oldSts.Spec.Selector, newSts.Spec.Selector,
+		)
+	}
+	if oldSts.Spec.ServiceName != newSts.Spec.ServiceName {
+		return false, fmt.Errorf(
+			"serviceName not equal, old: %s, new: %s", oldSts.Spec.ServiceName, newSts.Spec.ServiceName,
+		)
+	}
+	if equal, err := podTemplateSpecEqual(oldSts.Spec.Template, newSts.Spec.Template); !equal {
+		return false, fmt.Errorf("podTemplateSpec not equal, %s", err.Error())
+	}
+
+	if checkStatus {
+		oldStatus, newStatus := oldSts.Status, newSts.Status
+		if oldStatus.Replicas != newStatus.Replicas || oldStatus.ReadyReplicas != newStatus.ReadyReplicas ||
+			oldStatus.UpdatedReadyReplicas != newStatus.UpdatedReadyReplicas ||
+			oldStatus.CurrentRevision != newStatus.CurrentRevision {
+			return false, fmt.Errorf(
+				"status not equal, old: %d/%d ready, %d updated ready, revision %s, "+
+					"new: %d/%d ready, %d updated ready, revision %s",
+				oldStatus.ReadyReplicas, oldStatus.Replicas, oldStatus.UpdatedReadyReplicas, oldStatus.CurrentRevision,
+				newStatus.ReadyReplicas, newStatus.Replicas, newStatus.UpdatedReadyReplicas, newStatus.CurrentRevision,
+			)
+		}
+	}
+	return true, nil
+}
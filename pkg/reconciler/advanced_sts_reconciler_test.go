@@ -0,0 +1,150 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	kruiseappsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestAdvancedStatefulSetReconciler_Reconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = kruiseappsv1beta1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+
+	role := wrappers.BuildBasicRole("decode").WithReplicas(4).Obj()
+	role.Workload = workloadsv1alpha1.WorkloadSpec{APIVersion: "apps.kruise.io/v1beta1", Kind: "StatefulSet"}
+	role.RolloutStrategy = &workloadsv1alpha1.RolloutStrategy{
+		Type: workloadsv1alpha1.RollingUpdateStrategyType,
+		RollingUpdate: &workloadsv1alpha1.RollingUpdate{
+			Partition:      ptr.To[int32](1),
+			MaxUnavailable: intstr.FromString("50%"),
+		},
+	}
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{role}).Obj()
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rbg).Build()
+	r, err := NewWorkloadReconciler(role.Workload, scheme, c)
+	assert.NoError(t, err)
+	assert.IsType(t, &AdvancedStatefulSetReconciler{}, r)
+	assert.NoError(t, r.Reconciler(ctx, rbg, &rbg.Spec.Roles[0], expectedRevisionHash))
+
+	sts := &kruiseappsv1beta1.StatefulSet{}
+	assert.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-rbg-decode", Namespace: "default"}, sts))
+	assert.Equal(t, ptr.To[int32](4), sts.Spec.Replicas)
+	rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate
+	assert.Equal(t, kruiseappsv1beta1.InPlaceIfPossiblePodUpdateStrategyType, rollingUpdate.PodUpdatePolicy)
+	assert.Equal(t, ptr.To[int32](1), rollingUpdate.Partition)
+	assert.Equal(t, ptr.To(intstr.FromString("50%")), rollingUpdate.MaxUnavailable)
+	assert.Contains(
+		t, sts.Spec.Template.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: appspub.InPlaceUpdateReady},
+	)
+
+	svc := &corev1.Service{}
+	assert.NoError(t, c.Get(ctx, types.NamespacedName{Name: rbg.GetServiceName(&role), Namespace: "default"}, svc))
+	assert.Equal(t, "StatefulSet", svc.OwnerReferences[0].Kind)
+	assert.Equal(t, "apps.kruise.io/v1beta1", svc.OwnerReferences[0].APIVersion)
+
+	// the role status is built from the pod status reported by kruise
+	sts.Status = kruiseappsv1beta1.StatefulSetStatus{Replicas: 4, ReadyReplicas: 3}
+	assert.NoError(t, c.Update(ctx, sts))
+	status, update, err := r.ConstructRoleStatus(ctx, rbg, &rbg.Spec.Roles[0])
+	assert.NoError(t, err)
+	assert.True(t, update)
+	assert.Equal(t, workloadsv1alpha1.RoleStatus{Name: "decode", Replicas: 4, ReadyReplicas: 3}, status)
+	ready, err := r.CheckWorkloadReady(ctx, rbg, &rbg.Spec.Roles[0])
+	assert.NoError(t, err)
+	assert.False(t, ready)
+}
+
+func TestAdvancedStatefulSetRollout(t *testing.T) {
+	role := wrappers.BuildBasicRole("decode").WithReplicas(4).Obj()
+	role.RolloutStrategy = &workloadsv1alpha1.RolloutStrategy{
+		Type: workloadsv1alpha1.RollingUpdateStrategyType,
+		RollingUpdate: &workloadsv1alpha1.RollingUpdate{
+			Partition:      ptr.To[int32](0),
+			MaxUnavailable: intstr.FromInt32(1),
+			MaxSurge:       intstr.FromInt32(1),
+		},
+	}
+	buildSts := func(replicas int32, status kruiseappsv1beta1.StatefulSetStatus) *kruiseappsv1beta1.StatefulSet {
+		return &kruiseappsv1beta1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{UID: "sts-uid", Generation: 2},
+			Spec:       kruiseappsv1beta1.StatefulSetSpec{Replicas: ptr.To(replicas)},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name            string
+		sts             *kruiseappsv1beta1.StatefulSet
+		stsUpdated      bool
+		expectReplicas  int32
+		expectPartition int32
+	}{
+		{
+			name:            "creating",
+			sts:             &kruiseappsv1beta1.StatefulSet{},
+			stsUpdated:      true,
+			expectReplicas:  4,
+			expectPartition: 0,
+		},
+		{
+			name: "update started",
+			sts: buildSts(4, kruiseappsv1beta1.StatefulSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "v1", UpdateRevision: "v1", UpdatedReadyReplicas: 4,
+			}),
+			stsUpdated:      true,
+			expectReplicas:  5,
+			expectPartition: 4,
+		},
+		{
+			name: "surge pod not ready",
+			sts: buildSts(5, kruiseappsv1beta1.StatefulSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "v1", UpdateRevision: "v2", UpdatedReadyReplicas: 0,
+			}),
+			expectReplicas:  5,
+			expectPartition: 4,
+		},
+		{
+			name: "surge pod ready",
+			sts: buildSts(5, kruiseappsv1beta1.StatefulSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "v1", UpdateRevision: "v2", UpdatedReadyReplicas: 1,
+			}),
+			expectReplicas:  5,
+			expectPartition: 0,
+		},
+		{
+			name: "update completed",
+			sts: buildSts(5, kruiseappsv1beta1.StatefulSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "v2", UpdateRevision: "v2", UpdatedReadyReplicas: 5,
+			}),
+			expectReplicas:  4,
+			expectPartition: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replicas, partition, err := advancedStatefulSetRollout(&role, tt.sts, tt.stsUpdated)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectReplicas, replicas)
+			assert.Equal(t, tt.expectPartition, partition)
+		})
+	}
+}
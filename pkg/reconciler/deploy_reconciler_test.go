@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -163,6 +164,51 @@ func TestDeploymentReconciler_Reconciler(t *testing.T) {
 	}
 }
 
+// TestDeploymentReconciler_Reconciler_RBGSetLabel tests that the workload of an existing rbg is updated once the
+// rbgset name label is propagated to the pods.
+func TestDeploymentReconciler_Reconciler_RBGSetLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	rbg := &workloadsv1alpha1.RoleBasedGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rbgset-0",
+			Namespace: "default",
+			UID:       "test-uid",
+		},
+		Spec: workloadsv1alpha1.RoleBasedGroupSpec{
+			Roles: []workloadsv1alpha1.RoleSpec{
+				{
+					Name:     "test-role",
+					Replicas: ptr.To(int32(1)),
+					Workload: workloadsv1alpha1.WorkloadSpec{APIVersion: "apps/v1", Kind: "Deployment"},
+				},
+			},
+		},
+	}
+	role := &rbg.Spec.Roles[0]
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &DeploymentReconciler{scheme: scheme, client: fakeClient}
+	ctx := context.Background()
+
+	// The workload was created before the label was propagated.
+	assert.NoError(t, r.Reconciler(ctx, rbg, role, expectedRevisionHash))
+	deploy := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}
+	assert.NoError(t, fakeClient.Get(ctx, key, deploy))
+	assert.NotContains(t, deploy.Spec.Template.Labels, workloadsv1alpha1.SetRBGSetNameLabelKey)
+	// the fake client does not set the uid of applied objects, which are taken as not created otherwise
+	deploy.UID = "deploy-uid"
+	assert.NoError(t, fakeClient.Update(ctx, deploy))
+
+	rbg.Labels = map[string]string{workloadsv1alpha1.SetRBGSetNameLabelKey: "test-rbgset"}
+	assert.NoError(t, r.Reconciler(ctx, rbg, role, expectedRevisionHash))
+	assert.NoError(t, fakeClient.Get(ctx, key, deploy))
+	assert.Equal(t, "test-rbgset", deploy.Spec.Template.Labels[workloadsv1alpha1.SetRBGSetNameLabelKey])
+}
+
 // TestDeploymentReconciler_CheckWorkloadReady tests the CheckWorkloadReady method of DeploymentReconciler
 func TestDeploymentReconciler_CheckWorkloadReady(t *testing.T) {
 	scheme := runtime.NewScheme()
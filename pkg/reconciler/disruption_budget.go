@@ -0,0 +1,210 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	metaapplyv1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	policyapplyv1 "k8s.io/client-go/applyconfigurations/policy/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/utils"
+)
+
+// DisruptionBudgetReconciler creates the PodDisruptionBudgets of the roles of a rbg declaring a disruption budget,
+// and deletes the ones no longer declared.
+type DisruptionBudgetReconciler struct {
+	scheme *runtime.Scheme
+	client client.Client
+}
+
+func NewDisruptionBudgetReconciler(scheme *runtime.Scheme, client client.Client) *DisruptionBudgetReconciler {
+	return &DisruptionBudgetReconciler{scheme: scheme, client: client}
+}
+
+// Reconcile applies the PodDisruptionBudget of a role, named after its workload.
+func (r *DisruptionBudgetReconciler) Reconcile(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) error {
+	if role.DisruptionBudget == nil {
+		return nil
+	}
+	minAvailable, maxUnavailable, err := podDisruptionBudgetOf(role)
+	if err != nil {
+		return fmt.Errorf("invalid disruption budget of role %s: %w", role.Name, err)
+	}
+
+	specConfig := policyapplyv1.PodDisruptionBudgetSpec().
+		WithSelector(
+			metaapplyv1.LabelSelector().WithMatchLabels(map[string]string{
+				workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+				workloadsv1alpha1.SetRoleLabelKey: role.Name,
+			}),
+		)
+	if minAvailable != nil {
+		specConfig = specConfig.WithMinAvailable(*minAvailable)
+	} else {
+		specConfig = specConfig.WithMaxUnavailable(*maxUnavailable)
+	}
+	pdbConfig := policyapplyv1.PodDisruptionBudget(rbg.GetWorkloadName(role), rbg.Namespace).
+		WithSpec(specConfig).
+		WithLabels(rbg.GetCommonLabelsFromRole(role)).
+		WithOwnerReferences(
+			metaapplyv1.OwnerReference().
+				WithAPIVersion(rbg.APIVersion).
+				WithKind(rbg.Kind).
+				WithName(rbg.Name).
+				WithUID(rbg.GetUID()).
+				WithBlockOwnerDeletion(true).
+				WithController(true),
+		)
+	return utils.PatchObjectApplyConfiguration(ctx, r.client, pdbConfig, utils.PatchSpec)
+}
+
+// CleanupOrphanedDisruptionBudgets deletes the PodDisruptionBudgets of removed roles and of roles no longer
+// declaring a disruption budget.
+func (r *DisruptionBudgetReconciler) CleanupOrphanedDisruptionBudgets(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+) error {
+	logger := log.FromContext(ctx)
+	desired := map[string]bool{}
+	for i := range rbg.Spec.Roles {
+		if rbg.Spec.Roles[i].DisruptionBudget != nil {
+			desired[rbg.GetWorkloadName(&rbg.Spec.Roles[i])] = true
+		}
+	}
+
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	if err := r.client.List(
+		ctx, pdbList, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{workloadsv1alpha1.SetNameLabelKey: rbg.Name},
+	); err != nil {
+		return err
+	}
+	for i := range pdbList.Items {
+		pdb := &pdbList.Items[i]
+		if desired[pdb.Name] || !metav1.IsControlledBy(pdb, rbg) {
+			continue
+		}
+		logger.Info("delete PodDisruptionBudget", "podDisruptionBudget", pdb.Name)
+		if err := r.client.Delete(ctx, pdb); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete PodDisruptionBudget %s error: %w", pdb.Name, err)
+		}
+	}
+	return nil
+}
+
+// RoleInstanceDisruptions returns the group indexes of the healthy instances of a LeaderWorkerSet role, i.e. whose
+// pods are all running, ready and not being disrupted, and the number of healthy instances the disruption budget of
+// the role allows to disrupt, which is negative once more instances than allowed are disrupted.
+func RoleInstanceDisruptions(
+	ctx context.Context, c client.Client, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) (healthy sets.Set[string], disruptionsAllowed int, err error) {
+	if _, _, err := podDisruptionBudgetOf(role); err != nil {
+		return nil, 0, fmt.Errorf("invalid disruption budget of role %s: %w", role.Name, err)
+	}
+	replicas := int(ptr.Deref(role.Replicas, 1))
+	budget := role.DisruptionBudget
+	allowed := 0
+	if budget.MaxUnavailable != nil {
+		allowed, err = intstr.GetScaledValueFromIntOrPercent(budget.MaxUnavailable, replicas, true)
+	} else {
+		allowed, err = intstr.GetScaledValueFromIntOrPercent(budget.MinAvailable, replicas, true)
+		allowed = replicas - allowed
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid disruption budget of role %s: %w", role.Name, err)
+	}
+
+	podList := &corev1.PodList{}
+	if err := c.List(
+		ctx, podList, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{
+			workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			workloadsv1alpha1.SetRoleLabelKey: role.Name,
+		},
+	); err != nil {
+		return nil, 0, err
+	}
+	healthyPods := map[string]int{}
+	unhealthy := sets.New[string]()
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		groupIndex, found := pod.Labels[lwsv1.GroupIndexLabelKey]
+		if !found {
+			continue
+		}
+		if pod.DeletionTimestamp != nil || !utils.PodRunningAndReady(*pod) || podDisruptionTarget(pod) {
+			unhealthy.Insert(groupIndex)
+			continue
+		}
+		healthyPods[groupIndex]++
+	}
+	size := int(ptr.Deref(role.LeaderWorkerSet.Size, 1))
+	healthy = sets.New[string]()
+	for groupIndex, count := range healthyPods {
+		if count >= size && !unhealthy.Has(groupIndex) {
+			healthy.Insert(groupIndex)
+		}
+	}
+	// the missing instances count as disrupted, like the missing pods of a PodDisruptionBudget
+	return healthy, allowed - max(replicas-healthy.Len(), 0), nil
+}
+
+// podDisruptionTarget returns true if the pod is about to be terminated by a disruption, e.g. the eviction API.
+func podDisruptionTarget(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podDisruptionBudgetOf converts the disruption budget of a role, counted in instances, to pods. The instances of
+// LeaderWorkerSet roles are groups of leader and workers: percentages are resolved against the replicas of the role
+// and scaled by the size of the groups. A PodDisruptionBudget only counts pods, so the pods of the budget may be taken
+// from different groups; the pod webhook enforces the budget in groups, see RoleInstanceDisruptions.
+func podDisruptionBudgetOf(
+	role *workloadsv1alpha1.RoleSpec,
+) (minAvailable, maxUnavailable *intstr.IntOrString, err error) {
+	budget := role.DisruptionBudget
+	if (budget.MinAvailable == nil) == (budget.MaxUnavailable == nil) {
+		return nil, nil, fmt.Errorf("exactly one of minAvailable and maxUnavailable must be set")
+	}
+	size := int32(1)
+	if role.Workload.String() == workloadsv1alpha1.LeaderWorkerSetWorkloadType {
+		size = ptr.Deref(role.LeaderWorkerSet.Size, 1)
+	}
+	if size == 1 {
+		return budget.MinAvailable, budget.MaxUnavailable, nil
+	}
+
+	toPods := func(instances *intstr.IntOrString) (*intstr.IntOrString, error) {
+		if instances == nil {
+			return nil, nil
+		}
+		// the disruption controller rounds both minAvailable and maxUnavailable percentages up
+		count, err := intstr.GetScaledValueFromIntOrPercent(instances, int(ptr.Deref(role.Replicas, 1)), true)
+		if err != nil {
+			return nil, err
+		}
+		return ptr.To(intstr.FromInt32(int32(count) * size)), nil
+	}
+	if minAvailable, err = toPods(budget.MinAvailable); err != nil {
+		return nil, nil, err
+	}
+	if maxUnavailable, err = toPods(budget.MaxUnavailable); err != nil {
+		return nil, nil, err
+	}
+	return minAvailable, maxUnavailable, nil
+}
@@ -0,0 +1,214 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func Test_podDisruptionBudgetOf(t *testing.T) {
+	lwsRole := wrappers.BuildLwsRole("decode").WithReplicas(3).Obj()
+	lwsRole.LeaderWorkerSet.Size = ptr.To[int32](4)
+	stsRole := wrappers.BuildBasicRole("prefill").WithReplicas(3).Obj()
+
+	tests := []struct {
+		name                 string
+		role                 workloadsv1alpha1.RoleSpec
+		budget               workloadsv1alpha1.RoleDisruptionBudget
+		expectMinAvailable   *intstr.IntOrString
+		expectMaxUnavailable *intstr.IntOrString
+		expectErr            bool
+	}{
+		{
+			name:               "pods are instances",
+			role:               stsRole,
+			budget:             workloadsv1alpha1.RoleDisruptionBudget{MinAvailable: ptr.To(intstr.FromString("50%"))},
+			expectMinAvailable: ptr.To(intstr.FromString("50%")),
+		},
+		{
+			name:                 "groups of a lws",
+			role:                 lwsRole,
+			budget:               workloadsv1alpha1.RoleDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromInt32(1))},
+			expectMaxUnavailable: ptr.To(intstr.FromInt32(4)),
+		},
+		{
+			name:               "percentage of the groups of a lws",
+			role:               lwsRole,
+			budget:             workloadsv1alpha1.RoleDisruptionBudget{MinAvailable: ptr.To(intstr.FromString("50%"))},
+			expectMinAvailable: ptr.To(intstr.FromInt32(8)),
+		},
+		{
+			name: "both set",
+			role: stsRole,
+			budget: workloadsv1alpha1.RoleDisruptionBudget{
+				MinAvailable: ptr.To(intstr.FromInt32(1)), MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+			},
+			expectErr: true,
+		},
+		{
+			name:      "none set",
+			role:      stsRole,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := tt.role.DeepCopy()
+			role.DisruptionBudget = &tt.budget
+			minAvailable, maxUnavailable, err := podDisruptionBudgetOf(role)
+			assert.Equal(t, tt.expectErr, err != nil)
+			assert.Equal(t, tt.expectMinAvailable, minAvailable)
+			assert.Equal(t, tt.expectMaxUnavailable, maxUnavailable)
+		})
+	}
+}
+
+func TestDisruptionBudgetReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
+	ctx := context.TODO()
+
+	decode := wrappers.BuildLwsRole("decode").WithReplicas(2).Obj()
+	decode.DisruptionBudget = &workloadsv1alpha1.RoleDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+	prefill := wrappers.BuildBasicRole("prefill").Obj()
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{decode, prefill}).Obj()
+	stale := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rbg.GetWorkloadName(&prefill),
+			Namespace: "default",
+			Labels:    rbg.GetCommonLabelsFromRole(&prefill),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(rbg, workloadsv1alpha1.GroupVersion.WithKind("RoleBasedGroup")),
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rbg, stale).Build()
+	r := NewDisruptionBudgetReconciler(scheme, c)
+
+	for i := range rbg.Spec.Roles {
+		assert.NoError(t, r.Reconcile(ctx, rbg, &rbg.Spec.Roles[i]))
+	}
+	pdb := &policyv1.PodDisruptionBudget{}
+	assert.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-rbg-decode", Namespace: "default"}, pdb))
+	assert.Equal(t, ptr.To(intstr.FromInt32(2)), pdb.Spec.MaxUnavailable)
+	assert.Equal(t, map[string]string{
+		workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+		workloadsv1alpha1.SetRoleLabelKey: "decode",
+	}, pdb.Spec.Selector.MatchLabels)
+	assert.True(t, metav1.IsControlledBy(pdb, rbg))
+
+	// the budget of the role no longer declaring one is deleted
+	assert.NoError(t, r.CleanupOrphanedDisruptionBudgets(ctx, rbg))
+	assert.Error(t, c.Get(ctx, types.NamespacedName{Name: "test-rbg-prefill", Namespace: "default"}, pdb))
+	assert.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-rbg-decode", Namespace: "default"}, pdb))
+
+	// and so are the budgets of removed roles
+	withoutRoles := rbg.DeepCopy()
+	withoutRoles.Spec.Roles = nil
+	assert.NoError(t, r.CleanupOrphanedDisruptionBudgets(ctx, withoutRoles))
+	assert.Error(t, c.Get(ctx, types.NamespacedName{Name: "test-rbg-decode", Namespace: "default"}, pdb))
+}
+
+func TestRoleInstanceDisruptions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	pod := func(name, groupIndex string, ready bool) *corev1.Pod {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+					workloadsv1alpha1.SetRoleLabelKey: "decode",
+					lwsv1.GroupIndexLabelKey:          groupIndex,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+	disrupted := pod("test-rbg-decode-2-1", "2", true)
+	disrupted.Status.Conditions = append(disrupted.Status.Conditions,
+		corev1.PodCondition{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue})
+
+	tests := []struct {
+		name                     string
+		budget                   workloadsv1alpha1.RoleDisruptionBudget
+		pods                     []client.Object
+		expectHealthy            sets.Set[string]
+		expectDisruptionsAllowed int
+	}{
+		{
+			name:   "all instances healthy",
+			budget: workloadsv1alpha1.RoleDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromInt32(1))},
+			pods: []client.Object{
+				pod("test-rbg-decode-0", "0", true), pod("test-rbg-decode-0-1", "0", true),
+				pod("test-rbg-decode-1", "1", true), pod("test-rbg-decode-1-1", "1", true),
+				pod("test-rbg-decode-2", "2", true), pod("test-rbg-decode-2-1", "2", true),
+			},
+			expectHealthy:            sets.New("0", "1", "2"),
+			expectDisruptionsAllowed: 1,
+		},
+		{
+			name:   "instance with a pod being disrupted",
+			budget: workloadsv1alpha1.RoleDisruptionBudget{MinAvailable: ptr.To(intstr.FromString("50%"))},
+			pods: []client.Object{
+				pod("test-rbg-decode-0", "0", true), pod("test-rbg-decode-0-1", "0", true),
+				pod("test-rbg-decode-1", "1", true), pod("test-rbg-decode-1-1", "1", true),
+				pod("test-rbg-decode-2", "2", true), disrupted,
+			},
+			expectHealthy:            sets.New("0", "1"),
+			expectDisruptionsAllowed: 0,
+		},
+		{
+			name:   "instances with a pod not ready or missing",
+			budget: workloadsv1alpha1.RoleDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromInt32(1))},
+			pods: []client.Object{
+				pod("test-rbg-decode-0", "0", true), pod("test-rbg-decode-0-1", "0", true),
+				pod("test-rbg-decode-1", "1", true), pod("test-rbg-decode-1-1", "1", false),
+				pod("test-rbg-decode-2", "2", true),
+			},
+			expectHealthy:            sets.New("0"),
+			expectDisruptionsAllowed: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := wrappers.BuildLwsRole("decode").WithReplicas(3).Obj()
+			role.LeaderWorkerSet.Size = ptr.To[int32](2)
+			role.DisruptionBudget = &tt.budget
+			rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles([]workloadsv1alpha1.RoleSpec{role}).Obj()
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.pods...).Build()
+
+			healthy, disruptionsAllowed, err := RoleInstanceDisruptions(context.TODO(), c, rbg, &rbg.Spec.Roles[0])
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectHealthy, healthy)
+			assert.Equal(t, tt.expectDisruptionsAllowed, disruptionsAllowed)
+		})
+	}
+}
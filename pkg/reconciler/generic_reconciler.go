@@ -0,0 +1,442 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/utils"
+)
+
+// Default paths of the fields of a workload declared by a GenericWorkloadConfig.
+const (
+	defaultReplicasPath      = ".spec.replicas"
+	defaultTemplatePath      = ".spec.template"
+	defaultSelectorPath      = ".spec.selector"
+	defaultReadyReplicasPath = ".status.readyReplicas"
+)
+
+// GenericWorkloadConfig declares a workload kind by the JSONPaths of its fields, for workloads shaped like a
+// Deployment: a number of replicas of a pod template, selected by a label selector.
+// Only field paths such as {.spec.replicas} are supported.
+type GenericWorkloadConfig struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// CRDName is the name of the CRD of the workload, e.g. clonesets.apps.kruise.io.
+	CRDName string `json:"crdName,omitempty"`
+	// ReplicasPath is the path of the desired replicas, defaults to {.spec.replicas}.
+	ReplicasPath string `json:"replicasPath,omitempty"`
+	// TemplatePath is the path of the pod template, defaults to {.spec.template}.
+	TemplatePath string `json:"templatePath,omitempty"`
+	// SelectorPath is the path of the label selector of the pods, defaults to {.spec.selector}.
+	SelectorPath string `json:"selectorPath,omitempty"`
+	// ReadyReplicasPath is the path of the ready replicas in the status, defaults to {.status.readyReplicas}.
+	ReadyReplicasPath string `json:"readyReplicasPath,omitempty"`
+}
+
+// genericWorkload is a GenericWorkloadConfig with its paths parsed into fields.
+type genericWorkload struct {
+	gvk           schema.GroupVersionKind
+	replicas      []string
+	template      []string
+	selector      []string
+	readyReplicas []string
+}
+
+// NewGenericWorkloadPlugin returns the plugin of a workload declared by a GenericWorkloadConfig.
+func NewGenericWorkloadPlugin(config GenericWorkloadConfig) (WorkloadPlugin, error) {
+	gv, err := schema.ParseGroupVersion(config.APIVersion)
+	if err != nil {
+		return WorkloadPlugin{}, err
+	}
+	if config.Kind == "" {
+		return WorkloadPlugin{}, fmt.Errorf("workload %s has no kind", config.APIVersion)
+	}
+	workload := &genericWorkload{gvk: gv.WithKind(config.Kind)}
+	for _, path := range []struct {
+		fields *[]string
+		path   string
+		def    string
+	}{
+		{&workload.replicas, config.ReplicasPath, defaultReplicasPath},
+		{&workload.template, config.TemplatePath, defaultTemplatePath},
+		{&workload.selector, config.SelectorPath, defaultSelectorPath},
+		{&workload.readyReplicas, config.ReadyReplicasPath, defaultReadyReplicasPath},
+	} {
+		if path.path == "" {
+			path.path = path.def
+		}
+		if *path.fields, err = parseFieldPath(path.path); err != nil {
+			return WorkloadPlugin{}, fmt.Errorf("workload %s: %s", config.Kind, err.Error())
+		}
+	}
+
+	suspendPatch := map[string]interface{}{}
+	if err := unstructured.SetNestedField(suspendPatch, int64(0), workload.replicas...); err != nil {
+		return WorkloadPlugin{}, err
+	}
+	suspendPatchData, err := json.Marshal(suspendPatch)
+	if err != nil {
+		return WorkloadPlugin{}, err
+	}
+
+	return WorkloadPlugin{
+		GroupVersionKind: workload.gvk,
+		CRDName:          config.CRDName,
+		NewObject:        workload.newObject,
+		NewReconciler: func(scheme *runtime.Scheme, client client.Client) WorkloadReconciler {
+			return &GenericWorkloadReconciler{workload: workload, scheme: scheme, client: client}
+		},
+		Equal:        workload.equal,
+		SuspendPatch: suspendPatchData,
+	}, nil
+}
+
+// parseFieldPath parses the JSONPath of a field, e.g. {.spec.replicas}, into its fields.
+func parseFieldPath(path string) ([]string, error) {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(path), "{"), "}")
+	if !strings.HasPrefix(trimmed, ".") {
+		return nil, fmt.Errorf("path %q must start with a dot", path)
+	}
+	fields := strings.Split(strings.TrimPrefix(trimmed, "."), ".")
+	for _, field := range fields {
+		if field == "" || strings.ContainsAny(field, "[]*@?()$'\"") {
+			return nil, fmt.Errorf("path %q is not a field path", path)
+		}
+	}
+	return fields, nil
+}
+
+func (w *genericWorkload) newObject() client.Object {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(w.gvk)
+	return obj
+}
+
+// equal determines whether a watched workload needs reconciliation, comparing the fields the rbg controller sets
+// and the ready replicas.
+func (w *genericWorkload) equal(obj1, obj2 client.Object) (bool, error) {
+	o1, ok1 := obj1.(*unstructured.Unstructured)
+	o2, ok2 := obj2.(*unstructured.Unstructured)
+	if !ok1 || !ok2 {
+		return false, fmt.Errorf("%s is not unstructured", w.gvk.Kind)
+	}
+	if equal, err := w.specEqual(o1, o2); !equal {
+		return false, err
+	}
+	ready1, _, _ := unstructured.NestedInt64(o1.Object, w.readyReplicas...)
+	ready2, _, _ := unstructured.NestedInt64(o2.Object, w.readyReplicas...)
+	if ready1 != ready2 {
+		return false, fmt.Errorf("ready replicas not equal, old: %d, new: %d", ready1, ready2)
+	}
+	return true, nil
+}
+
+// specEqual compares the metadata, replicas, selector and pod template of two workloads.
+func (w *genericWorkload) specEqual(oldObj, newObj *unstructured.Unstructured) (bool, error) {
+	if equal, err := objectMetaEqual(
+		metav1.ObjectMeta{Labels: oldObj.GetLabels(), Annotations: oldObj.GetAnnotations()},
+		metav1.ObjectMeta{Labels: newObj.GetLabels(), Annotations: newObj.GetAnnotations()},
+	); !equal {
+		return false, fmt.Errorf("objectMeta not equal: %s", err.Error())
+	}
+
+	replicas1, _, _ := unstructured.NestedInt64(oldObj.Object, w.replicas...)
+	replicas2, _, _ := unstructured.NestedInt64(newObj.Object, w.replicas...)
+	if replicas1 != replicas2 {
+		return false, fmt.Errorf("replicas not equal, old: %d, new: %d", replicas1, replicas2)
+	}
+
+	selector1, _, _ := unstructured.NestedStringMap(oldObj.Object, w.matchLabelsPath()...)
+	selector2, _, _ := unstructured.NestedStringMap(newObj.Object, w.matchLabelsPath()...)
+	if !reflect.DeepEqual(selector1, selector2) {
+		return false, fmt.Errorf("selector not equal, old: %v, new: %v", selector1, selector2)
+	}
+
+	template1, err := w.podTemplate(oldObj)
+	if err != nil {
+		return false, err
+	}
+	template2, err := w.podTemplate(newObj)
+	if err != nil {
+		return false, err
+	}
+	if equal, err := podTemplateSpecEqual(template1, template2); !equal {
+		return false, fmt.Errorf("podTemplateSpec not equal, %s", err.Error())
+	}
+	return true, nil
+}
+
+func (w *genericWorkload) matchLabelsPath() []string {
+	return append(append([]string{}, w.selector...), "matchLabels")
+}
+
+func (w *genericWorkload) podTemplate(obj *unstructured.Unstructured) (corev1.PodTemplateSpec, error) {
+	template := corev1.PodTemplateSpec{}
+	content, found, err := unstructured.NestedMap(obj.Object, w.template...)
+	if err != nil || !found {
+		return template, err
+	}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(content, &template)
+	return template, err
+}
+
+// GenericWorkloadReconciler reconciles the workloads declared by a GenericWorkloadConfig as unstructured objects.
+type GenericWorkloadReconciler struct {
+	workload *genericWorkload
+	scheme   *runtime.Scheme
+	client   client.Client
+}
+
+var _ WorkloadReconciler = &GenericWorkloadReconciler{}
+
+func (r *GenericWorkloadReconciler) getWorkload(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) (*unstructured.Unstructured, error) {
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(r.workload.gvk)
+	err := r.client.Get(ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, workload)
+	return workload, err
+}
+
+func (r *GenericWorkloadReconciler) Reconciler(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+	revisionKey string) error {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("start to reconciling workload", "kind", r.workload.gvk.Kind)
+
+	oldWorkload, err := r.getWorkload(ctx, rbg, role)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	newWorkload, err := r.constructWorkload(ctx, rbg, role, oldWorkload, revisionKey)
+	if err != nil {
+		logger.Error(err, "Failed to construct workload")
+		return err
+	}
+
+	if oldWorkload.GetUID() != "" {
+		// the err value was used to pass the differences between the old and new objects,
+		// not to indicate an actual processing error.
+		semanticallyEqual, err := r.workload.specEqual(oldWorkload, newWorkload)
+		if err != nil {
+			logger.Info(fmt.Sprintf("%s not equal, diff: %s", r.workload.gvk.Kind, err.Error()))
+		}
+		roleHashKey := fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, role.Name)
+		if semanticallyEqual && oldWorkload.GetLabels()[roleHashKey] == revisionKey {
+			logger.Info("workload equal, skip reconcile")
+			return nil
+		}
+	}
+
+	if err := utils.PatchObjectApplyConfiguration(ctx, r.client, newWorkload, utils.PatchSpec); err != nil {
+		logger.Error(err, "Failed to patch workload")
+		return err
+	}
+	return nil
+}
+
+func (r *GenericWorkloadReconciler) constructWorkload(
+	ctx context.Context,
+	rbg *workloadsv1alpha1.RoleBasedGroup,
+	role *workloadsv1alpha1.RoleSpec,
+	oldWorkload *unstructured.Unstructured,
+	revisionKey string,
+) (*unstructured.Unstructured, error) {
+	selectorPath := r.workload.matchLabelsPath()
+	matchLabels := rbg.GetCommonLabelsFromRole(role)
+	if oldWorkload.GetUID() != "" {
+		// do not update selector when workload exists
+		if oldMatchLabels, found, _ := unstructured.NestedStringMap(oldWorkload.Object, selectorPath...); found {
+			matchLabels = oldMatchLabels
+		}
+	}
+
+	podReconciler := NewPodReconciler(r.scheme, r.client)
+	podTemplateApplyConfiguration, err := podReconciler.ConstructPodTemplateSpecApplyConfiguration(
+		ctx, rbg, role, maps.Clone(matchLabels),
+	)
+	if err != nil {
+		return nil, err
+	}
+	podTemplate, err := runtime.DefaultUnstructuredConverter.ToUnstructured(podTemplateApplyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	workloadLabels := maps.Clone(matchLabels)
+	workloadLabels[fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, role.Name)] = revisionKey
+
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(r.workload.gvk)
+	workload.SetName(rbg.GetWorkloadName(role))
+	workload.SetNamespace(rbg.Namespace)
+	workload.SetLabels(workloadLabels)
+	workload.SetAnnotations(rbg.GetCommonAnnotationsFromRole(role))
+	workload.SetOwnerReferences([]metav1.OwnerReference{
+		{
+			APIVersion:         rbg.APIVersion,
+			Kind:               rbg.Kind,
+			Name:               rbg.Name,
+			UID:                rbg.GetUID(),
+			BlockOwnerDeletion: ptr.To(true),
+			Controller:         ptr.To(true),
+		},
+	})
+	if err := unstructured.SetNestedField(workload.Object, int64(*role.Replicas), r.workload.replicas...); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedMap(workload.Object, podTemplate, r.workload.template...); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedStringMap(workload.Object, matchLabels, selectorPath...); err != nil {
+		return nil, err
+	}
+	return workload, nil
+}
+
+func (r *GenericWorkloadReconciler) ConstructRoleStatus(
+	ctx context.Context,
+	rbg *workloadsv1alpha1.RoleBasedGroup,
+	role *workloadsv1alpha1.RoleSpec,
+) (workloadsv1alpha1.RoleStatus, bool, error) {
+	updateStatus := false
+	workload, err := r.getWorkload(ctx, rbg, role)
+	if err != nil {
+		return workloadsv1alpha1.RoleStatus{}, false, err
+	}
+
+	currentReplicas, _, _ := unstructured.NestedInt64(workload.Object, r.workload.replicas...)
+	currentReady, _, _ := unstructured.NestedInt64(workload.Object, r.workload.readyReplicas...)
+	status, found := rbg.GetRoleStatus(role.Name)
+	if !found || status.Replicas != int32(currentReplicas) || status.ReadyReplicas != int32(currentReady) {
+		status = workloadsv1alpha1.RoleStatus{
+			Name:          role.Name,
+			Replicas:      int32(currentReplicas),
+			ReadyReplicas: int32(currentReady),
+		}
+		updateStatus = true
+	}
+
+	return status, updateStatus, nil
+}
+
+func (r *GenericWorkloadReconciler) CheckWorkloadReady(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) (bool, error) {
+	workload, err := r.getWorkload(ctx, rbg, role)
+	if err != nil {
+		return false, err
+	}
+	replicas, _, _ := unstructured.NestedInt64(workload.Object, r.workload.replicas...)
+	ready, _, _ := unstructured.NestedInt64(workload.Object, r.workload.readyReplicas...)
+	return ready == replicas, nil
+}
+
+func (r *GenericWorkloadReconciler) CleanupOrphanedWorkloads(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+) error {
+	logger := log.FromContext(ctx)
+	// list workloads managed by rbg
+	workloadList := &unstructured.UnstructuredList{}
+	workloadList.SetGroupVersionKind(r.workload.gvk.GroupVersion().WithKind(r.workload.gvk.Kind + "List"))
+	if err := r.client.List(
+		ctx, workloadList, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels(
+			map[string]string{
+				workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			},
+		),
+	); err != nil {
+		return err
+	}
+
+	workloadSpec := workloadsv1alpha1.WorkloadSpec{
+		APIVersion: r.workload.gvk.GroupVersion().String(), Kind: r.workload.gvk.Kind,
+	}
+	workloadType := workloadSpec.String()
+	for i := range workloadList.Items {
+		workload := &workloadList.Items[i]
+		if !metav1.IsControlledBy(workload, rbg) {
+			continue
+		}
+		found := false
+		for _, role := range rbg.Spec.Roles {
+			if role.Workload.String() == workloadType && rbg.GetWorkloadName(&role) == workload.GetName() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			logger.Info("delete workload", "kind", r.workload.gvk.Kind, "workload", workload.GetName())
+			if err := r.client.Delete(ctx, workload); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("delete %s %s error: %s", r.workload.gvk.Kind, workload.GetName(), err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func (r *GenericWorkloadReconciler) RecreateWorkload(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+	role *workloadsv1alpha1.RoleSpec,
+) error {
+	logger := log.FromContext(ctx)
+	if rbg == nil || role == nil {
+		return nil
+	}
+
+	workload, err := r.getWorkload(ctx, rbg, role)
+	// if workload is not found, skip delete workload
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if workload.GetUID() == "" {
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Recreate %s workload, delete %s", r.workload.gvk.Kind, workload.GetName()))
+	if err := r.client.Delete(ctx, workload); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// wait new workload create
+	var retErr error
+	err = wait.PollUntilContextTimeout(
+		ctx, 5*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+			newWorkload, getErr := r.getWorkload(ctx, rbg, role)
+			retErr = getErr
+			if retErr != nil {
+				if apierrors.IsNotFound(retErr) {
+					return false, nil
+				}
+				return false, retErr
+			}
+			return newWorkload.GetUID() != workload.GetUID(), nil
+		},
+	)
+
+	if err != nil {
+		logger.Error(retErr, "wait new workload creating error")
+		return errors.Join(err, retErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+var cloneSetGVK = schema.GroupVersionKind{Group: "apps.kruise.io", Version: "v1alpha1", Kind: "CloneSet"}
+
+func newCloneSetClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(cloneSetGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(cloneSetGVK.GroupVersion().WithKind("CloneSetList"), &unstructured.UnstructuredList{})
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(cloneSetGVK, meta.RESTScopeNamespace)
+	return fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objs...).Build()
+}
+
+func buildCloneSetRBG() *workloadsv1alpha1.RoleBasedGroup {
+	role := wrappers.BuildBasicRole("prefill").WithReplicas(2).Obj()
+	role.Workload = workloadsv1alpha1.WorkloadSpec{APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet"}
+	return wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{role}).Obj()
+}
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		path      string
+		expect    []string
+		expectErr bool
+	}{
+		{path: "{.spec.replicas}", expect: []string{"spec", "replicas"}},
+		{path: ".status.readyReplicas", expect: []string{"status", "readyReplicas"}},
+		{path: "spec.replicas", expectErr: true},
+		{path: "{.spec.containers[0]}", expectErr: true},
+		{path: "{..replicas}", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			fields, err := parseFieldPath(tt.path)
+			assert.Equal(t, tt.expectErr, err != nil)
+			assert.Equal(t, tt.expect, fields)
+		})
+	}
+}
+
+func TestGenericWorkloadReconciler(t *testing.T) {
+	ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+	plugin, err := NewGenericWorkloadPlugin(GenericWorkloadConfig{
+		APIVersion:        "apps.kruise.io/v1alpha1",
+		Kind:              "CloneSet",
+		ReadyReplicasPath: "{.status.availableReplicas}",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"spec":{"replicas":0}}`, string(plugin.SuspendPatch))
+
+	rbg := buildCloneSetRBG()
+	role := &rbg.Spec.Roles[0]
+	c := newCloneSetClient(rbg)
+	r := plugin.NewReconciler(c.Scheme(), c)
+
+	// create the workload
+	assert.NoError(t, r.Reconciler(ctx, rbg, role, expectedRevisionHash))
+	cloneSet := &unstructured.Unstructured{}
+	cloneSet.SetGroupVersionKind(cloneSetGVK)
+	assert.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-rbg-prefill", Namespace: "default"}, cloneSet))
+	replicas, _, _ := unstructured.NestedInt64(cloneSet.Object, "spec", "replicas")
+	assert.Equal(t, int64(2), replicas)
+	matchLabels, _, _ := unstructured.NestedStringMap(cloneSet.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, rbg.GetCommonLabelsFromRole(role), matchLabels)
+	containers, _, _ := unstructured.NestedSlice(cloneSet.Object, "spec", "template", "spec", "containers")
+	assert.Len(t, containers, len(role.Template.Spec.Containers))
+	assert.Equal(t, expectedRevisionHash,
+		cloneSet.GetLabels()[fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, role.Name)])
+
+	// the status is read from the configured paths
+	_ = unstructured.SetNestedField(cloneSet.Object, int64(1), "status", "availableReplicas")
+	assert.NoError(t, c.Update(ctx, cloneSet))
+	status, update, err := r.ConstructRoleStatus(ctx, rbg, role)
+	assert.NoError(t, err)
+	assert.True(t, update)
+	assert.Equal(t, workloadsv1alpha1.RoleStatus{Name: "prefill", Replicas: 2, ReadyReplicas: 1}, status)
+	ready, err := r.CheckWorkloadReady(ctx, rbg, role)
+	assert.NoError(t, err)
+	assert.False(t, ready)
+
+	// workloads of removed roles are deleted
+	cloneSet.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(rbg, workloadsv1alpha1.GroupVersion.WithKind("RoleBasedGroup")),
+	})
+	assert.NoError(t, c.Update(ctx, cloneSet))
+	withoutRoles := rbg.DeepCopy()
+	withoutRoles.Spec.Roles = nil
+	assert.NoError(t, r.CleanupOrphanedWorkloads(ctx, withoutRoles))
+	assert.Error(t, c.Get(ctx, types.NamespacedName{Name: "test-rbg-prefill", Namespace: "default"}, cloneSet))
+}
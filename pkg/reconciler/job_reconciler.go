@@ -0,0 +1,347 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	batchapplyv1 "k8s.io/client-go/applyconfigurations/batch/v1"
+	metaapplyv1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/utils"
+)
+
+// JobReconciler runs the pods of a role to completion with a batch/v1 Job, e.g. to download or warm up a model
+// before the serving roles depending on it start. A role of a Job is ready once the Job succeeded.
+type JobReconciler struct {
+	scheme *runtime.Scheme
+	client client.Client
+}
+
+var _ WorkloadReconciler = &JobReconciler{}
+
+func NewJobReconciler(scheme *runtime.Scheme, client client.Client) *JobReconciler {
+	return &JobReconciler{scheme: scheme, client: client}
+}
+
+// Reconciler creates the Job of the role. The template of a Job is immutable, so a Job of an outdated revision is
+// deleted and created again once it is gone, which runs it again.
+func (r *JobReconciler) Reconciler(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+	revisionKey string) error {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("start to reconciling job workload")
+
+	oldJob := &batchv1.Job{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, oldJob)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if oldJob.UID != "" {
+		if oldJob.DeletionTimestamp != nil {
+			// the deletion of the job requeues the rbg
+			logger.V(1).Info("job is being deleted, wait for it to be gone")
+			return nil
+		}
+		roleHashKey := fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, role.Name)
+		if oldJob.Labels[roleHashKey] != revisionKey {
+			logger.Info(fmt.Sprintf("job hash not equal, old: %s, new: %s, rerun the job",
+				oldJob.Labels[roleHashKey], revisionKey))
+			err := r.client.Delete(ctx, oldJob, client.PropagationPolicy(metav1.DeletePropagationForeground))
+			return client.IgnoreNotFound(err)
+		}
+		if ptr.Deref(oldJob.Spec.Suspend, false) {
+			logger.Info("resume suspended job")
+			return r.client.Patch(
+				ctx, oldJob, client.RawPatch(types.MergePatchType, []byte(`{"spec":{"suspend":false}}`)),
+				client.FieldOwner(utils.FieldManager),
+			)
+		}
+		logger.V(1).Info("job equal, skip reconcile")
+		return nil
+	}
+
+	if status, found := rbg.GetRoleStatus(role.Name); found && status.CompletedRevision == revisionKey {
+		logger.V(1).Info("job of the revision succeeded and was cleaned up, skip reconcile")
+		return nil
+	}
+
+	jobApplyConfig, err := r.constructJobApplyConfiguration(ctx, rbg, role, revisionKey)
+	if err != nil {
+		logger.Error(err, "Failed to construct job apply configuration")
+		return err
+	}
+	if err := utils.PatchObjectApplyConfiguration(ctx, r.client, jobApplyConfig, utils.PatchSpec); err != nil {
+		logger.Error(err, "Failed to patch job apply configuration")
+		return err
+	}
+	return nil
+}
+
+func (r *JobReconciler) constructJobApplyConfiguration(
+	ctx context.Context,
+	rbg *workloadsv1alpha1.RoleBasedGroup,
+	role *workloadsv1alpha1.RoleSpec,
+	revisionKey string,
+) (*batchapplyv1.JobApplyConfiguration, error) {
+	podLabels := rbg.GetCommonLabelsFromRole(role)
+	podReconciler := NewPodReconciler(r.scheme, r.client)
+	podTemplateApplyConfiguration, err := podReconciler.ConstructPodTemplateSpecApplyConfiguration(
+		ctx, rbg, role, maps.Clone(podLabels),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// the pods of a job must not restart always
+	if podTemplateApplyConfiguration.Spec != nil && podTemplateApplyConfiguration.Spec.RestartPolicy == nil {
+		podTemplateApplyConfiguration.Spec.WithRestartPolicy(corev1.RestartPolicyNever)
+	}
+	jobLabel := maps.Clone(podLabels)
+	jobLabel[fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, role.Name)] = revisionKey
+
+	jobSpec := batchapplyv1.JobSpec().
+		WithParallelism(*role.Replicas).
+		WithCompletions(*role.Replicas).
+		WithSuspend(false).
+		WithTemplate(podTemplateApplyConfiguration)
+	if role.Job != nil {
+		if role.Job.BackoffLimit != nil {
+			jobSpec.WithBackoffLimit(*role.Job.BackoffLimit)
+		}
+		if role.Job.ActiveDeadlineSeconds != nil {
+			jobSpec.WithActiveDeadlineSeconds(*role.Job.ActiveDeadlineSeconds)
+		}
+		if role.Job.TTLSecondsAfterFinished != nil {
+			jobSpec.WithTTLSecondsAfterFinished(*role.Job.TTLSecondsAfterFinished)
+		}
+	}
+
+	jobConfig := batchapplyv1.Job(rbg.GetWorkloadName(role), rbg.Namespace).
+		WithSpec(jobSpec).
+		WithAnnotations(rbg.GetCommonAnnotationsFromRole(role)).
+		WithLabels(jobLabel).
+		WithOwnerReferences(
+			metaapplyv1.OwnerReference().
+				WithAPIVersion(rbg.APIVersion).
+				WithKind(rbg.Kind).
+				WithName(rbg.Name).
+				WithUID(rbg.GetUID()).
+				WithBlockOwnerDeletion(true).
+				WithController(true),
+		)
+	return jobConfig, nil
+}
+
+// ConstructRoleStatus counts the succeeded pods of the job as ready replicas. Once the job succeeded, its revision
+// is recorded, so that the role stays ready after the job is cleaned up by its TTL.
+func (r *JobReconciler) ConstructRoleStatus(
+	ctx context.Context,
+	rbg *workloadsv1alpha1.RoleBasedGroup,
+	role *workloadsv1alpha1.RoleSpec,
+) (workloadsv1alpha1.RoleStatus, bool, error) {
+	status, found := rbg.GetRoleStatus(role.Name)
+	job := &batchv1.Job{}
+	if err := r.client.Get(
+		ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, job,
+	); err != nil {
+		if apierrors.IsNotFound(err) && found && status.CompletedRevision != "" {
+			return status, false, nil
+		}
+		return workloadsv1alpha1.RoleStatus{}, false, err
+	}
+
+	newStatus := workloadsv1alpha1.RoleStatus{
+		Name:          role.Name,
+		Replicas:      ptr.Deref(job.Spec.Completions, 1),
+		ReadyReplicas: min(job.Status.Succeeded, ptr.Deref(job.Spec.Completions, 1)),
+	}
+	if jobSucceeded(job) {
+		newStatus.ReadyReplicas = newStatus.Replicas
+		newStatus.CompletedRevision = job.Labels[fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, role.Name)]
+	}
+	if found && status == newStatus {
+		return status, false, nil
+	}
+	return newStatus, true, nil
+}
+
+// CheckWorkloadReady returns true once the job succeeded, or if it succeeded and was cleaned up by its TTL.
+func (r *JobReconciler) CheckWorkloadReady(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) (bool, error) {
+	job := &batchv1.Job{}
+	if err := r.client.Get(
+		ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, job,
+	); err != nil {
+		if status, found := rbg.GetRoleStatus(role.Name); apierrors.IsNotFound(err) && found &&
+			status.CompletedRevision != "" {
+			return true, nil
+		}
+		return false, err
+	}
+	return jobSucceeded(job), nil
+}
+
+func (r *JobReconciler) CleanupOrphanedWorkloads(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+) error {
+	logger := log.FromContext(ctx)
+	// list job managed by rbg
+	jobList := &batchv1.JobList{}
+	if err := r.client.List(
+		context.Background(), jobList, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels(
+			map[string]string{
+				workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			},
+		),
+	); err != nil {
+		return err
+	}
+
+	for _, job := range jobList.Items {
+		if !metav1.IsControlledBy(&job, rbg) {
+			continue
+		}
+		found := false
+		for _, role := range rbg.Spec.Roles {
+			if role.Workload.String() == workloadsv1alpha1.JobWorkloadType && rbg.GetWorkloadName(&role) == job.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			logger.Info("delete job", "job", job.Name)
+			if err := r.client.Delete(
+				ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground),
+			); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("delete job %s error: %s", job.Name, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func (r *JobReconciler) RecreateWorkload(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup,
+	role *workloadsv1alpha1.RoleSpec,
+) error {
+	logger := log.FromContext(ctx)
+	if rbg == nil || role == nil {
+		return nil
+	}
+
+	jobName := rbg.GetWorkloadName(role)
+	var job batchv1.Job
+	err := r.client.Get(ctx, types.NamespacedName{Name: jobName, Namespace: rbg.Namespace}, &job)
+	// if job is not found, skip delete job
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if job.UID == "" {
+		return nil
+	}
+	// a succeeded job is not run again, the Reconciler only reruns it for a new revision
+	if jobSucceeded(&job) {
+		logger.Info(fmt.Sprintf("Job %s succeeded, skip recreating it", jobName))
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Recreate job workload, delete job %s", jobName))
+	if err := r.client.Delete(
+		ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground),
+	); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// wait new job create
+	var retErr error
+	err = wait.PollUntilContextTimeout(
+		ctx, 5*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+			var newJob batchv1.Job
+			retErr = r.client.Get(ctx, types.NamespacedName{Name: jobName, Namespace: rbg.Namespace}, &newJob)
+			if retErr != nil {
+				if apierrors.IsNotFound(retErr) {
+					return false, nil
+				}
+				return false, retErr
+			}
+			return newJob.UID != job.UID, nil
+		},
+	)
+
+	if err != nil {
+		logger.Error(retErr, "wait new job creating error")
+		return retErr
+	}
+
+	return nil
+}
+
+// jobSucceeded returns true if the job completed successfully.
+func jobSucceeded(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobComplete && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func semanticallyEqualJob(oldJob, newJob *batchv1.Job, checkStatus bool) (bool, error) {
+	if oldJob == nil || oldJob.UID == "" {
+		return false, errors.New("old job not exist")
+	}
+	if newJob == nil {
+		return false, fmt.Errorf("new job is nil")
+	}
+
+	if equal, err := objectMetaEqual(oldJob.ObjectMeta, newJob.ObjectMeta); !equal {
+		return false, fmt.Errorf("objectMeta not equal: %s", err.Error())
+	}
+
+	if checkStatus {
+		if equal, err := jobStatusEqual(oldJob.Status, newJob.Status); !equal {
+			return false, fmt.Errorf("status not equal: %s", err.Error())
+		}
+	}
+
+	return true, nil
+}
+
+func jobStatusEqual(oldStatus, newStatus batchv1.JobStatus) (bool, error) {
+	if oldStatus.Succeeded != newStatus.Succeeded {
+		return false, fmt.Errorf(
+			"status.succeeded not equal, old: %v, new: %v",
+			oldStatus.Succeeded, newStatus.Succeeded,
+		)
+	}
+
+	if oldStatus.Active != newStatus.Active {
+		return false, fmt.Errorf(
+			"status.active not equal, old: %v, new: %v",
+			oldStatus.Active, newStatus.Active,
+		)
+	}
+
+	if oldStatus.Failed != newStatus.Failed {
+		return false, fmt.Errorf(
+			"status.failed not equal, old: %v, new: %v",
+			oldStatus.Failed, newStatus.Failed,
+		)
+	}
+	return true, nil
+}
@@ -0,0 +1,178 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func buildJobRBG(status ...workloadsv1alpha1.RoleStatus) *workloadsv1alpha1.RoleBasedGroup {
+	role := wrappers.BuildBasicRole("download").WithReplicas(2).Obj()
+	role.Workload = workloadsv1alpha1.WorkloadSpec{APIVersion: "batch/v1", Kind: "Job"}
+	role.Job = &workloadsv1alpha1.JobTemplate{TTLSecondsAfterFinished: ptr.To[int32](60)}
+	return wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{role}).
+		WithStatus(workloadsv1alpha1.RoleBasedGroupStatus{RoleStatuses: status}).Obj()
+}
+
+func buildJob(revision string, succeeded bool) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rbg-download",
+			Namespace: "default",
+			UID:       "job-uid",
+			Labels: map[string]string{
+				workloadsv1alpha1.SetNameLabelKey:                                  "test-rbg",
+				fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, "download"): revision,
+			},
+		},
+		Spec: batchv1.JobSpec{Completions: ptr.To[int32](2)},
+	}
+	if succeeded {
+		job.Status.Succeeded = 2
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	}
+	return job
+}
+
+func TestJobReconciler_Reconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = batchv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+	key := types.NamespacedName{Name: "test-rbg-download", Namespace: "default"}
+
+	t.Run("create job", func(t *testing.T) {
+		rbg := buildJobRBG()
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := NewJobReconciler(scheme, c)
+		assert.NoError(t, r.Reconciler(ctx, rbg, &rbg.Spec.Roles[0], expectedRevisionHash))
+
+		job := &batchv1.Job{}
+		assert.NoError(t, c.Get(ctx, key, job))
+		assert.Equal(t, ptr.To[int32](2), job.Spec.Completions)
+		assert.Equal(t, ptr.To[int32](2), job.Spec.Parallelism)
+		assert.Equal(t, ptr.To[int32](60), job.Spec.TTLSecondsAfterFinished)
+		assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+		assert.Equal(t, expectedRevisionHash, job.Labels[fmt.Sprintf(workloadsv1alpha1.RoleRevisionLabelKeyFmt, "download")])
+	})
+
+	t.Run("rerun job of an outdated revision", func(t *testing.T) {
+		rbg := buildJobRBG()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(buildJob("old-revision", true)).Build()
+		r := NewJobReconciler(scheme, c)
+		assert.NoError(t, r.Reconciler(ctx, rbg, &rbg.Spec.Roles[0], expectedRevisionHash))
+		assert.True(t, apierrors.IsNotFound(c.Get(ctx, key, &batchv1.Job{})))
+
+		// the job is created again once the old one is gone
+		assert.NoError(t, r.Reconciler(ctx, rbg, &rbg.Spec.Roles[0], expectedRevisionHash))
+		assert.NoError(t, c.Get(ctx, key, &batchv1.Job{}))
+	})
+
+	t.Run("skip succeeded job cleaned up by its TTL", func(t *testing.T) {
+		rbg := buildJobRBG(workloadsv1alpha1.RoleStatus{
+			Name: "download", Replicas: 2, ReadyReplicas: 2, CompletedRevision: expectedRevisionHash,
+		})
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := NewJobReconciler(scheme, c)
+		assert.NoError(t, r.Reconciler(ctx, rbg, &rbg.Spec.Roles[0], expectedRevisionHash))
+		assert.True(t, apierrors.IsNotFound(c.Get(ctx, key, &batchv1.Job{})))
+
+		// a new revision runs the job again
+		assert.NoError(t, r.Reconciler(ctx, rbg, &rbg.Spec.Roles[0], "new-revision"))
+		assert.NoError(t, c.Get(ctx, key, &batchv1.Job{}))
+	})
+
+	t.Run("succeeded job is not recreated", func(t *testing.T) {
+		rbg := buildJobRBG()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(buildJob(expectedRevisionHash, true)).Build()
+		r := NewJobReconciler(scheme, c)
+		assert.NoError(t, r.RecreateWorkload(ctx, rbg, &rbg.Spec.Roles[0]))
+		assert.NoError(t, c.Get(ctx, key, &batchv1.Job{}))
+	})
+}
+
+func TestJobReconciler_ConstructRoleStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = batchv1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+
+	completed := workloadsv1alpha1.RoleStatus{
+		Name: "download", Replicas: 2, ReadyReplicas: 2, CompletedRevision: expectedRevisionHash,
+	}
+	tests := []struct {
+		name         string
+		rbg          *workloadsv1alpha1.RoleBasedGroup
+		job          *batchv1.Job
+		expectStatus workloadsv1alpha1.RoleStatus
+		expectUpdate bool
+		expectReady  bool
+		expectErr    bool
+	}{
+		{
+			name:         "running job",
+			rbg:          buildJobRBG(),
+			job:          buildJob(expectedRevisionHash, false),
+			expectStatus: workloadsv1alpha1.RoleStatus{Name: "download", Replicas: 2},
+			expectUpdate: true,
+			expectReady:  false,
+		},
+		{
+			name:         "succeeded job",
+			rbg:          buildJobRBG(),
+			job:          buildJob(expectedRevisionHash, true),
+			expectStatus: completed,
+			expectUpdate: true,
+			expectReady:  true,
+		},
+		{
+			name:         "succeeded job cleaned up by its TTL",
+			rbg:          buildJobRBG(completed),
+			expectStatus: completed,
+			expectUpdate: false,
+			expectReady:  true,
+		},
+		{
+			name:      "job not created",
+			rbg:       buildJobRBG(),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.job != nil {
+				builder.WithObjects(tt.job)
+			}
+			r := NewJobReconciler(scheme, builder.Build())
+
+			status, update, err := r.ConstructRoleStatus(ctx, tt.rbg, &tt.rbg.Spec.Roles[0])
+			assert.Equal(t, tt.expectErr, err != nil)
+			ready, readyErr := r.CheckWorkloadReady(ctx, tt.rbg, &tt.rbg.Spec.Roles[0])
+			assert.Equal(t, tt.expectErr, readyErr != nil)
+			if tt.expectErr {
+				return
+			}
+			assert.Equal(t, tt.expectStatus, status)
+			assert.Equal(t, tt.expectUpdate, update)
+			assert.Equal(t, tt.expectReady, ready)
+		})
+	}
+}
@@ -212,7 +212,6 @@ func (r *LeaderWorkerSetReconciler) constructLWSApplyConfiguration(
 		logger.Error(err, "patch Construct PodTemplateSpecApplyConfiguration failed", "rbg", keyOfRbg(rbg))
 		return nil, err
 	}
-	// TODO support SubGroupPolicy
 	if role.Replicas == nil {
 		role.Replicas = ptr.To(int32(1))
 	}
@@ -230,14 +229,38 @@ func (r *LeaderWorkerSetReconciler) constructLWSApplyConfiguration(
 		restartPolicy = lwsv1.RecreateGroupOnPodRestart
 	}
 
+	leaderWorkerTemplateConfig := lwsapplyv1.LeaderWorkerTemplate().
+		WithLeaderTemplate(leaderTemplateApplyCfg).
+		WithWorkerTemplate(workerTemplateApplyCfg).
+		WithSize(*role.LeaderWorkerSet.Size).
+		WithRestartPolicy(restartPolicy)
+
+	// SubGroupPolicy
+	if subGroupPolicy := role.LeaderWorkerSet.SubGroupPolicy; subGroupPolicy != nil {
+		subGroupPolicyConfig := lwsapplyv1.SubGroupPolicy()
+		if subGroupPolicy.Type != nil {
+			subGroupPolicyConfig = subGroupPolicyConfig.WithType(lwsv1.SubGroupPolicyType(*subGroupPolicy.Type))
+		}
+		if subGroupPolicy.SubGroupSize != nil {
+			subGroupPolicyConfig = subGroupPolicyConfig.WithSubGroupSize(*subGroupPolicy.SubGroupSize)
+		}
+		leaderWorkerTemplateConfig = leaderWorkerTemplateConfig.WithSubGroupPolicy(subGroupPolicyConfig)
+	}
+
 	lwsSpecConfig := lwsapplyv1.LeaderWorkerSetSpec().WithReplicas(*role.Replicas).
-		WithLeaderWorkerTemplate(
-			lwsapplyv1.LeaderWorkerTemplate().
-				WithLeaderTemplate(leaderTemplateApplyCfg).
-				WithWorkerTemplate(workerTemplateApplyCfg).
-				WithSize(*role.LeaderWorkerSet.Size).
-				WithRestartPolicy(restartPolicy),
+		WithLeaderWorkerTemplate(leaderWorkerTemplateConfig)
+
+	// StartupPolicy
+	if role.LeaderWorkerSet.StartupPolicy != "" {
+		lwsSpecConfig = lwsSpecConfig.WithStartupPolicy(lwsv1.StartupPolicyType(role.LeaderWorkerSet.StartupPolicy))
+	}
+
+	// NetworkConfig
+	if networkConfig := role.LeaderWorkerSet.NetworkConfig; networkConfig != nil && networkConfig.SubdomainPolicy != nil {
+		lwsSpecConfig = lwsSpecConfig.WithNetworkConfig(
+			lwsapplyv1.NetworkConfig().WithSubdomainPolicy(lwsv1.SubdomainPolicy(*networkConfig.SubdomainPolicy)),
 		)
+	}
 
 	// RollingUpdate
 	if role.RolloutStrategy != nil && role.RolloutStrategy.RollingUpdate != nil {
@@ -379,9 +402,47 @@ func lwsSpecEqual(lws1, lws2 lwsv1.LeaderWorkerSetSpec) (bool, error) {
 	if *lws1.Replicas != *lws2.Replicas {
 		return false, fmt.Errorf("LeaderWorkerSetSpec replicas not equal")
 	}
+	if lwsStartupPolicy(lws1.StartupPolicy) != lwsStartupPolicy(lws2.StartupPolicy) {
+		return false, fmt.Errorf(
+			"startup policy not equal, old: %s, new: %s",
+			lwsStartupPolicy(lws1.StartupPolicy), lwsStartupPolicy(lws2.StartupPolicy),
+		)
+	}
+	if lwsSubdomainPolicy(lws1.NetworkConfig) != lwsSubdomainPolicy(lws2.NetworkConfig) {
+		return false, fmt.Errorf(
+			"subdomain policy not equal, old: %s, new: %s",
+			lwsSubdomainPolicy(lws1.NetworkConfig), lwsSubdomainPolicy(lws2.NetworkConfig),
+		)
+	}
 	return true, nil
 }
 
+// lwsStartupPolicy returns the startup policy of a lws, as defaulted by the lws webhook.
+func lwsStartupPolicy(startupPolicy lwsv1.StartupPolicyType) lwsv1.StartupPolicyType {
+	if startupPolicy == "" {
+		return lwsv1.LeaderCreatedStartupPolicy
+	}
+	return startupPolicy
+}
+
+// lwsSubdomainPolicy returns the subdomain policy of a lws, as defaulted by the lws webhook.
+func lwsSubdomainPolicy(networkConfig *lwsv1.NetworkConfig) lwsv1.SubdomainPolicy {
+	if networkConfig == nil || networkConfig.SubdomainPolicy == nil {
+		return lwsv1.SubdomainShared
+	}
+	return *networkConfig.SubdomainPolicy
+}
+
+// subGroupPolicyEqual compares the subgroup policies of two lws, the type defaulting to LeaderWorker.
+func subGroupPolicyEqual(oldPolicy, newPolicy *lwsv1.SubGroupPolicy) bool {
+	if oldPolicy == nil || newPolicy == nil {
+		return oldPolicy == nil && newPolicy == nil
+	}
+	oldType := ptr.Deref(oldPolicy.Type, lwsv1.SubGroupPolicyTypeLeaderWorker)
+	newType := ptr.Deref(newPolicy.Type, lwsv1.SubGroupPolicyTypeLeaderWorker)
+	return oldType == newType && ptr.Equal(oldPolicy.SubGroupSize, newPolicy.SubGroupSize)
+}
+
 func lwsStatusEqual(oldStatus, newStatus lwsv1.LeaderWorkerSetStatus) (bool, error) {
 	if oldStatus.Replicas != newStatus.Replicas {
 		return false, fmt.Errorf("status.replicas not equal, old: %v, new: %v", oldStatus.Replicas, newStatus.Replicas)
@@ -423,6 +484,10 @@ func leaderWorkerTemplateEqual(oldLwt, newLwt lwsv1.LeaderWorkerTemplate) (bool,
 			oldLwt.RestartPolicy, newLwt.RestartPolicy,
 		)
 	}
+
+	if !subGroupPolicyEqual(oldLwt.SubGroupPolicy, newLwt.SubGroupPolicy) {
+		return false, fmt.Errorf("subGroupPolicy not equal")
+	}
 	return true, nil
 }
 
@@ -443,3 +443,57 @@ func TestLeaderWorkerSetReconciler_RecreateWorkload(t *testing.T) {
 
 	}
 }
+
+func TestLeaderWorkerSetReconciler_ReconcilerPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = lwsv1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	lwsRole := wrappers.BuildLwsRole("test-role").Obj()
+	lwsRole.LeaderWorkerSet.Size = ptr.To[int32](5)
+	lwsRole.LeaderWorkerSet.SubGroupPolicy = &workloadsv1alpha1.LeaderWorkerSubGroupPolicy{
+		Type:         ptr.To(workloadsv1alpha1.SubGroupPolicyTypeLeaderExcluded),
+		SubGroupSize: ptr.To[int32](2),
+	}
+	lwsRole.LeaderWorkerSet.StartupPolicy = workloadsv1alpha1.LeaderReadyStartupPolicy
+	lwsRole.LeaderWorkerSet.NetworkConfig = &workloadsv1alpha1.LeaderWorkerNetworkConfig{
+		SubdomainPolicy: ptr.To(workloadsv1alpha1.UniquePerReplicaSubdomainPolicy),
+	}
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{lwsRole}).Obj()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := NewLeaderWorkerSetReconciler(scheme, fakeClient)
+	ctx := context.Background()
+	assert.NoError(t, reconciler.Reconciler(ctx, rbg, &lwsRole, "revision-hash-value"))
+
+	lws := &lwsv1.LeaderWorkerSet{}
+	assert.NoError(t, fakeClient.Get(
+		ctx, types.NamespacedName{Name: rbg.GetWorkloadName(&lwsRole), Namespace: rbg.Namespace}, lws,
+	))
+	assert.Equal(t, &lwsv1.SubGroupPolicy{
+		Type:         ptr.To(lwsv1.SubGroupPolicyTypeLeaderExcluded),
+		SubGroupSize: ptr.To[int32](2),
+	}, lws.Spec.LeaderWorkerTemplate.SubGroupPolicy)
+	assert.Equal(t, lwsv1.LeaderReadyStartupPolicy, lws.Spec.StartupPolicy)
+	assert.Equal(t, ptr.To(lwsv1.SubdomainUniquePerReplica), lws.Spec.NetworkConfig.SubdomainPolicy)
+
+	// the policies defaulted by the lws webhook do not differ from unset ones
+	defaulted := lws.Spec.DeepCopy()
+	defaulted.StartupPolicy = lwsv1.LeaderCreatedStartupPolicy
+	defaulted.NetworkConfig = &lwsv1.NetworkConfig{SubdomainPolicy: ptr.To(lwsv1.SubdomainShared)}
+	defaulted.LeaderWorkerTemplate.SubGroupPolicy.Type = ptr.To(lwsv1.SubGroupPolicyTypeLeaderWorker)
+	unset := lws.Spec.DeepCopy()
+	unset.StartupPolicy = ""
+	unset.NetworkConfig = nil
+	unset.LeaderWorkerTemplate.SubGroupPolicy.Type = nil
+	equal, err := lwsSpecEqual(*defaulted, *unset)
+	assert.True(t, equal, err)
+
+	equal, _ = lwsSpecEqual(lws.Spec, *unset)
+	assert.False(t, equal)
+	unset.LeaderWorkerTemplate.SubGroupPolicy = nil
+	equal, _ = leaderWorkerTemplateEqual(defaulted.LeaderWorkerTemplate, unset.LeaderWorkerTemplate)
+	assert.False(t, equal)
+}
@@ -0,0 +1,131 @@
+package reconciler
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+const (
+	defaultPlacementRuleWeight  int32 = 100
+	defaultPlacementRuleMaxSkew int32 = 1
+)
+
+// setPlacementConstraints translates the placement rules of the rbg which apply to the role into
+// pod affinity, pod anti-affinity and topology spread constraints of the pod template.
+func setPlacementConstraints(
+	pod *corev1.PodTemplateSpec, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) {
+	if rbg.Spec.Placement == nil {
+		return
+	}
+	for _, rule := range rbg.Spec.Placement.Rules {
+		if !slices.Contains(rule.Roles, role.Name) {
+			continue
+		}
+		switch rule.Type {
+		case workloadsv1alpha1.ColocatePlacementRule:
+			term := placementAffinityTerm(rbg, rule.Roles, rule.TopologyKey)
+			affinity := podAffinity(pod)
+			if isPreferredPlacementRule(rule) {
+				affinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+					affinity.PreferredDuringSchedulingIgnoredDuringExecution,
+					corev1.WeightedPodAffinityTerm{Weight: placementRuleWeight(rule), PodAffinityTerm: term},
+				)
+			} else {
+				affinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+					affinity.RequiredDuringSchedulingIgnoredDuringExecution, term,
+				)
+			}
+		case workloadsv1alpha1.SeparatePlacementRule:
+			targetRoles := rule.TargetRoles
+			if len(targetRoles) == 0 {
+				targetRoles = rule.Roles
+			}
+			term := placementAffinityTerm(rbg, targetRoles, rule.TopologyKey)
+			antiAffinity := podAntiAffinity(pod)
+			if isPreferredPlacementRule(rule) {
+				antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+					antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+					corev1.WeightedPodAffinityTerm{Weight: placementRuleWeight(rule), PodAffinityTerm: term},
+				)
+			} else {
+				antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+					antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, term,
+				)
+			}
+		case workloadsv1alpha1.SpreadPlacementRule:
+			whenUnsatisfiable := corev1.DoNotSchedule
+			if isPreferredPlacementRule(rule) {
+				whenUnsatisfiable = corev1.ScheduleAnyway
+			}
+			maxSkew := defaultPlacementRuleMaxSkew
+			if rule.MaxSkew != nil {
+				maxSkew = *rule.MaxSkew
+			}
+			pod.Spec.TopologySpreadConstraints = append(pod.Spec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+				MaxSkew:           maxSkew,
+				TopologyKey:       rule.TopologyKey,
+				WhenUnsatisfiable: whenUnsatisfiable,
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+						workloadsv1alpha1.SetRoleLabelKey: role.Name,
+					},
+				},
+			})
+		}
+	}
+}
+
+// placementAffinityTerm selects the pods of the given roles of the rbg.
+func placementAffinityTerm(
+	rbg *workloadsv1alpha1.RoleBasedGroup, roles []string, topologyKey string,
+) corev1.PodAffinityTerm {
+	return corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{workloadsv1alpha1.SetNameLabelKey: rbg.Name},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      workloadsv1alpha1.SetRoleLabelKey,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   roles,
+				},
+			},
+		},
+		TopologyKey: topologyKey,
+	}
+}
+
+func podAffinity(pod *corev1.PodTemplateSpec) *corev1.PodAffinity {
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.PodAffinity == nil {
+		pod.Spec.Affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+	return pod.Spec.Affinity.PodAffinity
+}
+
+func podAntiAffinity(pod *corev1.PodTemplateSpec) *corev1.PodAntiAffinity {
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.PodAntiAffinity == nil {
+		pod.Spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	return pod.Spec.Affinity.PodAntiAffinity
+}
+
+func isPreferredPlacementRule(rule workloadsv1alpha1.PlacementRule) bool {
+	return rule.Mode == workloadsv1alpha1.PreferredPlacementRuleMode
+}
+
+func placementRuleWeight(rule workloadsv1alpha1.PlacementRule) int32 {
+	if rule.Weight != nil {
+		return *rule.Weight
+	}
+	return defaultPlacementRuleWeight
+}
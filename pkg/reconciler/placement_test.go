@@ -0,0 +1,155 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func Test_setPlacementConstraints(t *testing.T) {
+	rolesSelector := func(roles ...string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{
+			MatchLabels: map[string]string{workloadsv1alpha1.SetNameLabelKey: "test-rbg"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: workloadsv1alpha1.SetRoleLabelKey, Operator: metav1.LabelSelectorOpIn, Values: roles},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		rules []workloadsv1alpha1.PlacementRule
+		role  string
+		want  corev1.PodSpec
+	}{
+		{
+			name: "colocate roles in the same rack",
+			rules: []workloadsv1alpha1.PlacementRule{
+				{
+					Type:        workloadsv1alpha1.ColocatePlacementRule,
+					Roles:       []string{"prefill", "decode"},
+					TopologyKey: "rack",
+				},
+			},
+			role: "decode",
+			want: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					PodAffinity: &corev1.PodAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+							{LabelSelector: rolesSelector("prefill", "decode"), TopologyKey: "rack"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "spread replicas across hosts if possible",
+			rules: []workloadsv1alpha1.PlacementRule{
+				{
+					Type:        workloadsv1alpha1.SpreadPlacementRule,
+					Roles:       []string{"decode"},
+					TopologyKey: "kubernetes.io/hostname",
+					Mode:        workloadsv1alpha1.PreferredPlacementRuleMode,
+					MaxSkew:     ptr.To(int32(2)),
+				},
+			},
+			role: "decode",
+			want: corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           2,
+						TopologyKey:       "kubernetes.io/hostname",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+								workloadsv1alpha1.SetRoleLabelKey: "decode",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "separate router from gpu roles",
+			rules: []workloadsv1alpha1.PlacementRule{
+				{
+					Type:        workloadsv1alpha1.SeparatePlacementRule,
+					Roles:       []string{"router"},
+					TargetRoles: []string{"prefill", "decode"},
+					TopologyKey: "kubernetes.io/hostname",
+					Mode:        workloadsv1alpha1.PreferredPlacementRuleMode,
+					Weight:      ptr.To(int32(50)),
+				},
+			},
+			role: "router",
+			want: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+							{
+								Weight: 50,
+								PodAffinityTerm: corev1.PodAffinityTerm{
+									LabelSelector: rolesSelector("prefill", "decode"),
+									TopologyKey:   "kubernetes.io/hostname",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "separate defaults to the roles of the rule",
+			rules: []workloadsv1alpha1.PlacementRule{
+				{
+					Type:        workloadsv1alpha1.SeparatePlacementRule,
+					Roles:       []string{"router"},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			},
+			role: "router",
+			want: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+							{LabelSelector: rolesSelector("router"), TopologyKey: "kubernetes.io/hostname"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "rules of other roles are ignored",
+			rules: []workloadsv1alpha1.PlacementRule{
+				{
+					Type:        workloadsv1alpha1.SeparatePlacementRule,
+					Roles:       []string{"router"},
+					TargetRoles: []string{"decode"},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			},
+			role: "decode",
+			want: corev1.PodSpec{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj()
+				rbg.Spec.Placement = &workloadsv1alpha1.PlacementPolicy{Rules: tt.rules}
+				role := wrappers.BuildBasicRole(tt.role).Obj()
+				pod := &corev1.PodTemplateSpec{}
+
+				setPlacementConstraints(pod, rbg, &role)
+				assert.Equal(t, tt.want, pod.Spec)
+			},
+		)
+	}
+}
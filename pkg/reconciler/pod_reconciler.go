@@ -7,8 +7,10 @@ import (
 	"sort"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
@@ -87,6 +89,11 @@ func (r *PodReconciler) ConstructPodTemplateSpecApplyConfiguration(
 		}
 	}
 
+	setTopologyAffinities(&podTemplateSpec, rbg, role)
+	setPlacementConstraints(&podTemplateSpec, rbg, role)
+	setResourceClaims(&podTemplateSpec, rbg, role)
+	setDependencyReadinessGate(&podTemplateSpec, role)
+
 	// construct pod template spec configuration
 	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&podTemplateSpec)
 	if err != nil {
@@ -98,9 +105,14 @@ func (r *PodReconciler) ConstructPodTemplateSpecApplyConfiguration(
 		return nil, err
 	}
 
-	scheduler.InjectPodGroupProtocol(rbg, podTemplateApplyConfiguration)
+	scheduler.InjectPodGroupProtocol(rbg, role, podTemplateApplyConfiguration)
 
 	podTemplateApplyConfiguration.WithLabels(podLabels).WithAnnotations(podAnnotations)
+
+	// Propagate the rbgset name so that the rbgset label selector covers the pods of all its rbgs.
+	if rbgSetName, found := rbg.Labels[workloadsv1alpha1.SetRBGSetNameLabelKey]; found {
+		podTemplateApplyConfiguration.WithLabels(map[string]string{workloadsv1alpha1.SetRBGSetNameLabelKey: rbgSetName})
+	}
 	return podTemplateApplyConfiguration, nil
 }
 
@@ -238,9 +250,69 @@ func podSpecEqual(spec1, spec2 corev1.PodSpec) (bool, error) {
 		return false, fmt.Errorf("podTemplate volumes not equal: %s", err.Error())
 	}
 
+	// scheduling gates are part of the PodGroup protocol, a change must be rolled out to the pods
+	if !schedulingGateNames(spec1.SchedulingGates).Equal(schedulingGateNames(spec2.SchedulingGates)) {
+		return false, fmt.Errorf(
+			"podTemplate scheduling gates not equal, old: %v, new: %v", spec1.SchedulingGates, spec2.SchedulingGates,
+		)
+	}
+
+	// placement rules are translated into affinities and topology spread constraints, a change must be rolled out
+	if !apiequality.Semantic.DeepEqual(normalizeAffinity(spec1.Affinity), normalizeAffinity(spec2.Affinity)) {
+		return false, fmt.Errorf("podTemplate affinity not equal, old: %v, new: %v", spec1.Affinity, spec2.Affinity)
+	}
+	if !apiequality.Semantic.DeepEqual(spec1.TopologySpreadConstraints, spec2.TopologySpreadConstraints) {
+		return false, fmt.Errorf(
+			"podTemplate topology spread constraints not equal, old: %v, new: %v",
+			spec1.TopologySpreadConstraints, spec2.TopologySpreadConstraints,
+		)
+	}
+
+	if !apiequality.Semantic.DeepEqual(spec1.ReadinessGates, spec2.ReadinessGates) {
+		return false, fmt.Errorf(
+			"podTemplate readiness gates not equal, old: %v, new: %v", spec1.ReadinessGates, spec2.ReadinessGates,
+		)
+	}
+
+	if !apiequality.Semantic.DeepEqual(spec1.ResourceClaims, spec2.ResourceClaims) {
+		return false, fmt.Errorf(
+			"podTemplate resource claims not equal, old: %v, new: %v", spec1.ResourceClaims, spec2.ResourceClaims,
+		)
+	}
+
 	return true, nil
 }
 
+// normalizeAffinity drops the empty parts of an affinity, which do not survive a round trip through the apiserver.
+func normalizeAffinity(affinity *corev1.Affinity) *corev1.Affinity {
+	if affinity == nil {
+		return nil
+	}
+	normalized := affinity.DeepCopy()
+	if normalized.NodeAffinity != nil && apiequality.Semantic.DeepEqual(*normalized.NodeAffinity, corev1.NodeAffinity{}) {
+		normalized.NodeAffinity = nil
+	}
+	if normalized.PodAffinity != nil && apiequality.Semantic.DeepEqual(*normalized.PodAffinity, corev1.PodAffinity{}) {
+		normalized.PodAffinity = nil
+	}
+	if normalized.PodAntiAffinity != nil &&
+		apiequality.Semantic.DeepEqual(*normalized.PodAntiAffinity, corev1.PodAntiAffinity{}) {
+		normalized.PodAntiAffinity = nil
+	}
+	if apiequality.Semantic.DeepEqual(*normalized, corev1.Affinity{}) {
+		return nil
+	}
+	return normalized
+}
+
+func schedulingGateNames(gates []corev1.PodSchedulingGate) sets.Set[string] {
+	names := sets.New[string]()
+	for _, gate := range gates {
+		names.Insert(gate.Name)
+	}
+	return names
+}
+
 func containerEqual(c1, c2 corev1.Container) (bool, error) {
 	if c1.Name != c2.Name {
 		return false, fmt.Errorf("container name not equal")
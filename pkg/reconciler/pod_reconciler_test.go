@@ -115,6 +115,86 @@ func Test_podSpecEqual(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "unequal pod specs with different scheduling gates",
+			args: args{
+				spec1: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "container1", Image: "nginx:1.20"},
+					},
+				},
+				spec2: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "container1", Image: "nginx:1.20"},
+					},
+					SchedulingGates: []corev1.PodSchedulingGate{{Name: "gate"}},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "unequal pod specs with different topology spread constraints",
+			args: args{
+				spec1: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "container1", Image: "nginx:1.20"},
+					},
+				},
+				spec2: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "container1", Image: "nginx:1.20"},
+					},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.DoNotSchedule},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "unequal pod specs with different pod affinity",
+			args: args{
+				spec1: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "container1", Image: "nginx:1.20"},
+					},
+				},
+				spec2: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "container1", Image: "nginx:1.20"},
+					},
+					Affinity: &corev1.Affinity{
+						PodAffinity: &corev1.PodAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+								{TopologyKey: "topology.kubernetes.io/zone"},
+							},
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "equal pod specs with empty affinity",
+			args: args{
+				spec1: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "container1", Image: "nginx:1.20"},
+					},
+				},
+				spec2: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "container1", Image: "nginx:1.20"},
+					},
+					Affinity: &corev1.Affinity{PodAffinity: &corev1.PodAffinity{}},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(
@@ -926,6 +1006,47 @@ func TestPodReconciler_ConstructPodTemplateSpecApplyConfiguration_WithInjectors(
 	)
 }
 
+func TestPodReconciler_ConstructPodTemplateSpecApplyConfiguration_RBGSetLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := NewPodReconciler(scheme, client)
+	reconciler.SetInjectors([]string{})
+
+	t.Run(
+		"rbg not owned by rbgset", func(t *testing.T) {
+			rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj()
+			result, err := reconciler.ConstructPodTemplateSpecApplyConfiguration(
+				context.Background(), rbg, &rbg.Spec.Roles[0], rbg.GetCommonLabelsFromRole(&rbg.Spec.Roles[0]),
+			)
+			assert.NoError(t, err)
+			_, found := result.Labels[workloadsv1alpha1.SetRBGSetNameLabelKey]
+			assert.False(t, found)
+		},
+	)
+
+	t.Run(
+		"rbg owned by rbgset", func(t *testing.T) {
+			rbg := wrappers.BuildBasicRoleBasedGroup("test-rbgset-0", "default").Obj()
+			rbg.Labels = map[string]string{
+				workloadsv1alpha1.SetRBGSetNameLabelKey: "test-rbgset",
+				workloadsv1alpha1.SetRBGIndexLabelKey:   "0",
+			}
+			podLabels := rbg.GetCommonLabelsFromRole(&rbg.Spec.Roles[0])
+			result, err := reconciler.ConstructPodTemplateSpecApplyConfiguration(
+				context.Background(), rbg, &rbg.Spec.Roles[0], podLabels,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, "test-rbgset", result.Labels[workloadsv1alpha1.SetRBGSetNameLabelKey])
+			// The rbgset label must not leak into the workload selector labels.
+			_, found := podLabels[workloadsv1alpha1.SetRBGSetNameLabelKey]
+			assert.False(t, found)
+		},
+	)
+}
+
 func TestContainerEqual(t *testing.T) {
 	baseContainer := corev1.Container{
 		Name:    "app",
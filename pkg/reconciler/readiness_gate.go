@@ -0,0 +1,111 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	podutil "sigs.k8s.io/rbgs/pkg/inplace/pod"
+)
+
+// setDependencyReadinessGate adds the RoleDependenciesReady readiness gate to the pods of roles with
+// dependencyReadinessGate.
+func setDependencyReadinessGate(pod *corev1.PodTemplateSpec, role *workloadsv1alpha1.RoleSpec) {
+	if !role.DependencyReadinessGate {
+		return
+	}
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == workloadsv1alpha1.RoleDependenciesReadyConditionType {
+			return
+		}
+	}
+	pod.Spec.ReadinessGates = append(
+		pod.Spec.ReadinessGates,
+		corev1.PodReadinessGate{ConditionType: workloadsv1alpha1.RoleDependenciesReadyConditionType},
+	)
+}
+
+// SyncDependencyReadinessGate sets the RoleDependenciesReady condition of the pods of the role with the readiness
+// gate to false while any dependency of the role is blocking, and to true otherwise.
+func SyncDependencyReadinessGate(
+	ctx context.Context, c client.Client, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+	blocking []string,
+) error {
+	logger := log.FromContext(ctx)
+
+	condition := corev1.PodCondition{
+		Type:    workloadsv1alpha1.RoleDependenciesReadyConditionType,
+		Status:  corev1.ConditionTrue,
+		Reason:  "DependenciesReady",
+		Message: "All dependencies of the role are ready",
+	}
+	if len(blocking) > 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "DependenciesNotReady"
+		condition.Message = fmt.Sprintf("Dependencies not ready: %s", strings.Join(blocking, ", "))
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(
+		ctx, pods, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{
+			workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			workloadsv1alpha1.SetRoleLabelKey: role.Name,
+		},
+	); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || !containsDependencyReadinessGate(pod) {
+			continue
+		}
+		current := podutil.GetPodCondition(pod, condition.Type)
+		if current != nil && current.Status == condition.Status && current.Message == condition.Message {
+			continue
+		}
+
+		condition.LastTransitionTime = metav1.Now()
+		if current != nil && current.Status == condition.Status {
+			condition.LastTransitionTime = current.LastTransitionTime
+		}
+		original := pod.DeepCopy()
+		setPodCondition(pod, condition)
+		if condition.Status == corev1.ConditionFalse {
+			// set the Ready condition right away instead of waiting for the kubelet, like the in-place update gates
+			podutil.UpdatePodReadyCondition(pod)
+		}
+		// the conditions are merged by type, so the patch does not conflict with the status updates of the kubelet
+		if err := c.Status().Patch(ctx, pod, client.StrategicMergeFrom(original)); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		logger.V(1).Info("Update the dependency readiness gate of pod", "pod", pod.Name, "status", condition.Status)
+	}
+	return nil
+}
+
+func containsDependencyReadinessGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == workloadsv1alpha1.RoleDependenciesReadyConditionType {
+			return true
+		}
+	}
+	return false
+}
+
+// setPodCondition replaces the condition of the same type, or appends it.
+func setPodCondition(pod *corev1.Pod, condition corev1.PodCondition) {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == condition.Type {
+			pod.Status.Conditions[i] = condition
+			return
+		}
+	}
+	pod.Status.Conditions = append(pod.Status.Conditions, condition)
+}
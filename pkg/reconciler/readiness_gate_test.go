@@ -0,0 +1,95 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	podutil "sigs.k8s.io/rbgs/pkg/inplace/pod"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestSetDependencyReadinessGate(t *testing.T) {
+	role := wrappers.BuildBasicRole("router").WithDependencies([]string{"backend"}).Obj()
+	pod := &corev1.PodTemplateSpec{}
+	setDependencyReadinessGate(pod, &role)
+	assert.Empty(t, pod.Spec.ReadinessGates)
+
+	role.DependencyReadinessGate = true
+	setDependencyReadinessGate(pod, &role)
+	setDependencyReadinessGate(pod, &role)
+	assert.Equal(t, []corev1.PodReadinessGate{
+		{ConditionType: workloadsv1alpha1.RoleDependenciesReadyConditionType},
+	}, pod.Spec.ReadinessGates)
+}
+
+func TestSyncDependencyReadinessGate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	role := wrappers.BuildBasicRole("router").WithDependencies([]string{"backend"}).Obj()
+	role.DependencyReadinessGate = true
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{role}).Obj()
+
+	newPod := func(name string, readinessGate bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+					workloadsv1alpha1.SetRoleLabelKey: "router",
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+		if readinessGate {
+			pod.Spec.ReadinessGates = []corev1.PodReadinessGate{
+				{ConditionType: workloadsv1alpha1.RoleDependenciesReadyConditionType},
+			}
+		}
+		return pod
+	}
+	gated, ungated := newPod("test-rbg-router-0", true), newPod("test-rbg-router-1", false)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gated, ungated).
+		WithStatusSubresource(&corev1.Pod{}).Build()
+	ctx := context.TODO()
+
+	getPod := func(pod *corev1.Pod) *corev1.Pod {
+		t.Helper()
+		updated := &corev1.Pod{}
+		assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), updated))
+		return updated
+	}
+
+	// the gate turns false and the pod is not ready while a dependency is not ready
+	assert.NoError(t, SyncDependencyReadinessGate(ctx, c, rbg, &rbg.Spec.Roles[0], []string{"backend"}))
+	pod := getPod(gated)
+	condition := podutil.GetPodCondition(pod, workloadsv1alpha1.RoleDependenciesReadyConditionType)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Equal(t, "Dependencies not ready: backend", condition.Message)
+	}
+	assert.Equal(t, corev1.ConditionFalse, podutil.GetPodCondition(pod, corev1.PodReady).Status)
+	assert.Nil(t, podutil.GetPodCondition(getPod(ungated), workloadsv1alpha1.RoleDependenciesReadyConditionType))
+
+	// the gate turns true once all dependencies are ready
+	assert.NoError(t, SyncDependencyReadinessGate(ctx, c, rbg, &rbg.Spec.Roles[0], nil))
+	condition = podutil.GetPodCondition(getPod(gated), workloadsv1alpha1.RoleDependenciesReadyConditionType)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	}
+}
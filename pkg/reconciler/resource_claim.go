@@ -0,0 +1,265 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// ResourceClaimReconciler creates the ResourceClaimTemplates and ResourceClaims declared by the roles of a rbg,
+// and deletes the ones no longer declared.
+type ResourceClaimReconciler struct {
+	scheme *runtime.Scheme
+	client client.Client
+}
+
+func NewResourceClaimReconciler(scheme *runtime.Scheme, client client.Client) *ResourceClaimReconciler {
+	return &ResourceClaimReconciler{scheme: scheme, client: client}
+}
+
+func (r *ResourceClaimReconciler) Reconcile(ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup) error {
+	logger := log.FromContext(ctx)
+
+	templates := map[string]*resourcev1.ResourceClaimTemplate{}
+	claims := map[string]*resourcev1.ResourceClaim{}
+	for i := range rbg.Spec.Roles {
+		role := &rbg.Spec.Roles[i]
+		for _, claim := range role.ResourceClaims {
+			name := resourceClaimName(rbg, role, claim)
+			labels := map[string]string{workloadsv1alpha1.SetNameLabelKey: rbg.Name}
+			if claim.Scope != workloadsv1alpha1.GroupResourceClaimScope {
+				labels[workloadsv1alpha1.SetRoleLabelKey] = role.Name
+			}
+			objectMeta := metav1.ObjectMeta{Name: name, Namespace: rbg.Namespace, Labels: labels}
+
+			switch {
+			case isPodResourceClaim(role, claim):
+				templates[name] = &resourcev1.ResourceClaimTemplate{
+					ObjectMeta: objectMeta,
+					Spec:       resourcev1.ResourceClaimTemplateSpec{Spec: *claim.Spec.DeepCopy()},
+				}
+			case isRoleInstanceResourceClaim(role, claim):
+				// one ResourceClaim per LeaderWorkerSet group, the ones of removed groups are deleted below
+				for index := 0; index < int(*role.Replicas); index++ {
+					groupMeta := *objectMeta.DeepCopy()
+					groupMeta.Name = roleInstanceResourceClaimName(name, strconv.Itoa(index))
+					groupMeta.Labels[lwsv1.GroupIndexLabelKey] = strconv.Itoa(index)
+					claims[groupMeta.Name] = &resourcev1.ResourceClaim{ObjectMeta: groupMeta, Spec: *claim.Spec.DeepCopy()}
+				}
+			default:
+				claims[name] = &resourcev1.ResourceClaim{ObjectMeta: objectMeta, Spec: *claim.Spec.DeepCopy()}
+			}
+		}
+	}
+
+	// resource.k8s.io/v1 is only served by clusters with dynamic resource allocation
+	if _, err := r.client.RESTMapper().RESTMapping(
+		schema.GroupKind{Group: resourcev1.GroupName, Kind: "ResourceClaim"}, resourcev1.SchemeGroupVersion.Version,
+	); err != nil {
+		if !meta.IsNoMatchError(err) {
+			return err
+		}
+		if len(templates) > 0 || len(claims) > 0 {
+			return fmt.Errorf("resource claims are declared, but %s is not served", resourcev1.SchemeGroupVersion)
+		}
+		return nil
+	}
+
+	// The specs of ResourceClaimTemplates and ResourceClaims are immutable, their names carry a hash of the spec.
+	for name, template := range templates {
+		if err := r.createIfNotExists(ctx, rbg, template, &resourcev1.ResourceClaimTemplate{}); err != nil {
+			return fmt.Errorf("failed to create ResourceClaimTemplate %s: %w", name, err)
+		}
+	}
+	for name, claim := range claims {
+		if err := r.createIfNotExists(ctx, rbg, claim, &resourcev1.ResourceClaim{}); err != nil {
+			return fmt.Errorf("failed to create ResourceClaim %s: %w", name, err)
+		}
+	}
+
+	templateList := &resourcev1.ResourceClaimTemplateList{}
+	if err := r.client.List(
+		ctx, templateList, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{workloadsv1alpha1.SetNameLabelKey: rbg.Name},
+	); err != nil {
+		return err
+	}
+	for i := range templateList.Items {
+		template := &templateList.Items[i]
+		if _, desired := templates[template.Name]; desired || !metav1.IsControlledBy(template, rbg) {
+			continue
+		}
+		logger.Info("delete ResourceClaimTemplate", "resourceClaimTemplate", template.Name)
+		if err := r.client.Delete(ctx, template); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	claimList := &resourcev1.ResourceClaimList{}
+	if err := r.client.List(
+		ctx, claimList, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{workloadsv1alpha1.SetNameLabelKey: rbg.Name},
+	); err != nil {
+		return err
+	}
+	for i := range claimList.Items {
+		claim := &claimList.Items[i]
+		if _, desired := claims[claim.Name]; desired || !metav1.IsControlledBy(claim, rbg) {
+			continue
+		}
+		// a claim still reserved by pods is kept by its delete protection finalizer until they are gone
+		logger.Info("delete ResourceClaim", "resourceClaim", claim.Name)
+		if err := r.client.Delete(ctx, claim); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ResourceClaimReconciler) createIfNotExists(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, obj, existing client.Object,
+) error {
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(rbg, obj, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(ctx, obj)
+}
+
+// setResourceClaims adds the resource claims of the role to the resourceClaims of the pod template.
+// The pods of all groups of a LeaderWorkerSet share one template, so a RoleInstance claim references the name
+// prefix of its ResourceClaims, which does not exist. The pod webhook replaces it with the ResourceClaim of the
+// group when a pod is created, and a pod it did not mutate waits for the missing ResourceClaim.
+func setResourceClaims(
+	pod *corev1.PodTemplateSpec, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) {
+	roleInstanceClaims := map[string]string{}
+	for _, claim := range role.ResourceClaims {
+		podClaim := corev1.PodResourceClaim{Name: claim.Name}
+		name := resourceClaimName(rbg, role, claim)
+		if isPodResourceClaim(role, claim) {
+			podClaim.ResourceClaimTemplateName = &name
+		} else {
+			podClaim.ResourceClaimName = &name
+		}
+		if isRoleInstanceResourceClaim(role, claim) {
+			roleInstanceClaims[claim.Name] = name
+		}
+
+		replaced := false
+		for i := range pod.Spec.ResourceClaims {
+			if pod.Spec.ResourceClaims[i].Name == claim.Name {
+				pod.Spec.ResourceClaims[i] = podClaim
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			pod.Spec.ResourceClaims = append(pod.Spec.ResourceClaims, podClaim)
+		}
+	}
+
+	if len(roleInstanceClaims) == 0 {
+		return
+	}
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	data, _ := json.Marshal(roleInstanceClaims)
+	pod.Labels[workloadsv1alpha1.RoleInstanceResourceClaimsLabelKey] = "true"
+	pod.Annotations[workloadsv1alpha1.RoleInstanceResourceClaimsAnnotationKey] = string(data)
+}
+
+// SetRoleInstanceResourceClaims sets the ResourceClaims of the LeaderWorkerSet group of a pod being created for its
+// RoleInstance resource claims. The group index of a leader pod is set by the LeaderWorkerSet webhook, a pod without
+// it is left unchanged until the webhook is reinvoked.
+func SetRoleInstanceResourceClaims(pod *corev1.Pod) error {
+	value, ok := pod.Annotations[workloadsv1alpha1.RoleInstanceResourceClaimsAnnotationKey]
+	if !ok {
+		return nil
+	}
+	index, ok := pod.Labels[lwsv1.GroupIndexLabelKey]
+	if !ok {
+		return nil
+	}
+	if _, err := strconv.Atoi(index); err != nil {
+		return fmt.Errorf("invalid group index %q: %w", index, err)
+	}
+	prefixes := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &prefixes); err != nil {
+		return fmt.Errorf("invalid annotation %s: %w", workloadsv1alpha1.RoleInstanceResourceClaimsAnnotationKey, err)
+	}
+
+	for i := range pod.Spec.ResourceClaims {
+		podClaim := &pod.Spec.ResourceClaims[i]
+		if prefix, ok := prefixes[podClaim.Name]; ok {
+			podClaim.ResourceClaimName = ptr.To(roleInstanceResourceClaimName(prefix, index))
+			podClaim.ResourceClaimTemplateName = nil
+		}
+	}
+	return nil
+}
+
+// isPodResourceClaim reports whether a claim of the role gets a ResourceClaim per pod. Every instance of the
+// workloads other than LeaderWorkerSets is a single pod.
+func isPodResourceClaim(role *workloadsv1alpha1.RoleSpec, claim workloadsv1alpha1.RoleResourceClaim) bool {
+	switch claim.Scope {
+	case "", workloadsv1alpha1.PodResourceClaimScope:
+		return true
+	case workloadsv1alpha1.RoleInstanceResourceClaimScope:
+		return role.Workload.String() != workloadsv1alpha1.LeaderWorkerSetWorkloadType
+	}
+	return false
+}
+
+func isRoleInstanceResourceClaim(role *workloadsv1alpha1.RoleSpec, claim workloadsv1alpha1.RoleResourceClaim) bool {
+	return claim.Scope == workloadsv1alpha1.RoleInstanceResourceClaimScope &&
+		role.Workload.String() == workloadsv1alpha1.LeaderWorkerSetWorkloadType
+}
+
+func roleInstanceResourceClaimName(prefix, groupIndex string) string {
+	return fmt.Sprintf("%s-%s", prefix, groupIndex)
+}
+
+// resourceClaimName returns the name of the ResourceClaimTemplate or ResourceClaim of a resource claim of the role.
+// Group claims with the same name and spec share one ResourceClaim. The ResourceClaims of the RoleInstance claims
+// of a LeaderWorkerSet role are named after it and their group index.
+func resourceClaimName(
+	rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec, claim workloadsv1alpha1.RoleResourceClaim,
+) string {
+	prefix := rbg.GetWorkloadName(role)
+	if claim.Scope == workloadsv1alpha1.GroupResourceClaimScope {
+		prefix = rbg.Name
+	}
+	return fmt.Sprintf("%s-%s-%s", prefix, claim.Name, resourceClaimSpecHash(claim.Spec))
+}
+
+func resourceClaimSpecHash(spec resourcev1.ResourceClaimSpec) string {
+	hf := fnv.New32a()
+	data, _ := json.Marshal(spec)
+	hf.Write(data)
+	return rand.SafeEncodeString(fmt.Sprint(hf.Sum32()))
+}
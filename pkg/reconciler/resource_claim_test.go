@@ -0,0 +1,240 @@
+package reconciler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func buildResourceClaim(name string, scope workloadsv1alpha1.ResourceClaimScopeType) workloadsv1alpha1.RoleResourceClaim {
+	return workloadsv1alpha1.RoleResourceClaim{
+		Name:  name,
+		Scope: scope,
+		Spec: resourcev1.ResourceClaimSpec{
+			Devices: resourcev1.DeviceClaim{
+				Requests: []resourcev1.DeviceRequest{
+					{Name: name, Exactly: &resourcev1.ExactDeviceRequest{DeviceClassName: name + ".example.com"}},
+				},
+			},
+		},
+	}
+}
+
+func buildResourceClaimRBG() *workloadsv1alpha1.RoleBasedGroup {
+	prefill := wrappers.BuildBasicRole("prefill").Obj()
+	prefill.ResourceClaims = []workloadsv1alpha1.RoleResourceClaim{
+		buildResourceClaim("gpu", ""),
+		buildResourceClaim("nvswitch", workloadsv1alpha1.GroupResourceClaimScope),
+	}
+	decode := wrappers.BuildBasicRole("decode").Obj()
+	decode.ResourceClaims = []workloadsv1alpha1.RoleResourceClaim{
+		buildResourceClaim("rdma", workloadsv1alpha1.RoleResourceClaimScope),
+		buildResourceClaim("nvswitch", workloadsv1alpha1.GroupResourceClaimScope),
+	}
+	return wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{prefill, decode}).Obj()
+}
+
+func Test_setResourceClaims(t *testing.T) {
+	rbg := buildResourceClaimRBG()
+	prefill, decode := &rbg.Spec.Roles[0], &rbg.Spec.Roles[1]
+	nvswitch := resourceClaimName(rbg, prefill, prefill.ResourceClaims[1])
+
+	// the group claim is shared between the roles
+	assert.Equal(t, nvswitch, resourceClaimName(rbg, decode, decode.ResourceClaims[1]))
+
+	pod := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			ResourceClaims: []corev1.PodResourceClaim{
+				{Name: "gpu", ResourceClaimName: ptr.To("placeholder")},
+				{Name: "other", ResourceClaimName: ptr.To("other")},
+			},
+		},
+	}
+	setResourceClaims(pod, rbg, prefill)
+	assert.Equal(t, []corev1.PodResourceClaim{
+		{Name: "gpu", ResourceClaimTemplateName: ptr.To(resourceClaimName(rbg, prefill, prefill.ResourceClaims[0]))},
+		{Name: "other", ResourceClaimName: ptr.To("other")},
+		{Name: "nvswitch", ResourceClaimName: ptr.To(nvswitch)},
+	}, pod.Spec.ResourceClaims)
+}
+
+func TestResourceClaimReconciler_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	_ = resourcev1.AddToScheme(scheme)
+
+	rbg := buildResourceClaimRBG()
+	stale := &resourcev1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-rbg-stale",
+			Namespace:       "default",
+			Labels:          map[string]string{workloadsv1alpha1.SetNameLabelKey: "test-rbg"},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())},
+		},
+	}
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{resourcev1.SchemeGroupVersion})
+	restMapper.Add(resourcev1.SchemeGroupVersion.WithKind("ResourceClaim"), meta.RESTScopeNamespace)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).WithObjects(rbg, stale).Build()
+
+	ctx := context.TODO()
+	assert.NoError(t, NewResourceClaimReconciler(scheme, fakeClient).Reconcile(ctx, rbg))
+
+	templates := &resourcev1.ResourceClaimTemplateList{}
+	assert.NoError(t, fakeClient.List(ctx, templates, client.InNamespace("default")))
+	assert.Len(t, templates.Items, 1)
+	assert.Equal(t, resourceClaimName(rbg, &rbg.Spec.Roles[0], rbg.Spec.Roles[0].ResourceClaims[0]), templates.Items[0].Name)
+	assert.True(t, metav1.IsControlledBy(&templates.Items[0], rbg))
+
+	claims := &resourcev1.ResourceClaimList{}
+	assert.NoError(t, fakeClient.List(ctx, claims, client.InNamespace("default")))
+	names := []string{}
+	for _, claim := range claims.Items {
+		names = append(names, claim.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		resourceClaimName(rbg, &rbg.Spec.Roles[0], rbg.Spec.Roles[0].ResourceClaims[1]),
+		resourceClaimName(rbg, &rbg.Spec.Roles[1], rbg.Spec.Roles[1].ResourceClaims[0]),
+	}, names)
+}
+
+func TestResourceClaimReconciler_Reconcile_NotServed(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	// without resource claims the rbg does not need dynamic resource allocation
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj()
+	assert.NoError(t, NewResourceClaimReconciler(scheme, fakeClient).Reconcile(context.TODO(), rbg))
+
+	assert.Error(t, NewResourceClaimReconciler(scheme, fakeClient).Reconcile(context.TODO(), buildResourceClaimRBG()))
+}
+
+func buildRoleInstanceResourceClaimRBG() *workloadsv1alpha1.RoleBasedGroup {
+	leader := wrappers.BuildLwsRole("leader").WithReplicas(2).Obj()
+	leader.ResourceClaims = []workloadsv1alpha1.RoleResourceClaim{
+		buildResourceClaim("rdma", workloadsv1alpha1.RoleInstanceResourceClaimScope),
+	}
+	// every instance of a deployment is a single pod
+	router := wrappers.BuildBasicRole("router").Obj()
+	router.ResourceClaims = []workloadsv1alpha1.RoleResourceClaim{
+		buildResourceClaim("rdma", workloadsv1alpha1.RoleInstanceResourceClaimScope),
+	}
+	return wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{leader, router}).Obj()
+}
+
+func Test_setResourceClaims_RoleInstance(t *testing.T) {
+	rbg := buildRoleInstanceResourceClaimRBG()
+	leader, router := &rbg.Spec.Roles[0], &rbg.Spec.Roles[1]
+	prefix := resourceClaimName(rbg, leader, leader.ResourceClaims[0])
+
+	pod := &corev1.PodTemplateSpec{}
+	setResourceClaims(pod, rbg, leader)
+	assert.Equal(t, []corev1.PodResourceClaim{{Name: "rdma", ResourceClaimName: ptr.To(prefix)}}, pod.Spec.ResourceClaims)
+	assert.Equal(t, "true", pod.Labels[workloadsv1alpha1.RoleInstanceResourceClaimsLabelKey])
+	assert.JSONEq(t, `{"rdma":"`+prefix+`"}`, pod.Annotations[workloadsv1alpha1.RoleInstanceResourceClaimsAnnotationKey])
+
+	pod = &corev1.PodTemplateSpec{}
+	setResourceClaims(pod, rbg, router)
+	assert.Equal(t, []corev1.PodResourceClaim{
+		{Name: "rdma", ResourceClaimTemplateName: ptr.To(resourceClaimName(rbg, router, router.ResourceClaims[0]))},
+	}, pod.Spec.ResourceClaims)
+	assert.NotContains(t, pod.Labels, workloadsv1alpha1.RoleInstanceResourceClaimsLabelKey)
+}
+
+func TestSetRoleInstanceResourceClaims(t *testing.T) {
+	rbg := buildRoleInstanceResourceClaimRBG()
+	leader := &rbg.Spec.Roles[0]
+	prefix := resourceClaimName(rbg, leader, leader.ResourceClaims[0])
+	template := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			ResourceClaims: []corev1.PodResourceClaim{{Name: "other", ResourceClaimName: ptr.To("other")}},
+		},
+	}
+	setResourceClaims(template, rbg, leader)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{
+			name:   "group index set",
+			labels: map[string]string{lwsv1.GroupIndexLabelKey: "1"},
+			want:   prefix + "-1",
+		},
+		{
+			name: "group index not set yet",
+			want: prefix,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: *template.Spec.DeepCopy()}
+			for k, v := range tt.labels {
+				pod.Labels[k] = v
+			}
+			assert.NoError(t, SetRoleInstanceResourceClaims(pod))
+			assert.Equal(t, []corev1.PodResourceClaim{
+				{Name: "other", ResourceClaimName: ptr.To("other")},
+				{Name: "rdma", ResourceClaimName: ptr.To(tt.want)},
+			}, pod.Spec.ResourceClaims)
+
+			// the webhook may be reinvoked
+			assert.NoError(t, SetRoleInstanceResourceClaims(pod))
+			assert.Equal(t, tt.want, *pod.Spec.ResourceClaims[1].ResourceClaimName)
+		})
+	}
+}
+
+func TestResourceClaimReconciler_Reconcile_RoleInstance(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+	_ = resourcev1.AddToScheme(scheme)
+
+	rbg := buildRoleInstanceResourceClaimRBG()
+	leader := &rbg.Spec.Roles[0]
+	prefix := resourceClaimName(rbg, leader, leader.ResourceClaims[0])
+	removed := &resourcev1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            prefix + "-2",
+			Namespace:       "default",
+			Labels:          map[string]string{workloadsv1alpha1.SetNameLabelKey: "test-rbg"},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())},
+		},
+	}
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{resourcev1.SchemeGroupVersion})
+	restMapper.Add(resourcev1.SchemeGroupVersion.WithKind("ResourceClaim"), meta.RESTScopeNamespace)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).WithObjects(rbg, removed).Build()
+
+	ctx := context.TODO()
+	assert.NoError(t, NewResourceClaimReconciler(scheme, fakeClient).Reconcile(ctx, rbg))
+
+	claims := &resourcev1.ResourceClaimList{}
+	assert.NoError(t, fakeClient.List(ctx, claims, client.InNamespace("default")))
+	names := []string{}
+	for _, claim := range claims.Items {
+		names = append(names, claim.Name)
+		assert.Equal(t, strings.TrimPrefix(claim.Name, prefix+"-"), claim.Labels[lwsv1.GroupIndexLabelKey])
+	}
+	assert.ElementsMatch(t, []string{prefix + "-0", prefix + "-1"}, names)
+
+	templates := &resourcev1.ResourceClaimTemplateList{}
+	assert.NoError(t, fakeClient.List(ctx, templates, client.InNamespace("default")))
+	assert.Len(t, templates.Items, 1)
+}
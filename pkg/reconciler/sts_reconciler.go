@@ -401,16 +401,23 @@ func calculateContinuousReadyReplicas(states []replicaState) int32 {
 func (r *StatefulSetReconciler) reconcileHeadlessService(
 	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
 ) error {
-	logger := log.FromContext(ctx)
-	logger.V(1).Info("start to reconciling headless service")
-
 	sts := &appsv1.StatefulSet{}
 	err := r.client.Get(ctx, types.NamespacedName{Name: rbg.GetWorkloadName(role), Namespace: rbg.Namespace}, sts)
 	if err != nil {
 		return fmt.Errorf("get sts error, skip reconcile svc. error:  %s", err.Error())
 	}
+	return r.reconcileHeadlessServiceOf(ctx, rbg, role, sts)
+}
+
+// reconcileHeadlessServiceOf reconciles the headless service of the role, owned by its workload.
+func (r *StatefulSetReconciler) reconcileHeadlessServiceOf(
+	ctx context.Context, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+	workload client.Object,
+) error {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("start to reconciling headless service")
 
-	svcApplyConfig, err := r.constructServiceApplyConfiguration(ctx, rbg, role, sts)
+	svcApplyConfig, err := r.constructServiceApplyConfiguration(ctx, rbg, role, workload)
 	if err != nil {
 		return fmt.Errorf("constructServiceApplyConfiguration error: %s", err.Error())
 	}
@@ -505,7 +512,7 @@ func (r *StatefulSetReconciler) constructServiceApplyConfiguration(
 	ctx context.Context,
 	rbg *workloadsv1alpha1.RoleBasedGroup,
 	role *workloadsv1alpha1.RoleSpec,
-	sts *appsv1.StatefulSet,
+	workload client.Object,
 ) (*coreapplyv1.ServiceApplyConfiguration, error) {
 	selectMap := map[string]string{
 		workloadsv1alpha1.SetNameLabelKey: rbg.Name,
@@ -526,10 +533,10 @@ func (r *StatefulSetReconciler) constructServiceApplyConfiguration(
 		WithAnnotations(rbg.GetCommonAnnotationsFromRole(role)).
 		WithOwnerReferences(
 			metaapplyv1.OwnerReference().
-				WithAPIVersion(sts.APIVersion).
-				WithKind(sts.Kind).
-				WithName(sts.Name).
-				WithUID(sts.GetUID()).
+				WithAPIVersion(workload.GetObjectKind().GroupVersionKind().GroupVersion().String()).
+				WithKind(workload.GetObjectKind().GroupVersionKind().Kind).
+				WithName(workload.GetName()).
+				WithUID(workload.GetUID()).
 				WithBlockOwnerDeletion(true),
 		)
 	return serviceConfig, nil
@@ -597,7 +604,7 @@ func (r *StatefulSetReconciler) CleanupOrphanedWorkloads(
 		}
 		found := false
 		for _, role := range rbg.Spec.Roles {
-			if role.Workload.Kind == "StatefulSet" && rbg.GetWorkloadName(&role) == sts.Name {
+			if role.Workload.String() == workloadsv1alpha1.StatefulSetWorkloadType && rbg.GetWorkloadName(&role) == sts.Name {
 				found = true
 				break
 			}
@@ -0,0 +1,56 @@
+package reconciler
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/pkg/utils"
+)
+
+// SuspendWorkload scales the workload of the role to zero replicas, or suspends its Job, keeping the rest of its spec.
+// The replicas of the role are restored by the workload reconciler once the rbg is resumed.
+// It returns true once no pod of the role is left running, the succeeded and failed pods of a Job are kept.
+func SuspendWorkload(
+	ctx context.Context, c client.Client, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) (bool, error) {
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(schema.FromAPIVersionAndKind(role.Workload.APIVersion, role.Workload.Kind))
+	workload.SetNamespace(rbg.Namespace)
+	workload.SetName(rbg.GetWorkloadName(role))
+	patch := []byte(`{"spec":{"replicas":0}}`)
+	if role.Workload.String() == workloadsv1alpha1.JobWorkloadType {
+		// a job has no replicas, suspending it deletes its active pods
+		patch = []byte(`{"spec":{"suspend":true}}`)
+	} else if plugin, found := LookupWorkloadPlugin(role.Workload); found && len(plugin.SuspendPatch) > 0 {
+		patch = plugin.SuspendPatch
+	}
+	err := c.Patch(
+		ctx, workload, client.RawPatch(types.MergePatchType, patch), client.FieldOwner(utils.FieldManager),
+	)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(
+		ctx, pods, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{
+			workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			workloadsv1alpha1.SetRoleLabelKey: role.Name,
+		},
+	); err != nil {
+		return false, err
+	}
+	for i := range pods.Items {
+		if phase := pods.Items[i].Status.Phase; phase != corev1.PodSucceeded && phase != corev1.PodFailed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
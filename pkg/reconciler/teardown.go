@@ -0,0 +1,101 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// TeardownRole deletes the workload of the role in the foreground and waits for the pods of the role to terminate.
+// Pods terminating for longer than the drain timeout of the role are force deleted. Once no pod of the role is left,
+// the service and the configmap of the role are deleted, and it returns true.
+func TeardownRole(
+	ctx context.Context, c client.Client, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(schema.FromAPIVersionAndKind(role.Workload.APIVersion, role.Workload.Kind))
+	workload.SetNamespace(rbg.Namespace)
+	workload.SetName(rbg.GetWorkloadName(role))
+	// the workload of a role can't exist if its kind is not served, e.g. after the lws CRD is uninstalled
+	if err := c.Delete(ctx, workload, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil &&
+		!apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return false, err
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(
+		ctx, pods, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{
+			workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			workloadsv1alpha1.SetRoleLabelKey: role.Name,
+		},
+	); err != nil {
+		return false, err
+	}
+	if len(pods.Items) > 0 {
+		drainTimeout := rbg.GetRoleDrainTimeout(role)
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if !drainTimeoutExceeded(pod, drainTimeout) {
+				continue
+			}
+			logger.Info("Force delete pod after the drain timeout", "pod", pod.Name, "drainTimeout", drainTimeout)
+			if err := c.Delete(ctx, pod, client.GracePeriodSeconds(0)); err != nil && !apierrors.IsNotFound(err) {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	services := &corev1.ServiceList{}
+	if err := c.List(
+		ctx, services, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{
+			workloadsv1alpha1.SetNameLabelKey: rbg.Name,
+			workloadsv1alpha1.SetRoleLabelKey: role.Name,
+		},
+	); err != nil {
+		return false, err
+	}
+	for i := range services.Items {
+		if err := c.Delete(ctx, &services.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: rbg.Namespace, Name: rbg.GetWorkloadName(role)}, configMap)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	if metav1.IsControlledBy(configMap, rbg) {
+		if err := c.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// drainTimeoutExceeded returns true if the pod has been terminating for longer than the drain timeout.
+func drainTimeoutExceeded(pod *corev1.Pod, drainTimeout time.Duration) bool {
+	if pod.DeletionTimestamp == nil {
+		return false
+	}
+	// the deletion timestamp of a pod is the end of its grace period
+	deletedAt := pod.DeletionTimestamp.Add(-time.Duration(ptr.Deref(pod.DeletionGracePeriodSeconds, 0)) * time.Second)
+	return time.Since(deletedAt) > drainTimeout
+}
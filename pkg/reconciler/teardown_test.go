@@ -0,0 +1,102 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestDrainTimeoutExceeded(t *testing.T) {
+	tests := []struct {
+		name              string
+		deletionTimestamp *metav1.Time
+		gracePeriod       *int64
+		expect            bool
+	}{
+		{
+			name:   "pod not terminating",
+			expect: false,
+		},
+		{
+			name:              "pod terminating within its grace period",
+			deletionTimestamp: &metav1.Time{Time: time.Now().Add(20 * time.Second)},
+			gracePeriod:       ptr.To[int64](30),
+			expect:            false,
+		},
+		{
+			name:              "pod terminating longer than the drain timeout",
+			deletionTimestamp: &metav1.Time{Time: time.Now().Add(-2 * time.Minute)},
+			gracePeriod:       ptr.To[int64](30),
+			expect:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						DeletionTimestamp:          tt.deletionTimestamp,
+						DeletionGracePeriodSeconds: tt.gracePeriod,
+					},
+				}
+				assert.Equal(t, tt.expect, drainTimeoutExceeded(pod, time.Minute))
+			},
+		)
+	}
+}
+
+func TestTeardownRole(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	role := wrappers.BuildBasicRole("backend").Obj()
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{role}).Obj()
+	rbg.UID = "rbg-uid"
+	labels := map[string]string{
+		workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+		workloadsv1alpha1.SetRoleLabelKey: "backend",
+	}
+
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "test-rbg-backend", Namespace: "default"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-rbg-backend-0", Namespace: "default", Labels: labels}}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "s-test-rbg-backend", Namespace: "default", Labels: labels}}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-rbg-backend",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sts, pod, svc, configMap).Build()
+	ctx := context.TODO()
+
+	// the service and config of the role are kept while its pods terminate
+	done, err := TeardownRole(ctx, c, rbg, &rbg.Spec.Roles[0])
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(sts), &appsv1.StatefulSet{})))
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(svc), &corev1.Service{}))
+
+	assert.NoError(t, c.Delete(ctx, pod))
+	done, err = TeardownRole(ctx, c, rbg, &rbg.Spec.Roles[0])
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(svc), &corev1.Service{})))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})))
+}
@@ -0,0 +1,175 @@
+package reconciler
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// setTopologyAffinities translates the topology levels of the rbg which apply to the role into required pod affinity
+// terms, so the pods of each member of a level scope land in one domain of the level topology key.
+func setTopologyAffinities(
+	pod *corev1.PodTemplateSpec, rbg *workloadsv1alpha1.RoleBasedGroup, role *workloadsv1alpha1.RoleSpec,
+) {
+	if rbg.Spec.Topology == nil {
+		return
+	}
+	for _, level := range rbg.Spec.Topology.Levels {
+		if !topologyLevelAppliesTo(level, role) {
+			continue
+		}
+
+		var term corev1.PodAffinityTerm
+		switch level.Scope {
+		case workloadsv1alpha1.RoleTopologyScope:
+			term = corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{workloadsv1alpha1.SetRoleUniqueHashLabelKey: rbg.GenRoleUniqueKey(role)},
+				},
+			}
+		case workloadsv1alpha1.RoleInstanceTopologyScope:
+			// every instance of the other workloads is a single pod
+			if role.Workload.String() != workloadsv1alpha1.LeaderWorkerSetWorkloadType {
+				continue
+			}
+			// the group key of a LeaderWorkerSet group is only known once its pods are created
+			term = corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{workloadsv1alpha1.SetRoleUniqueHashLabelKey: rbg.GenRoleUniqueKey(role)},
+				},
+				MatchLabelKeys: []string{lwsv1.GroupUniqueHashLabelKey},
+			}
+		default:
+			term = corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{workloadsv1alpha1.SetGroupUniqueHashLabelKey: rbg.GenGroupUniqueKey()},
+				},
+			}
+			if len(level.Roles) > 0 {
+				term.LabelSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+					{Key: workloadsv1alpha1.SetRoleLabelKey, Operator: metav1.LabelSelectorOpIn, Values: level.Roles},
+				}
+			}
+		}
+		term.TopologyKey = level.TopologyKey
+
+		if level.Scope == workloadsv1alpha1.RoleTopologyScope || level.Scope == workloadsv1alpha1.RoleInstanceTopologyScope {
+			if pod.Labels == nil {
+				pod.Labels = map[string]string{}
+			}
+			pod.Labels[workloadsv1alpha1.SetRoleUniqueHashLabelKey] = rbg.GenRoleUniqueKey(role)
+		}
+		affinity := podAffinity(pod)
+		affinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			affinity.RequiredDuringSchedulingIgnoredDuringExecution, term,
+		)
+	}
+}
+
+func topologyLevelAppliesTo(level workloadsv1alpha1.TopologyLevel, role *workloadsv1alpha1.RoleSpec) bool {
+	return len(level.Roles) == 0 || slices.Contains(level.Roles, role.Name)
+}
+
+// ConstructTopologyStatus returns the domains the scheduled pods of the rbg landed in, for each level of spec.topology.
+func ConstructTopologyStatus(
+	ctx context.Context, c client.Client, rbg *workloadsv1alpha1.RoleBasedGroup,
+) ([]workloadsv1alpha1.TopologyLevelStatus, error) {
+	if rbg.Spec.Topology == nil {
+		return nil, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(
+		ctx, pods, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{workloadsv1alpha1.SetNameLabelKey: rbg.Name},
+	); err != nil {
+		return nil, err
+	}
+
+	nodeLabels := map[string]map[string]string{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if _, found := nodeLabels[pod.Spec.NodeName]; found {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		nodeLabels[pod.Spec.NodeName] = node.Labels
+	}
+
+	statuses := make([]workloadsv1alpha1.TopologyLevelStatus, 0, len(rbg.Spec.Topology.Levels))
+	for _, level := range rbg.Spec.Topology.Levels {
+		scope := level.Scope
+		if scope == "" {
+			scope = workloadsv1alpha1.GroupTopologyScope
+		}
+
+		seen := map[workloadsv1alpha1.TopologyDomain]struct{}{}
+		domains := []workloadsv1alpha1.TopologyDomain{}
+		for _, pod := range pods.Items {
+			labels, scheduled := nodeLabels[pod.Spec.NodeName]
+			if !scheduled || pod.DeletionTimestamp != nil {
+				continue
+			}
+			role, err := rbg.GetRole(pod.Labels[workloadsv1alpha1.SetRoleLabelKey])
+			if err != nil || !topologyLevelAppliesTo(level, role) {
+				continue
+			}
+			value, found := labels[level.TopologyKey]
+			if !found {
+				continue
+			}
+
+			domain := workloadsv1alpha1.TopologyDomain{Value: value}
+			switch scope {
+			case workloadsv1alpha1.RoleTopologyScope:
+				domain.Role = role.Name
+			case workloadsv1alpha1.RoleInstanceTopologyScope:
+				groupIndex, found := pod.Labels[lwsv1.GroupIndexLabelKey]
+				if !found {
+					continue
+				}
+				domain.Role = role.Name
+				domain.Instance = groupIndex
+			}
+			if _, found := seen[domain]; found {
+				continue
+			}
+			seen[domain] = struct{}{}
+			domains = append(domains, domain)
+		}
+		sort.Slice(domains, func(i, j int) bool {
+			if domains[i].Role != domains[j].Role {
+				return domains[i].Role < domains[j].Role
+			}
+			if domains[i].Instance != domains[j].Instance {
+				// group indexes are ordered numerically
+				if len(domains[i].Instance) != len(domains[j].Instance) {
+					return len(domains[i].Instance) < len(domains[j].Instance)
+				}
+				return domains[i].Instance < domains[j].Instance
+			}
+			return domains[i].Value < domains[j].Value
+		})
+
+		statuses = append(statuses, workloadsv1alpha1.TopologyLevelStatus{
+			TopologyKey: level.TopologyKey,
+			Scope:       scope,
+			Domains:     domains,
+		})
+	}
+	return statuses, nil
+}
@@ -0,0 +1,170 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func Test_setTopologyAffinities(t *testing.T) {
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj()
+	rbg.Spec.Topology = &workloadsv1alpha1.TopologyPolicy{
+		Levels: []workloadsv1alpha1.TopologyLevel{
+			{TopologyKey: "spine", Scope: workloadsv1alpha1.GroupTopologyScope},
+			{TopologyKey: "rack", Scope: workloadsv1alpha1.RoleTopologyScope, Roles: []string{"prefill"}},
+			{TopologyKey: "nvlink", Scope: workloadsv1alpha1.RoleInstanceTopologyScope},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		role       workloadsv1alpha1.RoleSpec
+		wantLabels map[string]string
+		wantTerms  []corev1.PodAffinityTerm
+	}{
+		{
+			name: "lws role in the group and instance domains",
+			role: wrappers.BuildLwsRole("decode").Obj(),
+			wantLabels: map[string]string{
+				workloadsv1alpha1.SetRoleUniqueHashLabelKey: rbg.GenRoleUniqueKey(&workloadsv1alpha1.RoleSpec{Name: "decode"}),
+			},
+			wantTerms: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{workloadsv1alpha1.SetGroupUniqueHashLabelKey: rbg.GenGroupUniqueKey()},
+					},
+					TopologyKey: "spine",
+				},
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							workloadsv1alpha1.SetRoleUniqueHashLabelKey: rbg.GenRoleUniqueKey(&workloadsv1alpha1.RoleSpec{Name: "decode"}),
+						},
+					},
+					MatchLabelKeys: []string{lwsv1.GroupUniqueHashLabelKey},
+					TopologyKey:    "nvlink",
+				},
+			},
+		},
+		{
+			name: "statefulset role in the group and role domains",
+			role: wrappers.BuildBasicRole("prefill").Obj(),
+			wantLabels: map[string]string{
+				workloadsv1alpha1.SetRoleUniqueHashLabelKey: rbg.GenRoleUniqueKey(&workloadsv1alpha1.RoleSpec{Name: "prefill"}),
+			},
+			wantTerms: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{workloadsv1alpha1.SetGroupUniqueHashLabelKey: rbg.GenGroupUniqueKey()},
+					},
+					TopologyKey: "spine",
+				},
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							workloadsv1alpha1.SetRoleUniqueHashLabelKey: rbg.GenRoleUniqueKey(&workloadsv1alpha1.RoleSpec{Name: "prefill"}),
+						},
+					},
+					TopologyKey: "rack",
+				},
+			},
+		},
+		{
+			name: "statefulset role in the group domain only",
+			role: wrappers.BuildBasicRole("router").Obj(),
+			wantTerms: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{workloadsv1alpha1.SetGroupUniqueHashLabelKey: rbg.GenGroupUniqueKey()},
+					},
+					TopologyKey: "spine",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				pod := &corev1.PodTemplateSpec{}
+				setTopologyAffinities(pod, rbg, &tt.role)
+				assert.Equal(t, tt.wantLabels, pod.Labels)
+				assert.Equal(t, tt.wantTerms, pod.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+			},
+		)
+	}
+}
+
+func TestConstructTopologyStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha1.AddToScheme(scheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha1.RoleSpec{
+			wrappers.BuildBasicRole("router").Obj(),
+			wrappers.BuildLwsRole("decode").WithReplicas(2).Obj(),
+		}).Obj()
+	rbg.Spec.Topology = &workloadsv1alpha1.TopologyPolicy{
+		Levels: []workloadsv1alpha1.TopologyLevel{
+			{TopologyKey: "spine"},
+			{TopologyKey: "nvlink", Scope: workloadsv1alpha1.RoleInstanceTopologyScope},
+		},
+	}
+
+	node := func(name, spine, nvlink string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"spine": spine, "nvlink": nvlink},
+		}}
+	}
+	pod := func(name, role, groupIndex, nodeName string) *corev1.Pod {
+		labels := map[string]string{
+			workloadsv1alpha1.SetNameLabelKey: "test-rbg",
+			workloadsv1alpha1.SetRoleLabelKey: role,
+		}
+		if groupIndex != "" {
+			labels[lwsv1.GroupIndexLabelKey] = groupIndex
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		node("node-1", "spine-a", "nvl-1"),
+		node("node-2", "spine-a", "nvl-2"),
+		pod("router-0", "router", "", "node-1"),
+		pod("decode-0", "decode", "0", "node-1"),
+		pod("decode-0-1", "decode", "0", "node-1"),
+		pod("decode-1", "decode", "1", "node-2"),
+		pod("decode-1-1", "decode", "1", ""),
+	).Build()
+
+	topology, err := ConstructTopologyStatus(context.TODO(), fakeClient, rbg)
+	assert.NoError(t, err)
+	assert.Equal(t, []workloadsv1alpha1.TopologyLevelStatus{
+		{
+			TopologyKey: "spine",
+			Scope:       workloadsv1alpha1.GroupTopologyScope,
+			Domains:     []workloadsv1alpha1.TopologyDomain{{Value: "spine-a"}},
+		},
+		{
+			TopologyKey: "nvlink",
+			Scope:       workloadsv1alpha1.RoleInstanceTopologyScope,
+			Domains: []workloadsv1alpha1.TopologyDomain{
+				{Role: "decode", Instance: "0", Value: "nvl-1"},
+				{Role: "decode", Instance: "1", Value: "nvl-2"},
+			},
+		},
+	}, topology)
+}
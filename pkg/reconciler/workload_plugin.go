@@ -0,0 +1,147 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// WorkloadPlugin adds a workload kind, e.g. a CRD-based workload, to the workloads roles can run on.
+type WorkloadPlugin struct {
+	// GroupVersionKind of the workload, matched against the workload of the roles.
+	GroupVersionKind schema.GroupVersionKind
+	// CRDName is the name of the CRD of the workload, e.g. clonesets.apps.kruise.io. The workloads are only
+	// watched and cleaned up once the CRD exists. Leave it empty for built-in kinds.
+	CRDName string
+	// NewObject returns an empty workload, used to watch the workloads owned by rbgs.
+	NewObject func() client.Object
+	// NewReconciler returns the reconciler of the workloads of the roles.
+	NewReconciler func(scheme *runtime.Scheme, client client.Client) WorkloadReconciler
+	// Equal determines whether a watched workload needs reconciliation, see WorkloadEqual.
+	Equal func(obj1, obj2 client.Object) (bool, error)
+	// SuspendPatch is the merge patch suspending the workload, defaults to scaling spec.replicas to zero.
+	SuspendPatch []byte
+}
+
+// WorkloadType returns the workload type of the plugin, in the format of WorkloadSpec.String.
+func (p WorkloadPlugin) WorkloadType() string {
+	workload := workloadsv1alpha1.WorkloadSpec{
+		APIVersion: p.GroupVersionKind.GroupVersion().String(),
+		Kind:       p.GroupVersionKind.Kind,
+	}
+	return workload.String()
+}
+
+var (
+	workloadPluginsLock sync.RWMutex
+	workloadPlugins     = map[string]WorkloadPlugin{}
+)
+
+var builtinWorkloadTypes = map[string]bool{
+	workloadsv1alpha1.DeploymentWorkloadType:      true,
+	workloadsv1alpha1.StatefulSetWorkloadType:     true,
+	workloadsv1alpha1.LeaderWorkerSetWorkloadType: true,
+	workloadsv1alpha1.JobWorkloadType:             true,
+}
+
+// RegisterWorkloadPlugin registers a workload plugin. Plugins are registered before the controller is set up,
+// a workload type can be registered only once and the built-in workloads cannot be replaced.
+func RegisterWorkloadPlugin(plugin WorkloadPlugin) error {
+	if plugin.GroupVersionKind.Kind == "" || plugin.GroupVersionKind.Version == "" {
+		return fmt.Errorf("workload plugin has no group version kind")
+	}
+	if plugin.NewObject == nil || plugin.NewReconciler == nil || plugin.Equal == nil {
+		return fmt.Errorf("workload plugin %s must set NewObject, NewReconciler and Equal", plugin.WorkloadType())
+	}
+	workloadType := plugin.WorkloadType()
+	if builtinWorkloadTypes[workloadType] {
+		return fmt.Errorf("workload %s is built in", workloadType)
+	}
+
+	workloadPluginsLock.Lock()
+	defer workloadPluginsLock.Unlock()
+	if _, found := workloadPlugins[workloadType]; found {
+		return fmt.Errorf("workload plugin %s is already registered", workloadType)
+	}
+	workloadPlugins[workloadType] = plugin
+	return nil
+}
+
+// WorkloadPlugins returns the registered workload plugins, sorted by workload type.
+func WorkloadPlugins() []WorkloadPlugin {
+	workloadPluginsLock.RLock()
+	defer workloadPluginsLock.RUnlock()
+	plugins := make([]WorkloadPlugin, 0, len(workloadPlugins))
+	for _, plugin := range workloadPlugins {
+		plugins = append(plugins, plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].WorkloadType() < plugins[j].WorkloadType()
+	})
+	return plugins
+}
+
+// LookupWorkloadPlugin returns the plugin registered for the workload of a role.
+func LookupWorkloadPlugin(workload workloadsv1alpha1.WorkloadSpec) (WorkloadPlugin, bool) {
+	workloadPluginsLock.RLock()
+	defer workloadPluginsLock.RUnlock()
+	plugin, found := workloadPlugins[workload.String()]
+	return plugin, found
+}
+
+// workloadPluginForObject returns the plugin of a watched workload, matching typed objects by their Go type and
+// unstructured objects by their group version kind.
+func workloadPluginForObject(obj interface{}) (WorkloadPlugin, bool) {
+	var gvk schema.GroupVersionKind
+	if runtimeObj, ok := obj.(runtime.Object); ok {
+		gvk = runtimeObj.GetObjectKind().GroupVersionKind()
+	}
+	for _, plugin := range WorkloadPlugins() {
+		if reflect.TypeOf(plugin.NewObject()) != reflect.TypeOf(obj) {
+			continue
+		}
+		if gvk.Empty() || gvk == plugin.GroupVersionKind {
+			return plugin, true
+		}
+	}
+	return WorkloadPlugin{}, false
+}
+
+// LoadWorkloadPlugins registers the workloads declared in a ConfigMap, each key of which holds a
+// GenericWorkloadConfig in YAML.
+func LoadWorkloadPlugins(ctx context.Context, reader client.Reader, key types.NamespacedName) error {
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, key, configMap); err != nil {
+		return fmt.Errorf("get workload plugins configmap %s: %w", key, err)
+	}
+
+	names := make([]string, 0, len(configMap.Data))
+	for name := range configMap.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		config := GenericWorkloadConfig{}
+		if err := yaml.UnmarshalStrict([]byte(configMap.Data[name]), &config); err != nil {
+			return fmt.Errorf("parse workload plugin %s: %w", name, err)
+		}
+		plugin, err := NewGenericWorkloadPlugin(config)
+		if err != nil {
+			return fmt.Errorf("workload plugin %s: %w", name, err)
+		}
+		if err := RegisterWorkloadPlugin(plugin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// unregisterWorkloadPlugin removes a workload plugin registered by a test.
+func unregisterWorkloadPlugin(workloadType string) {
+	workloadPluginsLock.Lock()
+	defer workloadPluginsLock.Unlock()
+	delete(workloadPlugins, workloadType)
+}
+
+func TestRegisterWorkloadPlugin(t *testing.T) {
+	plugin, err := NewGenericWorkloadPlugin(GenericWorkloadConfig{
+		APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet", CRDName: "clonesets.apps.kruise.io",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, RegisterWorkloadPlugin(plugin))
+	defer unregisterWorkloadPlugin(plugin.WorkloadType())
+
+	assert.Error(t, RegisterWorkloadPlugin(plugin), "a workload can only be registered once")
+	deployment, err := NewGenericWorkloadPlugin(GenericWorkloadConfig{APIVersion: "apps/v1", Kind: "Deployment"})
+	assert.NoError(t, err)
+	assert.Error(t, RegisterWorkloadPlugin(deployment), "built-in workloads cannot be replaced")
+	assert.Error(t, RegisterWorkloadPlugin(WorkloadPlugin{GroupVersionKind: cloneSetGVK}), "incomplete plugin")
+
+	workload := workloadsv1alpha1.WorkloadSpec{APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet"}
+	found, ok := LookupWorkloadPlugin(workload)
+	assert.True(t, ok)
+	assert.Equal(t, "clonesets.apps.kruise.io", found.CRDName)
+	r, err := NewWorkloadReconciler(workload, runtime.NewScheme(), fake.NewClientBuilder().Build())
+	assert.NoError(t, err)
+	assert.IsType(t, &GenericWorkloadReconciler{}, r)
+
+	// watched workloads are compared by their plugin
+	old := plugin.NewObject().(*unstructured.Unstructured)
+	_ = unstructured.SetNestedField(old.Object, int64(2), "spec", "replicas")
+	updated := old.DeepCopy()
+	equal, err := WorkloadEqual(old, updated)
+	assert.True(t, equal)
+	assert.NoError(t, err)
+	_ = unstructured.SetNestedField(updated.Object, int64(1), "status", "readyReplicas")
+	equal, err = WorkloadEqual(old, updated)
+	assert.False(t, equal)
+	assert.Error(t, err)
+
+	// unstructured objects of other kinds are not handled by the plugin
+	other := &unstructured.Unstructured{}
+	other.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Other"})
+	_, err = WorkloadEqual(other, other.DeepCopy())
+	assert.Error(t, err)
+}
+
+func TestLoadWorkloadPlugins(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	key := types.NamespacedName{Name: "workload-plugins", Namespace: "rbgs-system"}
+
+	tests := []struct {
+		name      string
+		data      map[string]string
+		expectErr bool
+	}{
+		{
+			name: "valid plugins",
+			data: map[string]string{
+				"cloneset": `
+apiVersion: apps.kruise.io/v1alpha1
+kind: CloneSet
+crdName: clonesets.apps.kruise.io
+readyReplicasPath: "{.status.availableReplicas}"
+`,
+			},
+		},
+		{
+			name:      "unknown field",
+			data:      map[string]string{"cloneset": "apiVersion: apps.kruise.io/v1alpha1\nkind: CloneSet\nreplicas: 1\n"},
+			expectErr: true,
+		},
+		{
+			name: "invalid path",
+			data: map[string]string{
+				"cloneset": "apiVersion: apps.kruise.io/v1alpha1\nkind: CloneSet\nreplicasPath: spec.replicas\n",
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer unregisterWorkloadPlugin("apps.kruise.io/v1alpha1/CloneSet")
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Data:       tt.data,
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build()
+			err := LoadWorkloadPlugins(context.TODO(), c, key)
+			assert.Equal(t, tt.expectErr, err != nil)
+			_, found := LookupWorkloadPlugin(
+				workloadsv1alpha1.WorkloadSpec{APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet"},
+			)
+			assert.Equal(t, !tt.expectErr, found)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
@@ -37,7 +38,12 @@ func NewWorkloadReconciler(
 		return NewStatefulSetReconciler(scheme, client), nil
 	case workload.String() == workloadsv1alpha1.LeaderWorkerSetWorkloadType:
 		return NewLeaderWorkerSetReconciler(scheme, client), nil
+	case workload.String() == workloadsv1alpha1.JobWorkloadType:
+		return NewJobReconciler(scheme, client), nil
 	default:
+		if plugin, found := LookupWorkloadPlugin(workload); found {
+			return plugin.NewReconciler(scheme, client), nil
+		}
 		return nil, fmt.Errorf("unsupported workload type: %s", workload.String())
 	}
 }
@@ -66,6 +72,24 @@ func WorkloadEqual(obj1, obj2 interface{}) (bool, error) {
 			}
 			return true, nil
 		}
+	case *batchv1.Job:
+		if o2, ok := obj2.(*batchv1.Job); ok {
+			if equal, err := semanticallyEqualJob(o1, o2, true); !equal {
+				return false, fmt.Errorf("job not equal, error: %s", err.Error())
+			}
+			return true, nil
+		}
+	default:
+		if plugin, found := workloadPluginForObject(obj1); found {
+			o1, ok1 := obj1.(client.Object)
+			o2, ok2 := obj2.(client.Object)
+			if ok1 && ok2 && reflect.TypeOf(o1) == reflect.TypeOf(o2) {
+				if equal, err := plugin.Equal(o1, o2); !equal {
+					return false, fmt.Errorf("%s not equal, error: %v", plugin.GroupVersionKind.Kind, err)
+				}
+				return true, nil
+			}
+		}
 	}
 
 	return false, fmt.Errorf("not support workload: %v", reflect.TypeOf(obj1))
@@ -0,0 +1,270 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+const (
+	// GangSchedulingGateName holds the pods of a group back from scheduling until the whole group can start.
+	GangSchedulingGateName = "rolebasedgroup.workloads.x-k8s.io/gang"
+
+	// GangGateWaitingForPods means not every pod of the group exists yet.
+	GangGateWaitingForPods = "WaitingForPods"
+	// GangGateInsufficientCapacity means the nodes can not fit the requests of all pods of the group.
+	GangGateInsufficientCapacity = "InsufficientCapacity"
+	// GangGateUngating means the group can start and its scheduling gates are being removed. The remaining gates of
+	// an ungating group are removed without checking the group again, so that the group is never partially ungated.
+	GangGateUngating = "Ungating"
+	// GangGateUngated means the scheduling gates of the group are removed.
+	GangGateUngated = "Ungated"
+)
+
+// GangGateResult is the outcome of a scheduling gates check of a group.
+type GangGateResult struct {
+	// Ungated is true once the scheduling gates of all pods of the group are removed.
+	Ungated bool
+	// Reason is one of the GangGate* reasons.
+	Reason  string
+	Message string
+	// OldestGatedPod is the creation time of the oldest pod still gated, used for the timeout.
+	OldestGatedPod *corev1.Pod
+}
+
+// HasGangSchedulingGate returns true if the pod is held back until its whole group can start.
+func HasGangSchedulingGate(pod *corev1.Pod) bool {
+	return hasSchedulingGate(pod, GangSchedulingGateName)
+}
+
+type GangGate struct {
+	client client.Client
+}
+
+func NewGangGate(client client.Client) *GangGate {
+	return &GangGate{client: client}
+}
+
+// Check returns whether the pods of the rbg can be ungated, i.e. every pod of the group exists and the free
+// allocatable resources of the nodes can fit the requests of all gated pods. The result has the GangGateUngating
+// reason once the group can start.
+func (g *GangGate) Check(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup) (*GangGateResult, error) {
+	pods, gatedPods, err := g.listPods(ctx, rbg)
+	if err != nil {
+		return nil, err
+	}
+	if len(gatedPods) == 0 {
+		return &GangGateResult{Ungated: true, Reason: GangGateUngated, Message: "All pods of the group are ungated"}, nil
+	}
+
+	sort.Slice(gatedPods, func(i, j int) bool {
+		return gatedPods[i].CreationTimestamp.Before(&gatedPods[j].CreationTimestamp)
+	})
+	result := &GangGateResult{OldestGatedPod: gatedPods[0]}
+
+	if groupSize := rbg.GetGroupSize(); len(pods) < groupSize {
+		result.Reason = GangGateWaitingForPods
+		result.Message = fmt.Sprintf("%d/%d pods of the group are created", len(pods), groupSize)
+		return result, nil
+	}
+
+	fit, err := g.fitsNodes(ctx, gatedPods)
+	if err != nil {
+		return nil, err
+	}
+	if !fit {
+		result.Reason = GangGateInsufficientCapacity
+		result.Message = fmt.Sprintf("the nodes can not fit the requests of %d gated pods", len(gatedPods))
+		return result, nil
+	}
+
+	result.Reason = GangGateUngating
+	result.Message = fmt.Sprintf("removing the scheduling gates of %d pods", len(gatedPods))
+	return result, nil
+}
+
+// Ungate removes the scheduling gates of all pods of the rbg. The gates of all pods are removed even if some of them
+// fail, so that a retry only has to remove the remaining ones.
+func (g *GangGate) Ungate(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup) error {
+	_, gatedPods, err := g.listPods(ctx, rbg)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pod := range gatedPods {
+		original := pod.DeepCopy()
+		RemoveSchedulingGate(pod, GangSchedulingGateName)
+		if err := g.client.Patch(ctx, pod, client.StrategicMergeFrom(original)); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("remove the scheduling gate of pod %s: %w", pod.Name, err))
+		}
+	}
+	if len(gatedPods) > 0 {
+		log.FromContext(ctx).Info("removed gang scheduling gates", "pods", len(gatedPods)-len(errs))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// listPods returns the pods of the rbg which are not being deleted, and the ones among them which are gated.
+func (g *GangGate) listPods(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup,
+) (pods, gatedPods []*corev1.Pod, err error) {
+	podList := &corev1.PodList{}
+	if err := g.client.List(
+		ctx, podList, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{workloadsv1alpha.SetNameLabelKey: rbg.Name},
+	); err != nil {
+		return nil, nil, err
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		pods = append(pods, pod)
+		if HasGangSchedulingGate(pod) {
+			gatedPods = append(gatedPods, pod)
+		}
+	}
+	return pods, gatedPods, nil
+}
+
+// fitsNodes is a best-effort first-fit check of the gated pods on the free allocatable resources of
+// the schedulable nodes matching their node selectors. Taints and affinities are not considered.
+func (g *GangGate) fitsNodes(ctx context.Context, gatedPods []*corev1.Pod) (bool, error) {
+	nodeList := &corev1.NodeList{}
+	if err := g.client.List(ctx, nodeList); err != nil {
+		return false, err
+	}
+	podList := &corev1.PodList{}
+	if err := g.client.List(ctx, podList); err != nil {
+		return false, err
+	}
+
+	free := make(map[string]corev1.ResourceList, len(nodeList.Items))
+	nodes := make([]*corev1.Node, 0, len(nodeList.Items))
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if node.Spec.Unschedulable || !isNodeReady(node) {
+			continue
+		}
+		nodes = append(nodes, node)
+		free[node.Name] = node.Status.Allocatable.DeepCopy()
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if allocatable, found := free[pod.Spec.NodeName]; found {
+			subtractResources(allocatable, podRequests(pod))
+		}
+	}
+
+	for _, pod := range gatedPods {
+		requests := podRequests(pod)
+		selector := labels.SelectorFromSet(pod.Spec.NodeSelector)
+		fit := false
+		for _, node := range nodes {
+			if !selector.Matches(labels.Set(node.Labels)) || !fitsResources(free[node.Name], requests) {
+				continue
+			}
+			subtractResources(free[node.Name], requests)
+			fit = true
+			break
+		}
+		if !fit {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func hasSchedulingGate(pod *corev1.Pod, name string) bool {
+	for _, gate := range pod.Spec.SchedulingGates {
+		if gate.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveSchedulingGate removes the scheduling gate with the given name from the pod.
+func RemoveSchedulingGate(pod *corev1.Pod, name string) {
+	gates := make([]corev1.PodSchedulingGate, 0, len(pod.Spec.SchedulingGates))
+	for _, gate := range pod.Spec.SchedulingGates {
+		if gate.Name != name {
+			gates = append(gates, gate)
+		}
+	}
+	pod.Spec.SchedulingGates = gates
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podRequests returns the effective requests of a pod, including the pod itself.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := podSpecRequests(&pod.Spec)
+	requests[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	return requests
+}
+
+// podSpecRequests returns the effective requests of a pod spec, i.e. the max of the sum of its containers
+// and of each init container.
+func podSpecRequests(spec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, c := range spec.Containers {
+		for name, quantity := range c.Resources.Requests {
+			q := requests[name]
+			q.Add(quantity)
+			requests[name] = q
+		}
+	}
+	for _, c := range spec.InitContainers {
+		for name, quantity := range c.Resources.Requests {
+			if q, found := requests[name]; !found || quantity.Cmp(q) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
+
+func fitsResources(free, requests corev1.ResourceList) bool {
+	for name, quantity := range requests {
+		available, found := free[name]
+		if !found {
+			if quantity.IsZero() {
+				continue
+			}
+			return false
+		}
+		if available.Cmp(quantity) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func subtractResources(free, requests corev1.ResourceList) {
+	for name, quantity := range requests {
+		if available, found := free[name]; found {
+			available.Sub(quantity)
+			free[name] = available
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func buildGangPod(name, rbgName, cpu string, gated bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{workloadsv1alpha.SetNameLabelKey: rbgName},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					},
+				},
+			},
+		},
+	}
+	if gated {
+		pod.Spec.SchedulingGates = []corev1.PodSchedulingGate{{Name: GangSchedulingGateName}}
+	}
+	return pod
+}
+
+func buildNode(name, cpu string, ready bool) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse(cpu),
+				corev1.ResourcePods: resource.MustParse("110"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func TestGangGate_Check(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha.AddToScheme(scheme)
+
+	// 3 pods: 2 of role-a and 1 of role-b
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha.RoleSpec{
+			wrappers.BuildBasicRole("role-a").WithReplicas(2).Obj(),
+			wrappers.BuildBasicRole("role-b").WithReplicas(1).Obj(),
+		}).Obj()
+
+	runningPod := buildGangPod("other", "other-rbg", "3", false)
+	runningPod.Spec.NodeName = "node-1"
+
+	tests := []struct {
+		name         string
+		objs         []client.Object
+		expectReason string
+		expectGated  bool
+	}{
+		{
+			name: "wait for all pods of the group",
+			objs: []client.Object{
+				buildGangPod("pod-0", rbg.Name, "1", true),
+				buildGangPod("pod-1", rbg.Name, "1", true),
+				buildNode("node-1", "8", true),
+			},
+			expectReason: GangGateWaitingForPods,
+			expectGated:  true,
+		},
+		{
+			name: "insufficient capacity",
+			objs: []client.Object{
+				buildGangPod("pod-0", rbg.Name, "2", true),
+				buildGangPod("pod-1", rbg.Name, "2", true),
+				buildGangPod("pod-2", rbg.Name, "2", true),
+				buildNode("node-1", "4", true),
+				buildNode("node-2", "8", false),
+				runningPod,
+			},
+			expectReason: GangGateInsufficientCapacity,
+			expectGated:  true,
+		},
+		{
+			name: "ungated group",
+			objs: []client.Object{
+				buildGangPod("pod-0", rbg.Name, "2", false),
+				buildGangPod("pod-1", rbg.Name, "2", false),
+				buildGangPod("pod-2", rbg.Name, "2", false),
+			},
+			expectReason: GangGateUngated,
+			expectGated:  false,
+		},
+		{
+			name: "ungate once the group fits",
+			objs: []client.Object{
+				buildGangPod("pod-0", rbg.Name, "2", true),
+				buildGangPod("pod-1", rbg.Name, "2", true),
+				buildGangPod("pod-2", rbg.Name, "2", true),
+				buildNode("node-1", "4", true),
+				buildNode("node-2", "4", true),
+			},
+			expectReason: GangGateUngating,
+			expectGated:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objs...).Build()
+				result, err := NewGangGate(fakeClient).Check(context.TODO(), rbg)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectReason, result.Reason)
+				assert.Equal(t, tt.expectReason == GangGateUngated, result.Ungated)
+
+				pods := &corev1.PodList{}
+				assert.NoError(t, fakeClient.List(context.TODO(), pods, client.MatchingLabels{workloadsv1alpha.SetNameLabelKey: rbg.Name}))
+				for i := range pods.Items {
+					assert.Equal(t, tt.expectGated, HasGangSchedulingGate(&pods.Items[i]))
+				}
+			},
+		)
+	}
+}
+
+func TestGangGate_Ungate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha.AddToScheme(scheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha.RoleSpec{wrappers.BuildBasicRole("role-a").WithReplicas(3).Obj()}).Obj()
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			buildGangPod("pod-0", rbg.Name, "1", true),
+			buildGangPod("pod-1", rbg.Name, "1", true),
+			buildGangPod("pod-2", rbg.Name, "1", true),
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(
+				ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption,
+			) error {
+				if obj.GetName() == "pod-1" {
+					return apierrors.NewConflict(corev1.Resource("pods"), obj.GetName(), nil)
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+
+	// The gates of the other pods are removed despite the failure.
+	assert.Error(t, NewGangGate(fakeClient).Ungate(context.TODO(), rbg))
+	pods := &corev1.PodList{}
+	assert.NoError(t, fakeClient.List(context.TODO(), pods))
+	for i := range pods.Items {
+		assert.Equal(t, pods.Items[i].Name == "pod-1", HasGangSchedulingGate(&pods.Items[i]), pods.Items[i].Name)
+	}
+}
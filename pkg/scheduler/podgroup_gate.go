@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// HasPodGroupSchedulingGate returns true if the pod is held back until it is bound to its PodGroup.
+func HasPodGroupSchedulingGate(pod *corev1.Pod) bool {
+	return hasSchedulingGate(pod, PodGroupSchedulingGateName)
+}
+
+// BindPodToPodGroup binds a gated lws pod to the PodGroup of its lws group and removes the scheduling gate.
+// Returns false if the pod is not ready to be bound yet, e.g. the lws group index label is not set.
+func BindPodToPodGroup(rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec, pod *corev1.Pod) bool {
+	if NeedPodGroupSchedulingGate(rbg, role) {
+		groupIndex := pod.Labels[lwsv1.GroupIndexLabelKey]
+		if groupIndex == "" {
+			return false
+		}
+		podGroupName := RoleInstancePodGroupName(rbg, role, groupIndex)
+		if rbg.IsKubeGangScheduling() {
+			if pod.Labels == nil {
+				pod.Labels = map[string]string{}
+			}
+			pod.Labels[KubePodGroupLabelKey] = podGroupName
+		} else {
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[VolcanoPodGroupAnnotationKey] = podGroupName
+		}
+	}
+
+	// The gate is removed even if the role no longer needs it, so that pods are never stuck after a policy change.
+	RemoveSchedulingGate(pod, PodGroupSchedulingGateName)
+	return true
+}
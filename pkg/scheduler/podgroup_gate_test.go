@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestBindPodToPodGroup(t *testing.T) {
+	decode := wrappers.BuildLwsRole("decode").Obj()
+
+	buildPod := func(labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-rbg-decode-1-1", Namespace: "default", Labels: labels},
+			Spec: corev1.PodSpec{
+				SchedulingGates: []corev1.PodSchedulingGate{
+					{Name: "other"},
+					{Name: PodGroupSchedulingGateName},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		rbg               *workloadsv1alpha.RoleBasedGroup
+		pod               *corev1.Pod
+		expectBound       bool
+		expectLabel       string
+		expectAnnotation  string
+		expectGateRemoved bool
+	}{
+		{
+			name: "kube scheduling",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithKubeGangScheduling(true).
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			pod:               buildPod(map[string]string{lwsv1.GroupIndexLabelKey: "1"}),
+			expectBound:       true,
+			expectLabel:       "test-rbg-decode-1",
+			expectGateRemoved: true,
+		},
+		{
+			name: "volcano scheduling",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithVolcanoGangScheduling("", "default").
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			pod:               buildPod(map[string]string{lwsv1.GroupIndexLabelKey: "1"}),
+			expectBound:       true,
+			expectAnnotation:  "test-rbg-decode-1",
+			expectGateRemoved: true,
+		},
+		{
+			name: "group index not set yet",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithKubeGangScheduling(true).
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			pod:         buildPod(nil),
+			expectBound: false,
+		},
+		{
+			name:              "gate is no longer needed",
+			rbg:               wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").WithKubeGangScheduling(true).Obj(),
+			pod:               buildPod(map[string]string{lwsv1.GroupIndexLabelKey: "1"}),
+			expectBound:       true,
+			expectGateRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expectBound, BindPodToPodGroup(tt.rbg, &decode, tt.pod))
+				assert.Equal(t, tt.expectLabel, tt.pod.Labels[KubePodGroupLabelKey])
+				assert.Equal(t, tt.expectAnnotation, tt.pod.Annotations[VolcanoPodGroupAnnotationKey])
+				assert.Equal(t, !tt.expectGateRemoved, HasPodGroupSchedulingGate(tt.pod))
+				assert.Contains(t, tt.pod.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: "other"})
+			},
+		)
+	}
+}
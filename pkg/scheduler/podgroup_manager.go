@@ -3,8 +3,12 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strconv"
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,6 +32,9 @@ const (
 	KubePodGroupCrdName = "podgroups.scheduling.x-k8s.io"
 
 	VolcanoPodGroupCrdName = "podgroups.scheduling.volcano.sh"
+
+	// PodGroupSchedulingGateName holds pods back from scheduling until their PodGroup is set.
+	PodGroupSchedulingGateName = "rolebasedgroup.workloads.x-k8s.io/pod-group"
 )
 
 type PodGroupScheduler struct {
@@ -38,63 +45,208 @@ func NewPodGroupScheduler(client client.Client) *PodGroupScheduler {
 	return &PodGroupScheduler{client: client}
 }
 
-func (r *PodGroupScheduler) Reconcile(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, runtimeController *builder.TypedBuilder[reconcile.Request], watchedWorkload *sync.Map, apiReader client.Reader) error {
-	// not support change podGroup scheduler
-	if rbg.IsKubeGangScheduling() {
-		// check and load kube podGroup CRD
-		_, podGroupExist := watchedWorkload.Load(KubePodGroupCrdName)
-		if !podGroupExist {
-			if err := utils.CheckCrdExists(apiReader, KubePodGroupCrdName); err != nil {
-				return fmt.Errorf("scheduling plugin %s not ready", KubePodGroupCrdName)
+// Reconcile creates the PodGroups required by the podGroupPolicy of the rbg and deletes the stale ones, including
+// those of a previous gang-scheduling backend. It returns the number of pods still using a previous PodGroup
+// configuration, which are migrated by the rolling update of their workloads.
+func (r *PodGroupScheduler) Reconcile(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, runtimeController *builder.TypedBuilder[reconcile.Request], watchedWorkload *sync.Map, apiReader client.Reader) (int32, error) {
+	outdatedPods, err := r.CountOutdatedPods(ctx, rbg)
+	if err != nil {
+		return 0, err
+	}
+
+	var desired map[string]int32
+	if rbg.IsKubeGangScheduling() || rbg.IsVolcanoGangScheduling() {
+		desired = DesiredPodGroups(rbg)
+		// During a migration only part of the pods carry the new PodGroup protocol at a time,
+		// so the gang is relaxed until the rolling update completes.
+		if outdatedPods > 0 {
+			for name := range desired {
+				desired[name] = 1
 			}
-			watchedWorkload.LoadOrStore(KubePodGroupCrdName, struct{}{})
-			runtimeController.Owns(&schedv1alpha1.PodGroup{})
 		}
+	}
 
-		return r.createOrUpdateKubePodGroup(ctx, rbg)
+	if rbg.IsKubeGangScheduling() {
+		if err := watchPodGroups(KubePodGroupCrdName, &schedv1alpha1.PodGroup{}, runtimeController, watchedWorkload, apiReader); err != nil {
+			return 0, err
+		}
+		for name, minMember := range desired {
+			if err := r.createOrUpdateKubePodGroup(ctx, rbg, name, minMember); err != nil {
+				return 0, err
+			}
+		}
 	} else if rbg.IsVolcanoGangScheduling() {
-		// check and load volcano podGroup CRD
-		_, podGroupExist := watchedWorkload.Load(VolcanoPodGroupCrdName)
-		if !podGroupExist {
-			if err := utils.CheckCrdExists(apiReader, VolcanoPodGroupCrdName); err != nil {
-				return fmt.Errorf("scheduling plugin %s not ready", VolcanoPodGroupCrdName)
+		if err := watchPodGroups(VolcanoPodGroupCrdName, &volcanoschedulingv1beta1.PodGroup{}, runtimeController, watchedWorkload, apiReader); err != nil {
+			return 0, err
+		}
+		for name, minMember := range desired {
+			var minTaskMember map[string]int32
+			var minResources corev1.ResourceList
+			// the tasks of pods not migrated yet are unknown, so only the relaxed MinMember applies
+			if outdatedPods == 0 {
+				minTaskMember, minResources = VolcanoPodGroupTasks(rbg, name)
+			}
+			if err := r.createOrUpdateVolcanoPodGroup(ctx, rbg, name, minMember, minTaskMember, minResources); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return outdatedPods, r.cleanupStalePodGroups(ctx, rbg, desired, watchedWorkload)
+}
+
+// watchPodGroups starts watching the PodGroups of a gang-scheduling backend the first time it is used,
+// so that a backend switched on at runtime is watched like one whose CRD existed at startup.
+func watchPodGroups(crdName string, podGroup client.Object, runtimeController *builder.TypedBuilder[reconcile.Request], watchedWorkload *sync.Map, apiReader client.Reader) error {
+	if _, podGroupExist := watchedWorkload.Load(crdName); podGroupExist {
+		return nil
+	}
+	if err := utils.CheckCrdExists(apiReader, crdName); err != nil {
+		return fmt.Errorf("scheduling plugin %s not ready", crdName)
+	}
+	watchedWorkload.LoadOrStore(crdName, struct{}{})
+	runtimeController.Owns(podGroup)
+	return nil
+}
+
+// DesiredPodGroups returns the PodGroups required by the rbg, keyed by name, with their MinMember.
+// Roles contribute their minAvailable pods, and once the gang is shrunk after a schedule timeout,
+// the pods of optional roles are left out of the MinMember.
+func DesiredPodGroups(rbg *workloadsv1alpha.RoleBasedGroup) map[string]int32 {
+	desired := make(map[string]int32)
+	shrunk := rbg.IsGangShrunk()
+	switch rbg.Spec.PodGroupPolicy.GetScope() {
+	case workloadsv1alpha.RolePodGroupScope:
+		for i := range rbg.Spec.Roles {
+			role := &rbg.Spec.Roles[i]
+			minMember := max(int32(rbg.GetRoleMinAvailable(role)), 1)
+			if shrunk && role.Optional {
+				minMember = 1
 			}
-			watchedWorkload.LoadOrStore(VolcanoPodGroupCrdName, struct{}{})
-			runtimeController.Owns(&volcanoschedulingv1beta1.PodGroup{})
+			desired[RolePodGroupName(rbg, role)] = minMember
+		}
+	case workloadsv1alpha.RoleInstancePodGroupScope:
+		for i := range rbg.Spec.Roles {
+			role := &rbg.Spec.Roles[i]
+			// Each instance of a non-lws role is a single pod, which needs no gang.
+			if role.Workload.String() != workloadsv1alpha.LeaderWorkerSetWorkloadType {
+				continue
+			}
+			for index := 0; index < int(*role.Replicas); index++ {
+				desired[RoleInstancePodGroupName(rbg, role, strconv.Itoa(index))] = *role.LeaderWorkerSet.Size
+			}
+		}
+	default:
+		var minMember int32
+		for i := range rbg.Spec.Roles {
+			role := &rbg.Spec.Roles[i]
+			if shrunk && role.Optional {
+				continue
+			}
+			minMember += int32(rbg.GetRoleMinAvailable(role))
+		}
+		desired[rbg.Name] = minMember
+	}
+	return desired
+}
+
+// RolePodGroupName returns the name of the PodGroup of a role with Role scope.
+func RolePodGroupName(rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec) string {
+	return rbg.GetWorkloadName(role)
+}
+
+// RoleInstancePodGroupName returns the name of the PodGroup of a lws group with RoleInstance scope.
+func RoleInstancePodGroupName(rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec, groupIndex string) string {
+	return fmt.Sprintf("%s-%s", rbg.GetWorkloadName(role), groupIndex)
+}
+
+// NeedPodGroupSchedulingGate returns true if the pods of the role only learn their PodGroup after creation.
+// This is the case for lws roles with RoleInstance scope, since the group index is not known in the template.
+func NeedPodGroupSchedulingGate(rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec) bool {
+	return rbg.EnableGangScheduling() &&
+		rbg.Spec.PodGroupPolicy.GetScope() == workloadsv1alpha.RoleInstancePodGroupScope &&
+		role.Workload.String() == workloadsv1alpha.LeaderWorkerSetWorkloadType
+}
+
+func InjectPodGroupProtocol(rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec, pts *coreapplyv1.PodTemplateSpecApplyConfiguration) {
+	if rbg.IsSchedulingGatesGangScheduling() {
+		addSchedulingGate(pts, GangSchedulingGateName)
+		return
+	}
+	if !rbg.EnableGangScheduling() {
+		return
+	}
+
+	if NeedVolcanoTasks(rbg) {
+		InjectVolcanoTaskName(role, pts)
+	}
+
+	var podGroupName string
+	switch rbg.Spec.PodGroupPolicy.GetScope() {
+	case workloadsv1alpha.RolePodGroupScope:
+		podGroupName = RolePodGroupName(rbg, role)
+	case workloadsv1alpha.RoleInstancePodGroupScope:
+		// The pod group of a lws group is set on the pods by the pod group gate controller.
+		if NeedPodGroupSchedulingGate(rbg, role) {
+			addSchedulingGate(pts, PodGroupSchedulingGateName)
 		}
+		return
+	default:
+		podGroupName = rbg.Name
+	}
 
-		return r.createOrUpdateVolcanoPodGroup(ctx, rbg)
-	} else {
-		return r.deletePodGroup(ctx, rbg, watchedWorkload)
+	InjectPodGroupName(rbg, pts, podGroupName)
+}
+
+func addSchedulingGate(pts *coreapplyv1.PodTemplateSpecApplyConfiguration, name string) {
+	if pts.Spec == nil {
+		pts.WithSpec(coreapplyv1.PodSpec())
 	}
+	pts.Spec.WithSchedulingGates(coreapplyv1.PodSchedulingGate().WithName(name))
 }
 
-func InjectPodGroupProtocol(rbg *workloadsv1alpha.RoleBasedGroup, pts *coreapplyv1.PodTemplateSpecApplyConfiguration) {
+// InjectPodGroupName sets the label or annotation that binds pods to the PodGroup with the given name.
+func InjectPodGroupName(rbg *workloadsv1alpha.RoleBasedGroup, pts *coreapplyv1.PodTemplateSpecApplyConfiguration, podGroupName string) {
 	if rbg.IsKubeGangScheduling() {
-		pts.WithLabels(map[string]string{KubePodGroupLabelKey: rbg.Name})
+		pts.WithLabels(map[string]string{KubePodGroupLabelKey: podGroupName})
 	} else if rbg.IsVolcanoGangScheduling() {
-		pts.WithAnnotations(map[string]string{VolcanoPodGroupAnnotationKey: rbg.Name})
+		pts.WithAnnotations(map[string]string{VolcanoPodGroupAnnotationKey: podGroupName})
 	}
 }
 
-func (r *PodGroupScheduler) createOrUpdateVolcanoPodGroup(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup) error {
+func (r *PodGroupScheduler) createOrUpdateVolcanoPodGroup(
+	ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, name string, minMember int32,
+	minTaskMember map[string]int32, minResources corev1.ResourceList,
+) error {
 	logger := log.FromContext(ctx)
+	var minResourcesPtr *corev1.ResourceList
+	if len(minResources) > 0 {
+		minResourcesPtr = &minResources
+	}
+	if len(minTaskMember) == 0 {
+		minTaskMember = nil
+	}
 	podGroup := &volcanoschedulingv1beta1.PodGroup{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      rbg.Name,
+			Name:      name,
 			Namespace: rbg.Namespace,
+			Labels: map[string]string{
+				workloadsv1alpha.SetNameLabelKey: rbg.Name,
+			},
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(rbg, rbg.GroupVersionKind()),
 			},
 		},
 		Spec: volcanoschedulingv1beta1.PodGroupSpec{
-			MinMember:         int32(rbg.GetGroupSize()),
+			MinMember:         minMember,
+			MinTaskMember:     minTaskMember,
+			MinResources:      minResourcesPtr,
 			Queue:             rbg.Spec.PodGroupPolicy.VolcanoScheduling.Queue,
 			PriorityClassName: rbg.Spec.PodGroupPolicy.VolcanoScheduling.PriorityClassName,
 		},
 	}
 
-	err := r.client.Get(ctx, types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}, podGroup)
+	err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: rbg.Namespace}, podGroup)
 	if err != nil && !apierrors.IsNotFound(err) {
 		logger.Error(err, "get pod group error")
 		return err
@@ -108,13 +260,17 @@ func (r *PodGroupScheduler) createOrUpdateVolcanoPodGroup(ctx context.Context, r
 		return err
 	}
 
-	if podGroup.Spec.MinMember != int32(rbg.GetGroupSize()) || podGroup.Spec.Queue != rbg.Spec.PodGroupPolicy.VolcanoScheduling.Queue ||
-		podGroup.Spec.PriorityClassName != rbg.Spec.PodGroupPolicy.VolcanoScheduling.PriorityClassName {
+	if podGroup.Spec.MinMember != minMember || podGroup.Spec.Queue != rbg.Spec.PodGroupPolicy.VolcanoScheduling.Queue ||
+		podGroup.Spec.PriorityClassName != rbg.Spec.PodGroupPolicy.VolcanoScheduling.PriorityClassName ||
+		!maps.Equal(podGroup.Spec.MinTaskMember, minTaskMember) ||
+		!apiequality.Semantic.DeepEqual(podGroup.Spec.MinResources, minResourcesPtr) {
 		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			if err := r.client.Get(ctx, types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}, podGroup); err != nil {
+			if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: rbg.Namespace}, podGroup); err != nil {
 				return err
 			}
-			podGroup.Spec.MinMember = int32(rbg.GetGroupSize())
+			podGroup.Spec.MinMember = minMember
+			podGroup.Spec.MinTaskMember = minTaskMember
+			podGroup.Spec.MinResources = minResourcesPtr
 			podGroup.Spec.Queue = rbg.Spec.PodGroupPolicy.VolcanoScheduling.Queue
 			podGroup.Spec.PriorityClassName = rbg.Spec.PodGroupPolicy.VolcanoScheduling.PriorityClassName
 			updateErr := r.client.Update(ctx, podGroup)
@@ -129,24 +285,27 @@ func (r *PodGroupScheduler) createOrUpdateVolcanoPodGroup(ctx context.Context, r
 	return nil
 }
 
-func (r *PodGroupScheduler) createOrUpdateKubePodGroup(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup) error {
+func (r *PodGroupScheduler) createOrUpdateKubePodGroup(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, name string, minMember int32) error {
 	logger := log.FromContext(ctx)
 	gvk := utils.GetRbgGVK()
 	podGroup := &schedv1alpha1.PodGroup{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      rbg.Name,
+			Name:      name,
 			Namespace: rbg.Namespace,
+			Labels: map[string]string{
+				workloadsv1alpha.SetNameLabelKey: rbg.Name,
+			},
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(rbg, rbg.GroupVersionKind()),
 			},
 		},
 		Spec: schedv1alpha1.PodGroupSpec{
-			MinMember:              int32(rbg.GetGroupSize()),
+			MinMember:              minMember,
 			ScheduleTimeoutSeconds: rbg.Spec.PodGroupPolicy.KubeScheduling.ScheduleTimeoutSeconds,
 		},
 	}
 
-	err := r.client.Get(ctx, types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}, podGroup)
+	err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: rbg.Namespace}, podGroup)
 	if err != nil && !apierrors.IsNotFound(err) {
 		logger.Error(err, "get pod group error")
 		return err
@@ -160,18 +319,18 @@ func (r *PodGroupScheduler) createOrUpdateKubePodGroup(ctx context.Context, rbg
 		return err
 	}
 
-	if podGroup.Spec.MinMember != int32(rbg.GetGroupSize()) {
+	if podGroup.Spec.MinMember != minMember {
 		err = retry.RetryOnConflict(
 			retry.DefaultRetry, func() error {
 				if err := r.client.Get(
-					ctx, types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}, podGroup,
+					ctx, types.NamespacedName{Name: name, Namespace: rbg.Namespace}, podGroup,
 				); err != nil {
 					return err
 				}
 				if !utils.CheckOwnerReference(podGroup.OwnerReferences, utils.GetRbgGVK()) {
 					podGroup.OwnerReferences = append(podGroup.OwnerReferences, *metav1.NewControllerRef(rbg, gvk))
 				}
-				podGroup.Spec.MinMember = int32(rbg.GetGroupSize())
+				podGroup.Spec.MinMember = minMember
 				updateErr := r.client.Update(ctx, podGroup)
 				return updateErr
 			},
@@ -185,32 +344,59 @@ func (r *PodGroupScheduler) createOrUpdateKubePodGroup(ctx context.Context, rbg
 	return nil
 }
 
-func (r *PodGroupScheduler) deletePodGroup(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, watchedWorkload *sync.Map) error {
+// DeletePodGroups deletes all PodGroups controlled by the rbg, e.g. while the rbg is suspended.
+func (r *PodGroupScheduler) DeletePodGroups(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, watchedWorkload *sync.Map) error {
+	return r.cleanupStalePodGroups(ctx, rbg, nil, watchedWorkload)
+}
+
+// cleanupStalePodGroups deletes the PodGroups controlled by the rbg which are not desired anymore,
+// e.g. after a scope change or a scale down of a lws role with RoleInstance scope.
+func (r *PodGroupScheduler) cleanupStalePodGroups(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup, desired map[string]int32, watchedWorkload *sync.Map) error {
+	logger := log.FromContext(ctx)
+	var podGroups []client.Object
+
 	if _, podGroupExist := watchedWorkload.Load(KubePodGroupCrdName); podGroupExist {
-		kubePodGroup := &schedv1alpha1.PodGroup{}
-		if err := r.client.Get(ctx, types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}, kubePodGroup); err == nil {
-			if metav1.IsControlledBy(kubePodGroup, rbg) {
-				if deleteErr := r.client.Delete(ctx, kubePodGroup); deleteErr != nil {
-					return deleteErr
-				}
-			}
-		} else if !apierrors.IsNotFound(err) {
+		kubePodGroups := &schedv1alpha1.PodGroupList{}
+		if err := r.client.List(ctx, kubePodGroups, client.InNamespace(rbg.Namespace)); err != nil {
 			return err
 		}
+		for i := range kubePodGroups.Items {
+			podGroups = append(podGroups, &kubePodGroups.Items[i])
+		}
 	}
 
 	if _, podGroupExist := watchedWorkload.Load(VolcanoPodGroupCrdName); podGroupExist {
-		volcanoPodGroup := &volcanoschedulingv1beta1.PodGroup{}
-		if err := r.client.Get(ctx, types.NamespacedName{Name: rbg.Name, Namespace: rbg.Namespace}, volcanoPodGroup); err == nil {
-			if metav1.IsControlledBy(volcanoPodGroup, rbg) {
-				if deleteErr := r.client.Delete(ctx, volcanoPodGroup); deleteErr != nil {
-					return deleteErr
-				}
-			}
-		} else if !apierrors.IsNotFound(err) {
+		volcanoPodGroups := &volcanoschedulingv1beta1.PodGroupList{}
+		if err := r.client.List(ctx, volcanoPodGroups, client.InNamespace(rbg.Namespace)); err != nil {
+			return err
+		}
+		for i := range volcanoPodGroups.Items {
+			podGroups = append(podGroups, &volcanoPodGroups.Items[i])
+		}
+	}
+
+	for _, podGroup := range podGroups {
+		if !metav1.IsControlledBy(podGroup, rbg) {
+			continue
+		}
+		if _, found := desired[podGroup.GetName()]; found && isPodGroupOfCurrentScheduler(rbg, podGroup) {
+			continue
+		}
+		logger.Info("delete stale pod group", "podGroup", podGroup.GetName())
+		if err := r.client.Delete(ctx, podGroup); err != nil && !apierrors.IsNotFound(err) {
 			return err
 		}
 	}
 
 	return nil
 }
+
+func isPodGroupOfCurrentScheduler(rbg *workloadsv1alpha.RoleBasedGroup, podGroup client.Object) bool {
+	switch podGroup.(type) {
+	case *schedv1alpha1.PodGroup:
+		return rbg.IsKubeGangScheduling()
+	case *volcanoschedulingv1beta1.PodGroup:
+		return rbg.IsVolcanoGangScheduling()
+	}
+	return false
+}
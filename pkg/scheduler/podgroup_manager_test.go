@@ -6,10 +6,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,6 +29,7 @@ import (
 func TestPodGroupScheduler_Reconcile(t *testing.T) {
 	// Define test scheme
 	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
 	_ = workloadsv1alpha.AddToScheme(scheme)
 	_ = schedv1alpha1.AddToScheme(scheme)
 	_ = volcanoschedulingv1beta1.AddToScheme(scheme)
@@ -235,7 +238,7 @@ func TestPodGroupScheduler_Reconcile(t *testing.T) {
 				if tt.preFunc != nil {
 					tt.preFunc()
 				}
-				err := scheduler.Reconcile(ctx, tt.rbg, &runtimeController, &watchedWorkload, tt.apiReader)
+				_, err := scheduler.Reconcile(ctx, tt.rbg, &runtimeController, &watchedWorkload, tt.apiReader)
 
 				// Verify
 				if (err != nil) != tt.expectError {
@@ -295,3 +298,214 @@ func TestPodGroupScheduler_Reconcile(t *testing.T) {
 		)
 	}
 }
+
+func TestDesiredPodGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		rbg    *workloadsv1alpha.RoleBasedGroup
+		expect map[string]int32
+	}{
+		{
+			name: "group scope",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles([]workloadsv1alpha.RoleSpec{
+					wrappers.BuildBasicRole("router").WithReplicas(2).Obj(),
+					wrappers.BuildLwsRole("decode").WithReplicas(3).Obj(),
+				}).
+				WithKubeGangScheduling(true).Obj(),
+			expect: map[string]int32{"test-rbg": 8},
+		},
+		{
+			name: "role scope",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles([]workloadsv1alpha.RoleSpec{
+					wrappers.BuildBasicRole("router").WithReplicas(2).Obj(),
+					wrappers.BuildLwsRole("decode").WithReplicas(3).Obj(),
+				}).
+				WithKubeGangScheduling(true).
+				WithPodGroupScope(workloadsv1alpha.RolePodGroupScope).Obj(),
+			expect: map[string]int32{"test-rbg-router": 2, "test-rbg-decode": 6},
+		},
+		{
+			name: "role instance scope",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles([]workloadsv1alpha.RoleSpec{
+					wrappers.BuildBasicRole("router").WithReplicas(2).Obj(),
+					wrappers.BuildLwsRole("decode").WithReplicas(3).Obj(),
+				}).
+				WithVolcanoGangScheduling("", "default").
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			expect: map[string]int32{"test-rbg-decode-0": 2, "test-rbg-decode-1": 2, "test-rbg-decode-2": 2},
+		},
+		{
+			name: "group scope with gang shrunk after schedule timeout",
+			rbg: func() *workloadsv1alpha.RoleBasedGroup {
+				router := wrappers.BuildBasicRole("router").WithReplicas(2).Obj()
+				decode := wrappers.BuildLwsRole("decode").WithReplicas(3).Obj()
+				decode.Optional = true
+				rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+					WithRoles([]workloadsv1alpha.RoleSpec{router, decode}).
+					WithKubeGangScheduling(true).Obj()
+				rbg.Status.Conditions = []metav1.Condition{{
+					Type:   string(workloadsv1alpha.RoleBasedGroupGangShrunk),
+					Status: metav1.ConditionTrue,
+				}}
+				return rbg
+			}(),
+			expect: map[string]int32{"test-rbg": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expect, DesiredPodGroups(tt.rbg))
+			},
+		)
+	}
+}
+
+func TestPodGroupScheduler_Reconcile_CleanupStalePodGroups(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha.AddToScheme(scheme)
+	_ = schedv1alpha1.AddToScheme(scheme)
+	_ = volcanoschedulingv1beta1.AddToScheme(scheme)
+
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha.RoleSpec{
+			wrappers.BuildBasicRole("router").WithReplicas(1).Obj(),
+			wrappers.BuildBasicRole("prefill").WithReplicas(2).Obj(),
+		}).
+		WithKubeGangScheduling(true).
+		WithPodGroupScope(workloadsv1alpha.RolePodGroupScope).Obj()
+
+	newPodGroup := func(name string, controlled bool) *schedv1alpha1.PodGroup {
+		pg := &schedv1alpha1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: rbg.Namespace},
+		}
+		if controlled {
+			pg.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())}
+		}
+		return pg
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		// pod group of the previous Group scope
+		newPodGroup("test-rbg", true),
+		// pod group of a removed role
+		newPodGroup("test-rbg-decode", true),
+		// pod group not owned by the rbg
+		newPodGroup("other", false),
+	).Build()
+
+	runtimeController := builder.TypedBuilder[reconcile.Request]{}
+	watchedWorkload := sync.Map{}
+	watchedWorkload.Store(KubePodGroupCrdName, struct{}{})
+
+	scheduler := NewPodGroupScheduler(fakeClient)
+	ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+	_, err := scheduler.Reconcile(ctx, rbg, &runtimeController, &watchedWorkload, nil)
+	assert.NoError(t, err)
+
+	podGroups := &schedv1alpha1.PodGroupList{}
+	assert.NoError(t, fakeClient.List(ctx, podGroups))
+	minMembers := map[string]int32{}
+	for _, pg := range podGroups.Items {
+		minMembers[pg.Name] = pg.Spec.MinMember
+	}
+	assert.Equal(t, map[string]int32{"test-rbg-router": 1, "test-rbg-prefill": 2, "other": 0}, minMembers)
+}
+
+func TestInjectPodGroupProtocol(t *testing.T) {
+	router := wrappers.BuildBasicRole("router").Obj()
+	decode := wrappers.BuildLwsRole("decode").Obj()
+
+	tests := []struct {
+		name              string
+		rbg               *workloadsv1alpha.RoleBasedGroup
+		role              *workloadsv1alpha.RoleSpec
+		expectLabels      map[string]string
+		expectAnnotations map[string]string
+		expectGate        string
+	}{
+		{
+			name:         "kube group scope",
+			rbg:          wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").WithKubeGangScheduling(true).Obj(),
+			role:         &router,
+			expectLabels: map[string]string{KubePodGroupLabelKey: "test-rbg"},
+		},
+		{
+			name: "volcano role scope",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithVolcanoGangScheduling("", "default").
+				WithPodGroupScope(workloadsv1alpha.RolePodGroupScope).Obj(),
+			role:              &decode,
+			expectAnnotations: map[string]string{VolcanoPodGroupAnnotationKey: "test-rbg-decode"},
+		},
+		{
+			name: "volcano group scope with minAvailable",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles([]workloadsv1alpha.RoleSpec{router, wrappers.BuildBasicRole("prefill").WithMinAvailable(1).Obj()}).
+				WithVolcanoGangScheduling("", "default").Obj(),
+			role:              &router,
+			expectAnnotations: map[string]string{VolcanoPodGroupAnnotationKey: "test-rbg", VolcanoTaskSpecAnnotationKey: "router"},
+		},
+		{
+			name: "kube role instance scope with lws role",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithKubeGangScheduling(true).
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			role:       &decode,
+			expectGate: PodGroupSchedulingGateName,
+		},
+		{
+			name: "kube role instance scope with sts role",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithKubeGangScheduling(true).
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			role: &router,
+		},
+		{
+			name: "native scheduling gates",
+			rbg: func() *workloadsv1alpha.RoleBasedGroup {
+				rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj()
+				rbg.Spec.PodGroupPolicy = &workloadsv1alpha.PodGroupPolicy{
+					PodGroupPolicySource: workloadsv1alpha.PodGroupPolicySource{
+						SchedulingGates: &workloadsv1alpha.SchedulingGatesPodGroupPolicySource{},
+					},
+				}
+				return rbg
+			}(),
+			role:       &router,
+			expectGate: GangSchedulingGateName,
+		},
+		{
+			name: "gang scheduling disabled",
+			rbg:  wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj(),
+			role: &router,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				pts := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec())
+				InjectPodGroupProtocol(tt.rbg, tt.role, pts)
+
+				var labels, annotations map[string]string
+				if pts.ObjectMetaApplyConfiguration != nil {
+					labels, annotations = pts.Labels, pts.Annotations
+				}
+				assert.Equal(t, tt.expectLabels, labels)
+				assert.Equal(t, tt.expectAnnotations, annotations)
+				if tt.expectGate != "" {
+					assert.Len(t, pts.Spec.SchedulingGates, 1)
+					assert.Equal(t, tt.expectGate, *pts.Spec.SchedulingGates[0].Name)
+				} else {
+					assert.Empty(t, pts.Spec.SchedulingGates)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+)
+
+// CountOutdatedPods returns the number of running pods of the rbg which are not bound to their PodGroup the way the
+// current podGroupPolicy requires, e.g. after the gang-scheduling backend or the scope of a running rbg is changed.
+// Such pods are replaced by the rolling update of their workloads.
+func (r *PodGroupScheduler) CountOutdatedPods(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup) (int32, error) {
+	pods := &corev1.PodList{}
+	if err := r.client.List(
+		ctx, pods, client.InNamespace(rbg.Namespace),
+		client.MatchingLabels{workloadsv1alpha.SetNameLabelKey: rbg.Name},
+	); err != nil {
+		return 0, err
+	}
+
+	var outdated int32
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		role, err := rbg.GetRole(pod.Labels[workloadsv1alpha.SetRoleLabelKey])
+		if err != nil {
+			// pods of removed roles are deleted together with their workloads
+			continue
+		}
+		if !PodUsesCurrentPodGroupProtocol(rbg, role, pod) {
+			outdated++
+		}
+	}
+	return outdated, nil
+}
+
+// PodUsesCurrentPodGroupProtocol returns true if the PodGroup label and annotations of the pod match the ones
+// InjectPodGroupProtocol sets for the current podGroupPolicy.
+func PodUsesCurrentPodGroupProtocol(rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec, pod *corev1.Pod) bool {
+	// Gated pods are bound to the current PodGroup by the pod group gate controller before they are scheduled.
+	if HasPodGroupSchedulingGate(pod) {
+		return true
+	}
+
+	var kubePodGroupName, volcanoPodGroupName string
+	if rbg.IsKubeGangScheduling() {
+		kubePodGroupName = expectedPodGroupName(rbg, role, pod)
+	} else if rbg.IsVolcanoGangScheduling() {
+		volcanoPodGroupName = expectedPodGroupName(rbg, role, pod)
+		// a pod without the task annotation is not rolled for it, e.g. once a role sets minAvailable
+		if task, found := pod.Annotations[VolcanoTaskSpecAnnotationKey]; found && task != role.Name {
+			return false
+		}
+	}
+	return pod.Labels[KubePodGroupLabelKey] == kubePodGroupName &&
+		pod.Annotations[VolcanoPodGroupAnnotationKey] == volcanoPodGroupName
+}
+
+func expectedPodGroupName(rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec, pod *corev1.Pod) string {
+	switch rbg.Spec.PodGroupPolicy.GetScope() {
+	case workloadsv1alpha.RolePodGroupScope:
+		return RolePodGroupName(rbg, role)
+	case workloadsv1alpha.RoleInstancePodGroupScope:
+		groupIndex := pod.Labels[lwsv1.GroupIndexLabelKey]
+		if !NeedPodGroupSchedulingGate(rbg, role) || groupIndex == "" {
+			return ""
+		}
+		return RoleInstancePodGroupName(rbg, role, groupIndex)
+	default:
+		return rbg.Name
+	}
+}
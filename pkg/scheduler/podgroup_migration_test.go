@@ -0,0 +1,217 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+	schedv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
+	volcanoschedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func buildRolePod(name, rbgName, roleName string, labels, annotations map[string]string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				workloadsv1alpha.SetNameLabelKey: rbgName,
+				workloadsv1alpha.SetRoleLabelKey: roleName,
+			},
+			Annotations: annotations,
+		},
+	}
+	for k, v := range labels {
+		pod.Labels[k] = v
+	}
+	return pod
+}
+
+func TestPodUsesCurrentPodGroupProtocol(t *testing.T) {
+	router := wrappers.BuildBasicRole("router").Obj()
+	decode := wrappers.BuildLwsRole("decode").Obj()
+
+	tests := []struct {
+		name   string
+		rbg    *workloadsv1alpha.RoleBasedGroup
+		role   *workloadsv1alpha.RoleSpec
+		pod    *corev1.Pod
+		expect bool
+	}{
+		{
+			name: "kube pod group label matches",
+			rbg:  wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").WithKubeGangScheduling(true).Obj(),
+			role: &router,
+			pod: buildRolePod("router-0", "test-rbg", "router",
+				map[string]string{KubePodGroupLabelKey: "test-rbg"}, nil),
+			expect: true,
+		},
+		{
+			name: "kube pod group label left after switch to volcano",
+			rbg:  wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").WithVolcanoGangScheduling("", "default").Obj(),
+			role: &router,
+			pod: buildRolePod("router-0", "test-rbg", "router",
+				map[string]string{KubePodGroupLabelKey: "test-rbg"}, nil),
+			expect: false,
+		},
+		{
+			name: "volcano annotation left after gang scheduling disabled",
+			rbg:  wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").Obj(),
+			role: &router,
+			pod: buildRolePod("router-0", "test-rbg", "router",
+				nil, map[string]string{VolcanoPodGroupAnnotationKey: "test-rbg"}),
+			expect: false,
+		},
+		{
+			name: "volcano task annotation missing",
+			rbg:  wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").WithVolcanoGangScheduling("", "default").Obj(),
+			role: &router,
+			pod: buildRolePod("router-0", "test-rbg", "router",
+				nil, map[string]string{VolcanoPodGroupAnnotationKey: "test-rbg"}),
+			expect: true,
+		},
+		{
+			name: "volcano task annotation of another role",
+			rbg:  wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").WithVolcanoGangScheduling("", "default").Obj(),
+			role: &router,
+			pod: buildRolePod("router-0", "test-rbg", "router",
+				nil, map[string]string{VolcanoPodGroupAnnotationKey: "test-rbg", VolcanoTaskSpecAnnotationKey: "prefill"}),
+			expect: false,
+		},
+		{
+			name: "pod group of previous scope",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithKubeGangScheduling(true).
+				WithPodGroupScope(workloadsv1alpha.RolePodGroupScope).Obj(),
+			role: &router,
+			pod: buildRolePod("router-0", "test-rbg", "router",
+				map[string]string{KubePodGroupLabelKey: "test-rbg"}, nil),
+			expect: false,
+		},
+		{
+			name: "lws pod bound to its role instance pod group",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithVolcanoGangScheduling("", "default").
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			role: &decode,
+			pod: buildRolePod("decode-1", "test-rbg", "decode",
+				map[string]string{lwsv1.GroupIndexLabelKey: "1"},
+				map[string]string{VolcanoPodGroupAnnotationKey: "test-rbg-decode-1", VolcanoTaskSpecAnnotationKey: "decode"}),
+			expect: true,
+		},
+		{
+			name: "lws pod waiting to be bound",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithKubeGangScheduling(true).
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			role: &decode,
+			pod: func() *corev1.Pod {
+				pod := buildRolePod("decode-1", "test-rbg", "decode", map[string]string{lwsv1.GroupIndexLabelKey: "1"}, nil)
+				pod.Spec.SchedulingGates = []corev1.PodSchedulingGate{{Name: PodGroupSchedulingGateName}}
+				return pod
+			}(),
+			expect: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expect, PodUsesCurrentPodGroupProtocol(tt.rbg, tt.role, tt.pod))
+			},
+		)
+	}
+}
+
+func TestPodGroupScheduler_Reconcile_Migration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = workloadsv1alpha.AddToScheme(scheme)
+	_ = schedv1alpha1.AddToScheme(scheme)
+	_ = volcanoschedulingv1beta1.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	// the rbg is switched from kube to volcano gang-scheduling
+	rbg := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha.RoleSpec{
+			wrappers.BuildBasicRole("router").WithReplicas(2).Obj(),
+		}).
+		WithVolcanoGangScheduling("", "default").Obj()
+
+	kubePodGroup := &schedv1alpha1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-rbg",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rbg, rbg.GroupVersionKind())},
+		},
+		Spec: schedv1alpha1.PodGroupSpec{MinMember: 2},
+	}
+	migratedPod := buildRolePod("router-0", "test-rbg", "router",
+		nil, map[string]string{VolcanoPodGroupAnnotationKey: "test-rbg", VolcanoTaskSpecAnnotationKey: "router"})
+	outdatedPod := buildRolePod("router-1", "test-rbg", "router",
+		map[string]string{KubePodGroupLabelKey: "test-rbg"}, nil)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(kubePodGroup, migratedPod, outdatedPod).Build()
+	apiReader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: VolcanoPodGroupCrdName},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+					{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+				},
+			},
+		},
+	).Build()
+
+	runtimeController := builder.TypedBuilder[reconcile.Request]{}
+	watchedWorkload := sync.Map{}
+	watchedWorkload.Store(KubePodGroupCrdName, struct{}{})
+
+	scheduler := NewPodGroupScheduler(fakeClient)
+	ctx := log.IntoContext(context.TODO(), zap.New().WithValues("env", "test"))
+	outdatedPods, err := scheduler.Reconcile(ctx, rbg, &runtimeController, &watchedWorkload, apiReader)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), outdatedPods)
+
+	// the volcano pod group crd is watched from now on
+	_, watched := watchedWorkload.Load(VolcanoPodGroupCrdName)
+	assert.True(t, watched)
+
+	// the pod group of the previous backend is deleted
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "test-rbg", Namespace: "default"}, &schedv1alpha1.PodGroup{})
+	assert.Error(t, err)
+
+	// the gang is relaxed while the pods are rolled out
+	volcanoPodGroup := &volcanoschedulingv1beta1.PodGroup{}
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "test-rbg", Namespace: "default"}, volcanoPodGroup))
+	assert.Equal(t, int32(1), volcanoPodGroup.Spec.MinMember)
+
+	// the gang is restored once all pods are migrated
+	outdatedPod.Labels = map[string]string{
+		workloadsv1alpha.SetNameLabelKey: "test-rbg",
+		workloadsv1alpha.SetRoleLabelKey: "router",
+	}
+	outdatedPod.Annotations = map[string]string{VolcanoPodGroupAnnotationKey: "test-rbg", VolcanoTaskSpecAnnotationKey: "router"}
+	assert.NoError(t, fakeClient.Update(ctx, outdatedPod))
+
+	outdatedPods, err = scheduler.Reconcile(ctx, rbg, &runtimeController, &watchedWorkload, apiReader)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), outdatedPods)
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "test-rbg", Namespace: "default"}, volcanoPodGroup))
+	assert.Equal(t, int32(2), volcanoPodGroup.Spec.MinMember)
+}
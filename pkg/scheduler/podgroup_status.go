@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	schedv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
+	volcanoschedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+const (
+	// PodGroupsScheduled means every PodGroup of the rbg is scheduled.
+	PodGroupsScheduled = "PodGroupsScheduled"
+	// PodGroupNotScheduled means a PodGroup is waiting for its members or for the scheduler.
+	PodGroupNotScheduled = "PodGroupNotScheduled"
+	// PodGroupUnschedulable means the scheduler reported a PodGroup as unschedulable.
+	PodGroupUnschedulable = "Unschedulable"
+
+	// defaultVolcanoScheduleTimeoutSeconds is used when the schedule timeout of volcano scheduling is not set.
+	defaultVolcanoScheduleTimeoutSeconds = 300
+	// defaultKubeScheduleTimeoutSeconds matches the default of the scheduler-plugins coscheduling plugin.
+	defaultKubeScheduleTimeoutSeconds = 60
+)
+
+// PodGroupScheduleStatus summarizes the scheduling state of the PodGroups of a rbg.
+type PodGroupScheduleStatus struct {
+	// Scheduled is true once every PodGroup of the rbg is scheduled.
+	Scheduled bool
+	// Reason and Message describe the first PodGroup which is not scheduled.
+	Reason  string
+	Message string
+}
+
+// ScheduleStatus reads the status of the PodGroups the rbg requires. It returns nil if no PodGroup has a status yet,
+// e.g. when the status is not maintained by the scheduler.
+func (r *PodGroupScheduler) ScheduleStatus(ctx context.Context, rbg *workloadsv1alpha.RoleBasedGroup) (*PodGroupScheduleStatus, error) {
+	desired := DesiredPodGroups(rbg)
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var observed bool
+	var pending *PodGroupScheduleStatus
+	for _, name := range names {
+		key := client.ObjectKey{Namespace: rbg.Namespace, Name: name}
+		var status *PodGroupScheduleStatus
+		if rbg.IsKubeGangScheduling() {
+			podGroup := &schedv1alpha1.PodGroup{}
+			if err := r.client.Get(ctx, key, podGroup); err != nil {
+				// the PodGroup is not created yet, e.g. a role was just added
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			status = kubePodGroupScheduleStatus(podGroup)
+		} else if rbg.IsVolcanoGangScheduling() {
+			podGroup := &volcanoschedulingv1beta1.PodGroup{}
+			if err := r.client.Get(ctx, key, podGroup); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			status = volcanoPodGroupScheduleStatus(podGroup)
+		}
+		if status == nil {
+			continue
+		}
+		observed = true
+		// an unschedulable PodGroup explains more than one still waiting for its members
+		if !status.Scheduled && (pending == nil || pending.Reason != PodGroupUnschedulable) {
+			pending = status
+		}
+	}
+
+	if !observed {
+		return nil, nil
+	}
+	if pending != nil {
+		return pending, nil
+	}
+	return &PodGroupScheduleStatus{Scheduled: true, Reason: PodGroupsScheduled, Message: "All PodGroups are scheduled"}, nil
+}
+
+func kubePodGroupScheduleStatus(podGroup *schedv1alpha1.PodGroup) *PodGroupScheduleStatus {
+	switch podGroup.Status.Phase {
+	case "":
+		return nil
+	case schedv1alpha1.PodGroupPending, schedv1alpha1.PodGroupScheduling:
+		return &PodGroupScheduleStatus{
+			Reason: PodGroupNotScheduled,
+			Message: fmt.Sprintf("PodGroup %s is %s, %d of %d pods are running",
+				podGroup.Name, podGroup.Status.Phase, podGroup.Status.Running, podGroup.Spec.MinMember),
+		}
+	default:
+		// a Failed PodGroup was scheduled and one of its pods failed at runtime, which the schedule timeout of the
+		// rbg must not act on
+		return &PodGroupScheduleStatus{Scheduled: true}
+	}
+}
+
+func volcanoPodGroupScheduleStatus(podGroup *volcanoschedulingv1beta1.PodGroup) *PodGroupScheduleStatus {
+	switch podGroup.Status.Phase {
+	case "":
+		return nil
+	case volcanoschedulingv1beta1.PodGroupPending, volcanoschedulingv1beta1.PodGroupInqueue, volcanoschedulingv1beta1.PodGroupUnknown:
+		for _, condition := range podGroup.Status.Conditions {
+			if condition.Type == volcanoschedulingv1beta1.PodGroupUnschedulableType && condition.Status == corev1.ConditionTrue {
+				return &PodGroupScheduleStatus{
+					Reason:  PodGroupUnschedulable,
+					Message: fmt.Sprintf("PodGroup %s is unschedulable: %s", podGroup.Name, condition.Message),
+				}
+			}
+		}
+		return &PodGroupScheduleStatus{
+			Reason:  PodGroupNotScheduled,
+			Message: fmt.Sprintf("PodGroup %s is %s", podGroup.Name, podGroup.Status.Phase),
+		}
+	default:
+		return &PodGroupScheduleStatus{Scheduled: true}
+	}
+}
+
+// ScheduleTimeout returns the time the PodGroups of the rbg may stay unscheduled before podGroupPolicy.onTimeout applies.
+func ScheduleTimeout(rbg *workloadsv1alpha.RoleBasedGroup) time.Duration {
+	seconds := int32(0)
+	if rbg.IsKubeGangScheduling() {
+		seconds = defaultKubeScheduleTimeoutSeconds
+		if timeout := rbg.Spec.PodGroupPolicy.KubeScheduling.ScheduleTimeoutSeconds; timeout != nil && *timeout > 0 {
+			seconds = *timeout
+		}
+	} else if rbg.IsVolcanoGangScheduling() {
+		seconds = defaultVolcanoScheduleTimeoutSeconds
+		if timeout := rbg.Spec.PodGroupPolicy.VolcanoScheduling.ScheduleTimeoutSeconds; timeout != nil && *timeout > 0 {
+			seconds = *timeout
+		}
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+	schedv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
+	volcanoschedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestPodGroupScheduler_ScheduleStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = workloadsv1alpha.AddToScheme(scheme)
+	_ = schedv1alpha1.AddToScheme(scheme)
+	_ = volcanoschedulingv1beta1.AddToScheme(scheme)
+
+	kubeRBG := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").WithKubeGangScheduling(true).Obj()
+	volcanoRBG := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").WithVolcanoGangScheduling("", "default").Obj()
+	roleScopedRBG := wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+		WithRoles([]workloadsv1alpha.RoleSpec{
+			wrappers.BuildBasicRole("prefill").Obj(),
+			wrappers.BuildBasicRole("decode").Obj(),
+		}).
+		WithKubeGangScheduling(true).
+		WithPodGroupScope(workloadsv1alpha.RolePodGroupScope).
+		Obj()
+	objectMeta := metav1.ObjectMeta{Name: "test-rbg", Namespace: "default"}
+
+	tests := []struct {
+		name   string
+		rbg    *workloadsv1alpha.RoleBasedGroup
+		objs   []client.Object
+		expect *PodGroupScheduleStatus
+	}{
+		{
+			name:   "pod group not created yet",
+			rbg:    kubeRBG,
+			expect: nil,
+		},
+		{
+			name:   "pod group status not maintained",
+			rbg:    kubeRBG,
+			objs:   []client.Object{&schedv1alpha1.PodGroup{ObjectMeta: objectMeta}},
+			expect: nil,
+		},
+		{
+			name: "kube pod group running",
+			rbg:  kubeRBG,
+			objs: []client.Object{&schedv1alpha1.PodGroup{
+				ObjectMeta: objectMeta,
+				Status:     schedv1alpha1.PodGroupStatus{Phase: schedv1alpha1.PodGroupRunning},
+			}},
+			expect: &PodGroupScheduleStatus{Scheduled: true, Reason: PodGroupsScheduled, Message: "All PodGroups are scheduled"},
+		},
+		{
+			name: "kube pod group pending",
+			rbg:  kubeRBG,
+			objs: []client.Object{&schedv1alpha1.PodGroup{
+				ObjectMeta: objectMeta,
+				Spec:       schedv1alpha1.PodGroupSpec{MinMember: 1},
+				Status:     schedv1alpha1.PodGroupStatus{Phase: schedv1alpha1.PodGroupScheduling},
+			}},
+			expect: &PodGroupScheduleStatus{
+				Reason:  PodGroupNotScheduled,
+				Message: "PodGroup test-rbg is Scheduling, 0 of 1 pods are running",
+			},
+		},
+		{
+			name: "kube pod group failed at runtime is scheduled",
+			rbg:  kubeRBG,
+			objs: []client.Object{&schedv1alpha1.PodGroup{
+				ObjectMeta: objectMeta,
+				Spec:       schedv1alpha1.PodGroupSpec{MinMember: 2},
+				Status:     schedv1alpha1.PodGroupStatus{Phase: schedv1alpha1.PodGroupFailed, Running: 1},
+			}},
+			expect: &PodGroupScheduleStatus{
+				Scheduled: true,
+				Reason:    PodGroupsScheduled,
+				Message:   "All PodGroups are scheduled",
+			},
+		},
+		{
+			name: "pod group of one role not created yet",
+			rbg:  roleScopedRBG,
+			objs: []client.Object{&schedv1alpha1.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rbg-prefill", Namespace: "default"},
+				Spec:       schedv1alpha1.PodGroupSpec{MinMember: 1},
+				Status:     schedv1alpha1.PodGroupStatus{Phase: schedv1alpha1.PodGroupPending},
+			}},
+			expect: &PodGroupScheduleStatus{
+				Reason:  PodGroupNotScheduled,
+				Message: "PodGroup test-rbg-prefill is Pending, 0 of 1 pods are running",
+			},
+		},
+		{
+			name: "volcano pod group unschedulable",
+			rbg:  volcanoRBG,
+			objs: []client.Object{&volcanoschedulingv1beta1.PodGroup{
+				ObjectMeta: objectMeta,
+				Status: volcanoschedulingv1beta1.PodGroupStatus{
+					Phase: volcanoschedulingv1beta1.PodGroupPending,
+					Conditions: []volcanoschedulingv1beta1.PodGroupCondition{{
+						Type:    volcanoschedulingv1beta1.PodGroupUnschedulableType,
+						Status:  corev1.ConditionTrue,
+						Message: "1/1 tasks in gang unschedulable: pod group is not ready",
+					}},
+				},
+			}},
+			expect: &PodGroupScheduleStatus{
+				Reason:  PodGroupUnschedulable,
+				Message: "PodGroup test-rbg is unschedulable: 1/1 tasks in gang unschedulable: pod group is not ready",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objs...).Build()
+				status, err := NewPodGroupScheduler(fakeClient).ScheduleStatus(context.TODO(), tt.rbg)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expect, status)
+			},
+		)
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+// VolcanoTaskSpecAnnotationKey assigns a pod to a task of its volcano PodGroup. Every role is a task named after the role.
+const VolcanoTaskSpecAnnotationKey = batchv1alpha1.TaskSpecKey
+
+// NeedVolcanoTasks returns true if the volcano PodGroup of the rbg needs minTaskMember, i.e. it covers several roles
+// and a role sets minAvailable, so that minMember alone does not tell which pods the gang requires.
+// Only then the pods carry the task annotation, which would otherwise roll the workloads of every volcano rbg.
+func NeedVolcanoTasks(rbg *workloadsv1alpha.RoleBasedGroup) bool {
+	if !rbg.IsVolcanoGangScheduling() || rbg.Spec.PodGroupPolicy.GetScope() != workloadsv1alpha.GroupPodGroupScope ||
+		len(rbg.Spec.Roles) < 2 {
+		return false
+	}
+	for i := range rbg.Spec.Roles {
+		if rbg.Spec.Roles[i].MinAvailable != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// InjectVolcanoTaskName sets the annotation that assigns the pods of a role to the task of the role.
+func InjectVolcanoTaskName(role *workloadsv1alpha.RoleSpec, pts *coreapplyv1.PodTemplateSpecApplyConfiguration) {
+	pts.WithAnnotations(map[string]string{VolcanoTaskSpecAnnotationKey: role.Name})
+}
+
+// VolcanoPodGroupTasks returns the minTaskMember and the minResources of the volcano PodGroup with the given name.
+// The minResources are computed from the role templates, the patches of LeaderWorkerSet templates are not considered.
+// The minTaskMember is nil unless NeedVolcanoTasks.
+func VolcanoPodGroupTasks(rbg *workloadsv1alpha.RoleBasedGroup, podGroupName string) (map[string]int32, corev1.ResourceList) {
+	minTaskMember := map[string]int32{}
+	minResources := corev1.ResourceList{}
+	shrunk := rbg.IsGangShrunk()
+
+	for i := range rbg.Spec.Roles {
+		role := &rbg.Spec.Roles[i]
+		var members int32
+		switch rbg.Spec.PodGroupPolicy.GetScope() {
+		case workloadsv1alpha.RolePodGroupScope:
+			if RolePodGroupName(rbg, role) != podGroupName {
+				continue
+			}
+			members = max(int32(rbg.GetRoleMinAvailable(role)), 1)
+			if shrunk && role.Optional {
+				members = 1
+			}
+		case workloadsv1alpha.RoleInstancePodGroupScope:
+			if !isRoleInstancePodGroup(rbg, role, podGroupName) {
+				continue
+			}
+			members = *role.LeaderWorkerSet.Size
+		default:
+			if rbg.Name != podGroupName || (shrunk && role.Optional) {
+				continue
+			}
+			members = int32(rbg.GetRoleMinAvailable(role))
+		}
+		if members == 0 {
+			continue
+		}
+
+		minTaskMember[role.Name] = members
+		for name, quantity := range podSpecRequests(&role.Template.Spec) {
+			total := minResources[name]
+			for n := int32(0); n < members; n++ {
+				total.Add(quantity)
+			}
+			minResources[name] = total
+		}
+	}
+	if !NeedVolcanoTasks(rbg) {
+		return nil, minResources
+	}
+	return minTaskMember, minResources
+}
+
+func isRoleInstancePodGroup(rbg *workloadsv1alpha.RoleBasedGroup, role *workloadsv1alpha.RoleSpec, podGroupName string) bool {
+	if role.Workload.String() != workloadsv1alpha.LeaderWorkerSetWorkloadType {
+		return false
+	}
+	for index := 0; index < int(*role.Replicas); index++ {
+		if RoleInstancePodGroupName(rbg, role, strconv.Itoa(index)) == podGroupName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	workloadsv1alpha "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
+	"sigs.k8s.io/rbgs/test/wrappers"
+)
+
+func TestVolcanoPodGroupTasks(t *testing.T) {
+	gpuTemplate := wrappers.BuildBasicPodTemplateSpec().Obj()
+	gpuTemplate.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("1"),
+	}
+	roles := []workloadsv1alpha.RoleSpec{
+		wrappers.BuildBasicRole("router").WithReplicas(2).WithMinAvailable(0).Obj(),
+		wrappers.BuildBasicRole("prefill").WithReplicas(2).WithTemplate(gpuTemplate).Obj(),
+		wrappers.BuildLwsRole("decode").WithReplicas(2).WithMinAvailable(2).WithTemplate(gpuTemplate).Obj(),
+	}
+
+	tests := []struct {
+		name                string
+		rbg                 *workloadsv1alpha.RoleBasedGroup
+		podGroupName        string
+		expectMinTaskMember map[string]int32
+		expectMinResources  corev1.ResourceList
+		expectMinMember     int32
+	}{
+		{
+			name: "group scope",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles(roles).
+				WithVolcanoGangScheduling("", "default").Obj(),
+			podGroupName:        "test-rbg",
+			expectMinTaskMember: map[string]int32{"prefill": 2, "decode": 2},
+			expectMinResources:  corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("4")},
+			expectMinMember:     4,
+		},
+		{
+			name: "group scope without minAvailable",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles([]workloadsv1alpha.RoleSpec{
+					roles[1], wrappers.BuildBasicRole("decode").WithReplicas(2).WithTemplate(gpuTemplate).Obj(),
+				}).
+				WithVolcanoGangScheduling("", "default").Obj(),
+			podGroupName:        "test-rbg",
+			expectMinTaskMember: nil,
+			expectMinResources:  corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("4")},
+			expectMinMember:     4,
+		},
+		{
+			name: "role scope",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles(roles).
+				WithVolcanoGangScheduling("", "default").
+				WithPodGroupScope(workloadsv1alpha.RolePodGroupScope).Obj(),
+			podGroupName:        "test-rbg-prefill",
+			expectMinTaskMember: nil,
+			expectMinResources:  corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("2")},
+			expectMinMember:     2,
+		},
+		{
+			name: "role instance scope",
+			rbg: wrappers.BuildBasicRoleBasedGroup("test-rbg", "default").
+				WithRoles(roles).
+				WithVolcanoGangScheduling("", "default").
+				WithPodGroupScope(workloadsv1alpha.RoleInstancePodGroupScope).Obj(),
+			podGroupName:        "test-rbg-decode-1",
+			expectMinTaskMember: nil,
+			expectMinResources:  corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("2")},
+			expectMinMember:     2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				minTaskMember, minResources := VolcanoPodGroupTasks(tt.rbg, tt.podGroupName)
+				assert.Equal(t, tt.expectMinTaskMember, minTaskMember)
+				assert.Equal(t, len(tt.expectMinResources), len(minResources))
+				for name, quantity := range tt.expectMinResources {
+					actual := minResources[name]
+					assert.Equal(t, 0, quantity.Cmp(actual), "resource %s: expect %s, got %s", name, quantity.String(), actual.String())
+				}
+				assert.Equal(t, tt.expectMinMember, DesiredPodGroups(tt.rbg)[tt.podGroupName])
+			},
+		)
+	}
+}
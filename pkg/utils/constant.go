@@ -5,6 +5,9 @@ const (
 	// LwsCrdName is LWS CRD name
 	LwsCrdName = "leaderworkersets.leaderworkerset.x-k8s.io"
 
+	// AdvancedStatefulSetCrdName is OpenKruise Advanced StatefulSet CRD name
+	AdvancedStatefulSetCrdName = "statefulsets.apps.kruise.io"
+
 	// RbgCRDName is rbg crd name
 	RbgCRDName = "rolebasedgroups.workloads.x-k8s.io"
 
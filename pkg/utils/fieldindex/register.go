@@ -7,10 +7,13 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	workloadsv1alpha1 "sigs.k8s.io/rbgs/api/workloads/v1alpha1"
 )
 
 const (
 	IndexNameForOwnerRefUID = "ownerRefUID"
+	// IndexNameForDependencyRBG indexes rbgs by the <namespace>/<name> of the other rbgs their roles depend on.
+	IndexNameForDependencyRBG = "dependencyRBG"
 )
 
 var (
@@ -25,6 +28,24 @@ var ownerIndexFunc = func(obj client.Object) []string {
 	return owners
 }
 
+// DependencyRBGIndexFunc returns the <namespace>/<name> of the other rbgs the roles of a rbg depend on.
+var DependencyRBGIndexFunc = func(obj client.Object) []string {
+	rbg, ok := obj.(*workloadsv1alpha1.RoleBasedGroup)
+	if !ok {
+		return nil
+	}
+	var keys []string
+	for i := range rbg.Spec.Roles {
+		for j := range rbg.Spec.Roles[i].DependencyConditions {
+			dep := &rbg.Spec.Roles[i].DependencyConditions[j]
+			if dep.IsExternal() {
+				keys = append(keys, rbg.GetDependencyRBG(dep).String())
+			}
+		}
+	}
+	return keys
+}
+
 func RegisterFieldIndexes(c cache.Cache) error {
 	var (
 		err error
@@ -35,6 +56,12 @@ func RegisterFieldIndexes(c cache.Cache) error {
 		if err = c.IndexField(ctx, &v1.Pod{}, IndexNameForOwnerRefUID, ownerIndexFunc); err != nil {
 			return
 		}
+		// rbg cross-rbg dependencies
+		if err = c.IndexField(
+			ctx, &workloadsv1alpha1.RoleBasedGroup{}, IndexNameForDependencyRBG, DependencyRBGIndexFunc,
+		); err != nil {
+			return
+		}
 	})
 	return err
 }
@@ -634,6 +634,32 @@ func TestGetRolesRevisionHash(t *testing.T) {
 			assert.Equal(t, result1, result2)
 		}
 	})
+
+	t.Run("LeaderWorkerSetPoliciesChangeHash", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		_ = workloadsv1alpha1.AddToScheme(scheme)
+		_ = appsv1.AddToScheme(scheme)
+		ctx := context.Background()
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		revision, err := NewRevision(ctx, client, getRBG(), nil)
+		assert.NoError(t, err)
+		result, err := GetRolesRevisionHash(revision)
+		assert.NoError(t, err)
+
+		rbgDiff := getRBG()
+		rbgDiff.Spec.Roles[1].LeaderWorkerSet.StartupPolicy = workloadsv1alpha1.LeaderReadyStartupPolicy
+		rbgDiff.Spec.Roles[2].LeaderWorkerSet.SubGroupPolicy = &workloadsv1alpha1.LeaderWorkerSubGroupPolicy{
+			SubGroupSize: ptr.To[int32](2),
+		}
+		revisionDiff, err := NewRevision(ctx, client, rbgDiff, nil)
+		assert.NoError(t, err)
+		resultDiff, err := GetRolesRevisionHash(revisionDiff)
+		assert.NoError(t, err)
+		assert.Equal(t, result["router"], resultDiff["router"])
+		assert.NotEqual(t, result["decode"], resultDiff["decode"])
+		assert.NotEqual(t, result["prefill"], resultDiff["prefill"])
+	})
 }
 
 func getRBG() *workloadsv1alpha1.RoleBasedGroup {
@@ -65,6 +65,13 @@ func (rbgWrapper *RoleBasedGroupWrapper) WithVolcanoGangScheduling(priorityClass
 	return rbgWrapper
 }
 
+func (rbgWrapper *RoleBasedGroupWrapper) WithPodGroupScope(scope workloadsv1alpha.PodGroupScopeType) *RoleBasedGroupWrapper {
+	if rbgWrapper.Spec.PodGroupPolicy != nil {
+		rbgWrapper.Spec.PodGroupPolicy.Scope = scope
+	}
+	return rbgWrapper
+}
+
 func (rbgWrapper *RoleBasedGroupWrapper) WithDeletionTimestamp() *RoleBasedGroupWrapper {
 	rbgWrapper.DeletionTimestamp = &v1.Time{Time: time.Now()}
 	return rbgWrapper
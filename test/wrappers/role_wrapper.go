@@ -55,6 +55,16 @@ func (roleWrapper *RoleWrapper) WithDependencies(dependencies []string) *RoleWra
 	return roleWrapper
 }
 
+func (roleWrapper *RoleWrapper) WithDependency(dependency workloadsv1alpha.RoleDependency) *RoleWrapper {
+	roleWrapper.DependencyConditions = append(roleWrapper.DependencyConditions, dependency)
+	return roleWrapper
+}
+
+func (roleWrapper *RoleWrapper) WithMinAvailable(minAvailable int32) *RoleWrapper {
+	roleWrapper.MinAvailable = ptr.To(minAvailable)
+	return roleWrapper
+}
+
 func (roleWrapper *RoleWrapper) WithRollingUpdate(rollingUpdate workloadsv1alpha.RollingUpdate) *RoleWrapper {
 	roleWrapper.RolloutStrategy = &workloadsv1alpha.RolloutStrategy{
 		Type:          workloadsv1alpha.RollingUpdateStrategyType,
@@ -85,6 +95,11 @@ func (roleWrapper *RoleWrapper) WithWorkload(workloadType string) *RoleWrapper {
 			APIVersion: "leaderworkerset.x-k8s.io/v1",
 			Kind:       "LeaderWorkerSet",
 		}
+	case workloadsv1alpha.JobWorkloadType:
+		roleWrapper.Workload = workloadsv1alpha.WorkloadSpec{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		}
 	default:
 		panic(fmt.Sprintf("workload type not supported: %s", workloadType))
 	}